@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+)
+
+const configUsage = "usage: chat-service config print|validate [-config path] | chat-service config schema"
+
+// runConfig выполняет команды работы с конфигурацией.
+//
+//	chat-service config print -config configs/config.toml
+//	chat-service config validate -config configs/config.toml
+//	chat-service config schema > configs/config.schema.json
+func runConfig(args []string) error {
+	if len(args) == 0 {
+		return errors.New(configUsage)
+	}
+
+	switch args[0] {
+	case "print", "validate":
+	case "schema":
+		schema, err := config.JSONSchema()
+		if err != nil {
+			return fmt.Errorf("build schema: %v", err)
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(schema))
+		return err
+	default:
+		return errors.New(configUsage)
+	}
+
+	fs := flag.NewFlagSet("config "+args[0], flag.ContinueOnError)
+	cfgPath := fs.String("config", "configs/config.toml", "Path to config file")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	// Проверяется итоговый конфиг: файл с наложенными переменными окружения и секретами из файлов.
+	cfg, err := config.ParseAndValidate(*cfgPath)
+	if err != nil {
+		return fmt.Errorf("parse and validate config %q: %v", *cfgPath, err)
+	}
+
+	if args[0] == "validate" {
+		_, err = fmt.Fprintf(os.Stdout, "config %q is valid\n", *cfgPath)
+		return err
+	}
+	return config.WriteMasked(os.Stdout, cfg)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/logger"
+)
+
+// logSinkOptions возвращает опции логгера для дополнительных sink-ов: файла, семплирования и маскирования полей.
+func logSinkOptions(cfg config.LogConfig) []logger.OptOptionsSetter {
+	opts := []logger.OptOptionsSetter{
+		logger.WithRedactFields(cfg.RedactFields),
+	}
+	if cfg.File.Enabled {
+		opts = append(opts, logger.WithFileSink(logger.FileSink{
+			Path:       cfg.File.Path,
+			MaxSizeMB:  cfg.File.MaxSizeMB,
+			MaxAgeDays: cfg.File.MaxAgeDays,
+			MaxBackups: cfg.File.MaxBackups,
+			Compress:   cfg.File.Compress,
+		}))
+	}
+	if cfg.Sampling.Enabled {
+		opts = append(opts, logger.WithSampling(logger.Sampling{
+			Tick:       cfg.Sampling.Tick,
+			Initial:    cfg.Sampling.Initial,
+			Thereafter: cfg.Sampling.Thereafter,
+		}))
+	}
+	return opts
+}
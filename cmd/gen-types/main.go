@@ -159,6 +159,12 @@ func Parse[T any](s string) (T, error) {
 		return any(ProblemID(id)).(T), nil
 	case UserID:
 		return any(UserID(id)).(T), nil
+	case AttachmentID:
+		return any(AttachmentID(id)).(T), nil
+	case RequestID:
+		return any(RequestID(id)).(T), nil
+	case AuditEventID:
+		return any(AuditEventID(id)).(T), nil
 	default:
 		return any(id).(T), nil
 	}
@@ -182,6 +188,12 @@ func MustParse[T any](s string) T {
 		return any(ProblemID(id)).(T)
 	case UserID:
 		return any(UserID(id)).(T)
+	case AttachmentID:
+		return any(AttachmentID(id)).(T)
+	case RequestID:
+		return any(RequestID(id)).(T)
+	case AuditEventID:
+		return any(AuditEventID(id)).(T)
 	default:
 		return any(id).(T)
 	}
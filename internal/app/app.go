@@ -0,0 +1,366 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"entgo.io/ent/dialect"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+
+	keycloakclient "github.com/FischukSergey/chat-service/internal/clients/keycloak"
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	"github.com/FischukSergey/chat-service/internal/ratelimit"
+	problemsrepo "github.com/FischukSergey/chat-service/internal/repositories/problems"
+	serverclient "github.com/FischukSergey/chat-service/internal/server-client"
+	clientv1 "github.com/FischukSergey/chat-service/internal/server-client/v1"
+	serverdebug "github.com/FischukSergey/chat-service/internal/server-debug"
+	"github.com/FischukSergey/chat-service/internal/servertls"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/services/messenger"
+	"github.com/FischukSergey/chat-service/internal/services/normalizer"
+	"github.com/FischukSergey/chat-service/internal/services/presence"
+	"github.com/FischukSergey/chat-service/internal/services/typing"
+	"github.com/FischukSergey/chat-service/internal/store"
+)
+
+//go:generate options-gen -out-filename=app_options.gen.go -from-struct=Options
+type Options struct {
+	cfg config.Config `option:"mandatory"`
+	// configPath - файл конфига, изменения которого применяются без перезапуска. Если не задан, конфиг не перечитывается.
+	configPath string
+	// store - готовый клиент хранилища, например SQLite в тестах. Если не задан, сервис подключается к PostgreSQL.
+	// Хуки сообщений и журнала аудита регистрируются и в готовом клиенте, закрывает его вызывающая сторона.
+	store *store.Client
+	// storeDialect - диалект store, обязателен вместе с ним.
+	storeDialect string `validate:"omitempty,oneof=postgres sqlite3"`
+	// introspector проверяет токены вместо клиента Keycloak из конфига.
+	introspector middlewares.Introspector
+}
+
+type job struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// App - сервис, собранный из конфига: серверы, фоновые задачи и координатор остановки.
+// Логгер должен быть инициализирован до New.
+type App struct {
+	srvClient *serverclient.Server
+	srvDebug  *serverdebug.Server
+	jobs      []job
+	shutdown  *shutdownCoordinator
+
+	jobFailed     chan struct{}
+	jobFailedOnce sync.Once
+}
+
+// New создает сервис. Если создать сервис не удалось, уже открытые ресурсы закрываются.
+func New(ctx context.Context, opts Options) (_ *App, errReturned error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	if opts.store != nil && opts.storeDialect == "" {
+		return nil, errors.New("validate options: store dialect is required with store")
+	}
+	cfg := opts.cfg
+
+	a := &App{jobFailed: make(chan struct{})}
+	// Ошибка фоновой задачи запускает остановку.
+	a.shutdown = newShutdownCoordinator(func() {
+		a.jobFailedOnce.Do(func() { close(a.jobFailed) })
+	})
+	defer func() {
+		if errReturned != nil {
+			errReturned = errors.Join(errReturned, a.shutdown.close())
+		}
+	}()
+
+	if cfg.Servers.DrainDelay != 0 {
+		logger.Named("config").Warn("servers.drain_delay is deprecated, use shutdown.drain_delay")
+	}
+
+	shutdownTracing, err := initTracing(ctx, cfg.Tracing, cfg.Global.Env)
+	if err != nil {
+		return nil, fmt.Errorf("init tracing: %v", err)
+	}
+	a.shutdown.addCloser("tracing", shutdownTracing)
+
+	metricsRegistry := initMetricsRegistry()
+
+	// Загружаем Swagger спецификацию
+	swagger, err := clientv1.GetSwagger()
+	if err != nil {
+		return nil, fmt.Errorf("loading swagger spec: %w", err)
+	}
+	// Очищаем серверы из спецификации для избежания конфликтов
+	swagger.Servers = nil
+	if err := setMessageBodyMaxLength(swagger, cfg.Services.Messages.MaxBodyLength); err != nil {
+		return nil, fmt.Errorf("set message body max length: %v", err)
+	}
+
+	// Инициализируем Keycloak клиент, если токены не проверяет переданный Introspector
+	introspector := opts.introspector
+	var keycloakClient *keycloakclient.Client
+	if introspector == nil {
+		if keycloakClient, err = initKeycloakClient(cfg.Clients.Keycloak, metricsRegistry); err != nil {
+			return nil, fmt.Errorf("init keycloak client: %v", err)
+		}
+		introspector = keycloakClient
+	}
+
+	// init store
+	encryptor, err := NewEncryptor(cfg.Services.Encryption)
+	if err != nil {
+		return nil, fmt.Errorf("init encryptor: %v", err)
+	}
+	msgNormalizer, err := normalizer.New(normalizer.NewOptions(cfg.Services.Messages.MaxBodyLength))
+	if err != nil {
+		return nil, fmt.Errorf("init normalizer: %v", err)
+	}
+	// Пул соединений известен только для собственного подключения:
+	// для готового клиента проверка готовности БД и метрики пула не регистрируются.
+	storage, storeDialect := opts.store, opts.storeDialect
+	var db *sql.DB
+	if storage == nil {
+		if storage, db, err = openStore(ctx, cfg.Clients.PSQL); err != nil {
+			return nil, fmt.Errorf("init store: %v", err)
+		}
+		storeDialect = dialect.Postgres
+		a.shutdown.addCloser("store", storage.Close)
+	}
+	// init audit: хуки хранилища ставят события в очередь журнала
+	auditRecorder, err := audit.New(audit.NewOptions(storage, cfg.Services.Audit.Retention))
+	if err != nil {
+		return nil, fmt.Errorf("init audit: %v", err)
+	}
+
+	if err := useStoreHooks(storage, auditRecorder, msgNormalizer, cfg.Services.Redactor, encryptor); err != nil {
+		return nil, fmt.Errorf("init store hooks: %v", err)
+	}
+	registerDBStats(metricsRegistry, db)
+
+	// init messages repo
+	messagesRepo, searchEnabled, err := initMessagesRepo(ctx, storage, storeDialect, encryptor != nil)
+	if err != nil {
+		return nil, fmt.Errorf("init messages repo: %v", err)
+	}
+
+	// init attachments
+	attachmentsSvc, blobHandler, err := initAttachments(cfg.Services.Attachments, storage)
+	if err != nil {
+		return nil, fmt.Errorf("init attachments: %v", err)
+	}
+
+	// init presence & typing
+	eventStream, err := eventstream.New(eventstream.NewOptions())
+	if err != nil {
+		return nil, fmt.Errorf("init event stream: %v", err)
+	}
+	presenceSvc, err := presence.New(presence.NewOptions(
+		cfg.Services.Presence.OnlineTTL,
+		cfg.Services.Presence.Retention,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("init presence: %v", err)
+	}
+	typingSvc, err := typing.New(typing.NewOptions(
+		storage,
+		eventStream,
+		typing.WithThrottle(cfg.Services.Typing.Throttle),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("init typing: %v", err)
+	}
+
+	// init messenger
+	messengerSvc, err := messenger.New(messenger.NewOptions(storage, messagesRepo, eventStream))
+	if err != nil {
+		return nil, fmt.Errorf("init messenger: %v", err)
+	}
+
+	// init health
+	healthSvc, err := initHealth(cfg.Health, db, keycloakClient, auditRecorder)
+	if err != nil {
+		return nil, fmt.Errorf("init health: %v", err)
+	}
+
+	// init rate limit store
+	var rateLimitStore *ratelimit.MemoryStore
+	if cfg.Servers.Client.RateLimit.Enabled {
+		if rateLimitStore, err = ratelimit.NewMemoryStore(ratelimit.NewMemoryStoreOptions()); err != nil {
+			return nil, fmt.Errorf("init rate limit store: %v", err)
+		}
+	}
+
+	// init servers tls
+	clientCfg, debugCfg := cfg.Servers.Client, cfg.Servers.Debug
+	clientTLS, err := initServerTLS(nameServerClient,
+		clientCfg.TLSCertFile, clientCfg.TLSKeyFile, clientCfg.TLSMinVersion, "")
+	if err != nil {
+		return nil, fmt.Errorf("init server client tls: %v", err)
+	}
+	debugTLS, err := initServerTLS("server-debug",
+		debugCfg.TLSCertFile, debugCfg.TLSKeyFile, debugCfg.TLSMinVersion, debugCfg.TLSClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("init debug server tls: %v", err)
+	}
+
+	// init problems repo
+	problemsRepo, err := problemsrepo.New(problemsrepo.NewOptions(storage))
+	if err != nil {
+		return nil, fmt.Errorf("init problems repo: %v", err)
+	}
+
+	// init server client
+	a.srvClient, err = initServerClient(
+		clientCfg,
+		swagger,
+		introspector,
+		attachmentsSvc,
+		blobHandler,
+		messagesRepo,
+		searchEnabled,
+		problemsRepo,
+		messengerSvc,
+		eventStream,
+		typingSvc,
+		presenceSvc,
+		auditRecorder,
+		rateLimitStore,
+		clientTLS,
+		metricsRegistry,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("init server client: %v", err)
+	}
+
+	// init debug server
+	debugOptions := []serverdebug.OptOptionsSetter{
+		serverdebug.WithMetricsGatherer(metricsRegistry),
+		serverdebug.WithHealth(healthSvc),
+		serverdebug.WithRouteProviders(map[string]serverdebug.RoutesProvider{nameServerClient: a.srvClient}),
+		serverdebug.WithRealtime(eventStream),
+		serverdebug.WithPresence(presenceSvc),
+		serverdebug.WithWorkload(problemsRepo),
+		serverdebug.WithSpecs(map[string]*openapi3.T{"client": swagger}),
+		serverdebug.WithAudit(auditRecorder),
+		serverdebug.WithBasicAuthUsername(debugCfg.BasicAuth.Username),
+		serverdebug.WithBasicAuthPassword(debugCfg.BasicAuth.Password),
+		serverdebug.WithH2c(debugCfg.H2C),
+	}
+
+	// init config reloader
+	if opts.configPath != "" {
+		cfgReloader, err := initConfigReloader(opts.configPath, cfg, a.srvClient, auditRecorder)
+		if err != nil {
+			return nil, fmt.Errorf("init config reloader: %v", err)
+		}
+		debugOptions = append(debugOptions, serverdebug.WithCfg(cfgReloader))
+		a.jobs = append(a.jobs, job{name: "config-reloader", fn: cfgReloader.Run})
+	}
+
+	// TLS и проверка клиентских сертификатов включаются, если в конфиге задан сертификат
+	if debugTLS != nil {
+		debugOptions = append(debugOptions, serverdebug.WithTlsConfig(debugTLS.TLSConfig()))
+	}
+	a.srvDebug, err = serverdebug.New(serverdebug.NewOptions(debugCfg.Addr, debugOptions...))
+	if err != nil {
+		return nil, fmt.Errorf("init debug server: %v", err)
+	}
+
+	// Background jobs.
+	a.jobs = append(a.jobs,
+		job{name: "presence", fn: presenceSvc.Run},
+		job{name: "audit", fn: auditRecorder.Run},
+	)
+	for _, certs := range []*servertls.CertReloader{clientTLS, debugTLS} {
+		if certs != nil {
+			a.jobs = append(a.jobs, job{name: "tls-reloader", fn: certs.Run})
+		}
+	}
+	if rateLimitStore != nil {
+		a.jobs = append(a.jobs, job{name: "rate-limit", fn: rateLimitStore.Run})
+	}
+
+	// Отладочный сервер останавливается последним, чтобы пробы и метрики были доступны во время остановки.
+	shutdownCfg := cfg.Shutdown
+	a.shutdown.addPhase("mark not ready", shutdownCfg.DrainDelay, func(ctx context.Context) error {
+		healthSvc.SetShuttingDown()
+		return sleepPhase(ctx)
+	})
+	// Слушающие сокеты закрываются до кадров закрытия realtime-клиентам, а активные запросы ожидаются после:
+	// иначе клиенты получили бы кадр закрытия только после самого долгого запроса.
+	a.shutdown.addPhase("close realtime clients", shutdownCfg.RealtimeTimeout, func(ctx context.Context) error {
+		a.srvClient.StopAccepting()
+		return eventStream.Close(ctx)
+	})
+	a.shutdown.addPhase("stop client server", shutdownCfg.HTTPTimeout, a.srvClient.Shutdown)
+	a.shutdown.addJobsPhase(shutdownCfg.JobsTimeout)
+	a.shutdown.addPhase("stop debug server", shutdownCfg.HTTPTimeout, a.srvDebug.Shutdown)
+
+	return a, nil
+}
+
+// Run запускает фоновые задачи и серверы и работает до завершения ctx, ошибки сервера или фоновой задачи,
+// после чего останавливает сервис по этапам. Ресурсы после Run закрывает Close.
+func (a *App) Run(ctx context.Context) error {
+	for _, j := range a.jobs {
+		a.shutdown.goJob(j.name, j.fn)
+	}
+
+	eg, ctx := errgroup.WithContext(ctx)
+
+	// Run servers.
+	eg.Go(a.srvDebug.Run)
+	eg.Go(a.srvClient.Run)
+
+	// Остановка начинается по сигналу, при ошибке сервера или фоновой задачи.
+	eg.Go(func() error {
+		select {
+		case <-ctx.Done():
+		case <-a.jobFailed:
+		}
+		return a.shutdown.run()
+	})
+
+	return eg.Wait()
+}
+
+// Close закрывает ресурсы и сбрасывает логгер, в том числе отправляя накопленные события Sentry.
+// Вызывается и после Run, и если Run не запускался.
+func (a *App) Close() error {
+	return a.shutdown.close()
+}
+
+// initKeycloakClient инициализирует клиент для Keycloak.
+func initKeycloakClient(
+	cfg config.KeycloakConfig,
+	metricsRegisterer prometheus.Registerer,
+) (*keycloakclient.Client, error) {
+	lg := logger.Named("keycloak-client")
+
+	// Отладочный режим в окружении prod запрещен валидацией конфига.
+	// Создаем клиент Keycloak
+	client, err := keycloakclient.New(keycloakclient.NewOptions(
+		cfg.BasePath,
+		cfg.Realm,
+		cfg.ClientID,
+		cfg.ClientSecret,
+		keycloakclient.WithDebugMode(cfg.DebugMode),
+		keycloakclient.WithMetricsRegisterer(metricsRegisterer),
+		keycloakclient.WithLogger(lg),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("create keycloak client: %v", err)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,76 @@
+// Code generated by options-gen. DO NOT EDIT.
+package app
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	"github.com/FischukSergey/chat-service/internal/store"
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	cfg config.Config,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from field tag (if present)
+
+	o.cfg = cfg
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// configPath - файл конфига, изменения которого применяются без перезапуска. Если не задан, конфиг не перечитывается.
+func WithConfigPath(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.configPath = opt
+
+	}
+}
+
+// store - готовый клиент хранилища, например SQLite в тестах. Если не задан, сервис подключается к PostgreSQL.
+// Хуки сообщений и журнала аудита регистрируются и в готовом клиенте, закрывает его вызывающая сторона.
+func WithStore(opt *store.Client) OptOptionsSetter {
+	return func(o *Options) {
+		o.store = opt
+
+	}
+}
+
+// storeDialect - диалект store, обязателен вместе с ним.
+func WithStoreDialect(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.storeDialect = opt
+
+	}
+}
+
+// introspector проверяет токены вместо клиента Keycloak из конфига.
+func WithIntrospector(opt middlewares.Introspector) OptOptionsSetter {
+	return func(o *Options) {
+		o.introspector = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("storeDialect", _validate_Options_storeDialect(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_storeDialect(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.storeDialect, "omitempty,oneof=postgres sqlite3"); err != nil {
+		return fmt461e464ebed9.Errorf("field `storeDialect` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/FischukSergey/chat-service/internal/blobstore"
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/services/attachments"
+	"github.com/FischukSergey/chat-service/internal/store"
+)
+
+// initAttachments создает хранилище вложений и сервис загрузки.
+// Для локального хранилища также возвращается обработчик ссылок на скачивание,
+// который нужно зарегистрировать на клиентском сервере.
+func initAttachments(cfg config.AttachmentsConfig, storage *store.Client) (*attachments.Service, http.Handler, error) {
+	var (
+		blobs       blobstore.BlobStore
+		blobHandler http.Handler
+	)
+
+	switch cfg.Storage {
+	case "local":
+		local, err := blobstore.NewLocal(blobstore.NewLocalOptions(
+			cfg.Local.Dir,
+			cfg.Local.BaseURL,
+			[]byte(cfg.Local.SigningKey),
+		))
+		if err != nil {
+			return nil, nil, fmt.Errorf("create local blob store: %v", err)
+		}
+		blobs, blobHandler = local, local
+
+	case "s3":
+		s3, err := blobstore.NewS3(blobstore.NewS3Options(
+			cfg.S3.Endpoint,
+			cfg.S3.AccessKey,
+			cfg.S3.SecretKey,
+			cfg.S3.Bucket,
+			blobstore.WithRegion(cfg.S3.Region),
+			blobstore.WithUseSSL(cfg.S3.UseSSL),
+		))
+		if err != nil {
+			return nil, nil, fmt.Errorf("create s3 blob store: %v", err)
+		}
+		blobs = s3
+
+	default:
+		return nil, nil, fmt.Errorf("unknown blob storage %q", cfg.Storage)
+	}
+
+	svc, err := attachments.New(attachments.NewOptions(
+		storage,
+		blobs,
+		cfg.MaxSize,
+		cfg.AllowedMIMETypes,
+		cfg.URLTTL,
+	))
+	if err != nil {
+		return nil, nil, fmt.Errorf("create attachments service: %v", err)
+	}
+
+	return svc, blobHandler, nil
+}
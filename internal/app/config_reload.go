@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/config/reloader"
+	"github.com/FischukSergey/chat-service/internal/logger"
+	serverclient "github.com/FischukSergey/chat-service/internal/server-client"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+)
+
+// initConfigReloader создает перечитывание конфига и подписывает на него компоненты,
+// настройки которых меняются без перезапуска: уровень логирования, CORS и лимиты клиентского сервера.
+// Емкости менеджеров среди них нет: в сервисе нет распределения проблем по менеджерам, которое бы ее учитывало,
+// поэтому и настройки для нее в конфиге нет.
+func initConfigReloader(
+	path string,
+	cfg config.Config,
+	srvClient *serverclient.Server,
+	auditRecorder *audit.Recorder,
+) (*reloader.Reloader, error) {
+	// Изменения конфига попадают в журнал аудита так же, как изменения через отладочный сервер.
+	// Событие ставится в очередь после применения конфига: ошибка записи журнала не откатывает перечитывание.
+	onApplied := func(changes []config.Change) {
+		fields := make([]string, 0, len(changes))
+		for _, c := range changes {
+			fields = append(fields, c.Path)
+		}
+		auditRecorder.Enqueue(audit.Event{
+			Action:   audit.ActionConfigChanged,
+			Target:   "config",
+			Metadata: map[string]any{"fields": fields},
+		})
+	}
+
+	r, err := reloader.New(reloader.NewOptions(path, cfg, reloader.WithOnApplied(onApplied)))
+	if err != nil {
+		return nil, fmt.Errorf("create reloader: %v", err)
+	}
+
+	r.Subscribe("log-level", func(_ context.Context, oldCfg, newCfg config.Config) error {
+		if oldCfg.Log.Level == newCfg.Log.Level {
+			return nil
+		}
+		return logger.GlobalLevel.UnmarshalText([]byte(newCfg.Log.Level))
+	})
+
+	r.Subscribe(nameServerClient, func(_ context.Context, oldCfg, newCfg config.Config) error {
+		oldSrv, newSrv := oldCfg.Servers.Client, newCfg.Servers.Client
+		if !slices.Equal(oldSrv.AllowOrigins, newSrv.AllowOrigins) {
+			srvClient.SetAllowOrigins(newSrv.AllowOrigins)
+		}
+		if !reflect.DeepEqual(oldSrv.RateLimit, newSrv.RateLimit) {
+			return srvClient.SetRateLimitPolicies(rateLimitPolicies(newSrv.RateLimit))
+		}
+		return nil
+	})
+
+	return r, nil
+}
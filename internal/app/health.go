@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	keycloakclient "github.com/FischukSergey/chat-service/internal/clients/keycloak"
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/health"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+)
+
+// initHealth регистрирует проверки зависимостей для readiness-пробы.
+// db и keycloakClient могут быть nil: готовый клиент хранилища передан извне
+// или токены проверяет другой Introspector.
+func initHealth(
+	cfg config.HealthConfig,
+	db *sql.DB,
+	keycloakClient *keycloakclient.Client,
+	auditRecorder *audit.Recorder,
+) (*health.Health, error) {
+	h, err := health.New(health.NewOptions(health.WithDefaultTimeout(cfg.CheckTimeout)))
+	if err != nil {
+		return nil, fmt.Errorf("create health: %v", err)
+	}
+
+	if db != nil {
+		h.Register("db", cfg.CheckTimeout, db.PingContext)
+	}
+	if keycloakClient != nil {
+		h.Register("keycloak", cfg.CheckTimeout, keycloakClient.CheckHealth)
+	}
+	// Фоновая очередь сервиса - очередь записи журнала аудита: если она не разбирается, отказы в аутентификации теряются.
+	h.Register("audit-queue", cfg.CheckTimeout, func(context.Context) error {
+		if lag := auditRecorder.QueueLag(); lag > cfg.AuditQueueMaxLag {
+			return fmt.Errorf("audit queue lag %s exceeds %s", lag.Round(time.Second), cfg.AuditQueueMaxLag)
+		}
+		return nil
+	})
+
+	return h, nil
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"database/sql"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const dbStatsName = "chat_service"
+
+// initMetricsRegistry создает реестр метрик с метриками рантайма Go и процесса.
+func initMetricsRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
+
+// registerDBStats регистрирует метрики пула соединений с БД. db может быть nil, если пул сервису неизвестен.
+func registerDBStats(reg prometheus.Registerer, db *sql.DB) {
+	if db != nil {
+		reg.MustRegister(collectors.NewDBStatsCollector(db, dbStatsName))
+	}
+}
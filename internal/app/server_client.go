@@ -0,0 +1,218 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	"github.com/FischukSergey/chat-service/internal/ratelimit"
+	messagesrepo "github.com/FischukSergey/chat-service/internal/repositories/messages"
+	problemsrepo "github.com/FischukSergey/chat-service/internal/repositories/problems"
+	serverclient "github.com/FischukSergey/chat-service/internal/server-client"
+	clientv1 "github.com/FischukSergey/chat-service/internal/server-client/v1"
+	"github.com/FischukSergey/chat-service/internal/servertls"
+	"github.com/FischukSergey/chat-service/internal/services/attachments"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/services/messenger"
+	"github.com/FischukSergey/chat-service/internal/services/presence"
+	"github.com/FischukSergey/chat-service/internal/services/typing"
+	websocketstream "github.com/FischukSergey/chat-service/internal/websocket-stream"
+)
+
+const nameServerClient = "server-client"
+
+func initServerClient(
+	cfg config.ClientServerConfig,
+	v1Swagger *openapi3.T,
+	keycloakIntrospector middlewares.Introspector,
+	attachmentsSvc *attachments.Service,
+	blobHandler http.Handler,
+	messagesRepo *messagesrepo.Repo,
+	searchEnabled bool,
+	problemsRepo *problemsrepo.Repo,
+	messengerSvc *messenger.Service,
+	eventStream *eventstream.Stream,
+	typingSvc *typing.Service,
+	presenceSvc *presence.Presence,
+	auditRecorder *audit.Recorder,
+	rateLimitStore *ratelimit.MemoryStore,
+	tlsCerts *servertls.CertReloader,
+	metricsRegisterer prometheus.Registerer,
+) (*serverclient.Server, error) {
+	lg := logger.Named(nameServerClient)
+
+	var handlersOptions []clientv1.OptOptionsSetter
+	// Поиск недоступен при шифровании сообщений
+	if searchEnabled {
+		handlersOptions = append(handlersOptions, clientv1.WithSearch(messagesRepo))
+	}
+	// Журнал аудита доступен менеджерам
+	if auditRecorder != nil {
+		handlersOptions = append(handlersOptions, clientv1.WithAudit(auditRecorder))
+	}
+
+	v1Handlers, err := clientv1.NewHandlers(clientv1.NewOptions(
+		lg, attachmentsSvc, typingSvc, problemsRepo, presenceSvc, messengerSvc, handlersOptions...))
+	if err != nil {
+		return nil, fmt.Errorf("create v1 handlers: %v", err)
+	}
+
+	// События доставляются клиентам и менеджерам через WebSocket, сигналы "печатает" приходят оттуда же.
+	realtime, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
+		lg.Named("ws"), eventStream, websocketstream.NewClientSignals(typingSvc),
+		websocketstream.WithPresence(presenceSvc)))
+	if err != nil {
+		return nil, fmt.Errorf("create client websocket handler: %v", err)
+	}
+	managerRealtime, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
+		lg.Named("manager-ws"), eventStream, websocketstream.NewManagerSignals(typingSvc),
+		websocketstream.WithPresence(presenceSvc)))
+	if err != nil {
+		return nil, fmt.Errorf("create manager websocket handler: %v", err)
+	}
+
+	trustedProxies := make([]*net.IPNet, 0, len(cfg.TrustedProxies))
+	for _, cidr := range cfg.TrustedProxies {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("parse trusted proxy %q: %v", cidr, err)
+		}
+		trustedProxies = append(trustedProxies, n)
+	}
+
+	// Создаем опции для сервера
+	options := []serverclient.OptOptionsSetter{
+		serverclient.WithPresence(presenceSvc),
+		serverclient.WithRealtime(realtime),
+		serverclient.WithManagerRealtime(managerRealtime),
+		serverclient.WithMetricsRegisterer(metricsRegisterer),
+		serverclient.WithH2c(cfg.H2C),
+		serverclient.WithSecureHeaders(secureHeadersPolicy(cfg.SecureHeaders)),
+		serverclient.WithRoutes(routePolicies(cfg.Routes)),
+		serverclient.WithTrustedProxies(trustedProxies),
+	}
+	// TLS включается, если в конфиге задан сертификат
+	if tlsCerts != nil {
+		options = append(options, serverclient.WithTlsConfig(tlsCerts.TLSConfig()))
+	}
+	// Частота запросов ограничивается, только если создано хранилище лимитов
+	if rateLimitStore != nil {
+		options = append(options,
+			serverclient.WithRateLimitStore(rateLimitStore),
+			serverclient.WithRateLimitPolicies(rateLimitPolicies(cfg.RateLimit)),
+		)
+	}
+	// Отказы в аутентификации попадают в журнал аудита
+	if auditRecorder != nil {
+		options = append(options, serverclient.WithAuditRecorder(auditRecorder))
+	}
+
+	// Добавляем опцию для Keycloak, если клиент определен
+	if keycloakIntrospector != nil {
+		options = append(options, serverclient.WithKeycloakIntrospector(keycloakIntrospector))
+	}
+	// Ошибки запросов отправляются в Sentry с контекстом запроса
+	if logger.SentryClient != nil {
+		options = append(options, serverclient.WithSentryClient(logger.SentryClient))
+	}
+	// Локальное хранилище вложений отдает файлы через клиентский сервер
+	if blobHandler != nil {
+		options = append(options, serverclient.WithBlobHandler(blobHandler))
+	}
+
+	// Создаем сервер
+	srv, err := serverclient.New(serverclient.NewOptions(
+		lg,
+		cfg.Addr,
+		cfg.AllowOrigins,
+		corsPolicy(cfg.CORS),
+		cfg.BodyLimit,
+		v1Swagger,
+		v1Handlers,
+		attachmentsSvc.MaxSize(),
+		options...,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("build server: %v", err)
+	}
+
+	return srv, nil
+}
+
+func rateLimitPolicies(cfg config.RateLimitConfig) ratelimit.Policies {
+	policy := func(p config.RateLimitPolicyConfig) ratelimit.Policy {
+		return ratelimit.Policy{Rate: rate.Limit(p.Rate), Burst: p.Burst}
+	}
+
+	res := ratelimit.Policies{
+		PerIP:      ratelimit.Policy{Rate: rate.Limit(cfg.PerIP.Rate), Burst: cfg.PerIP.Burst},
+		Default:    policy(cfg.Default),
+		Operations: make(map[string]ratelimit.Policy, len(cfg.Operations)),
+	}
+	for operation, p := range cfg.Operations {
+		res.Operations[operation] = policy(p)
+	}
+	return res
+}
+
+func corsPolicy(cfg config.CORSConfig) serverclient.CORSPolicy {
+	return serverclient.CORSPolicy{
+		AllowMethods:     cfg.AllowMethods,
+		AllowHeaders:     cfg.AllowHeaders,
+		ExposeHeaders:    cfg.ExposeHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		MaxAge:           cfg.MaxAge,
+	}
+}
+
+func secureHeadersPolicy(cfg config.SecureHeadersConfig) serverclient.SecureHeadersPolicy {
+	return serverclient.SecureHeadersPolicy{
+		HSTSMaxAge:            cfg.HSTSMaxAge,
+		HSTSIncludeSubdomains: cfg.HSTSIncludeSubdomains,
+		HSTSPreload:           cfg.HSTSPreload,
+		ContentSecurityPolicy: cfg.ContentSecurityPolicy,
+		ContentTypeNosniff:    cfg.ContentTypeNosniff,
+		FrameOptions:          cfg.FrameOptions,
+		ReferrerPolicy:        cfg.ReferrerPolicy,
+	}
+}
+
+func routePolicies(cfg map[string]config.ClientRouteConfig) map[string]serverclient.RoutePolicy {
+	res := make(map[string]serverclient.RoutePolicy, len(cfg))
+	for operation, r := range cfg {
+		p := serverclient.RoutePolicy{BodyLimit: r.BodyLimit}
+		if r.CORS != nil {
+			cors := corsPolicy(*r.CORS)
+			p.CORS = &cors
+		}
+		if r.SecureHeaders != nil {
+			secureHeaders := secureHeadersPolicy(*r.SecureHeaders)
+			p.SecureHeaders = &secureHeaders
+		}
+		res[operation] = p
+	}
+	return res
+}
+
+// setMessageBodyMaxLength выставляет в спецификации ограничение длины тела сообщения из конфигурации,
+// чтобы валидатор запросов и опубликованная спецификация совпадали с проверкой нормализатора.
+// Схема MessageBody используется и в запросах отправки, и в ответах.
+func setMessageBodyMaxLength(swagger *openapi3.T, maxLength int) error {
+	body, ok := swagger.Components.Schemas["MessageBody"]
+	if !ok || body.Value == nil {
+		return errors.New("no MessageBody schema")
+	}
+
+	n := uint64(maxLength) //nolint:gosec // max length is validated to be positive
+	body.Value.MaxLength = &n
+	return nil
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+)
+
+// shutdownPhase - этап остановки сервиса. Этап получает контекст со своим таймаутом.
+type shutdownPhase struct {
+	name    string
+	timeout time.Duration
+	fn      func(ctx context.Context) error
+}
+
+type shutdownCloser struct {
+	name string
+	fn   func() error
+}
+
+// shutdownCoordinator останавливает сервис по этапам: каждый этап ограничен своим таймаутом,
+// ошибка или таймаут этапа логируется и не мешает следующим этапам.
+// После этапов в обратном порядке закрываются ресурсы (хранилище, трассировка), последним сбрасывается логгер.
+type shutdownCoordinator struct {
+	lg *zap.Logger
+
+	phases  []shutdownPhase
+	closers []shutdownCloser
+
+	// jobsCtx отменяется на этапе остановки фоновых задач.
+	jobsCtx    context.Context //nolint:containedctx // jobs live as long as the coordinator
+	stopJobs   context.CancelFunc
+	jobs       sync.WaitGroup
+	jobsErrMu  sync.Mutex
+	jobsErr    error
+	onJobError func()
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// newShutdownCoordinator создает координатор остановки. onJobError вызывается, если фоновая задача
+// завершилась с ошибкой, и должен запускать остановку сервиса.
+func newShutdownCoordinator(onJobError func()) *shutdownCoordinator {
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	return &shutdownCoordinator{
+		lg:         logger.Named("shutdown"),
+		jobsCtx:    jobsCtx,
+		stopJobs:   stopJobs,
+		onJobError: onJobError,
+	}
+}
+
+// addPhase добавляет этап остановки. Этапы выполняются в порядке добавления.
+func (c *shutdownCoordinator) addPhase(name string, timeout time.Duration, fn func(ctx context.Context) error) {
+	c.phases = append(c.phases, shutdownPhase{name: name, timeout: timeout, fn: fn})
+}
+
+// addCloser добавляет ресурс, который закрывается после этапов остановки. Ресурсы закрываются в обратном порядке.
+func (c *shutdownCoordinator) addCloser(name string, fn func() error) {
+	c.closers = append(c.closers, shutdownCloser{name: name, fn: fn})
+}
+
+// goJob запускает фоновую задачу. Задачи работают до этапа, добавленного addJobsPhase.
+func (c *shutdownCoordinator) goJob(name string, fn func(ctx context.Context) error) {
+	c.jobs.Add(1)
+	go func() {
+		defer c.jobs.Done()
+
+		if err := fn(c.jobsCtx); err != nil {
+			c.jobsErrMu.Lock()
+			c.jobsErr = errors.Join(c.jobsErr, fmt.Errorf("%s: %v", name, err))
+			c.jobsErrMu.Unlock()
+
+			c.lg.Error("background job failed", zap.String("job", name), zap.Error(err))
+			c.onJobError()
+		}
+	}()
+}
+
+// addJobsPhase добавляет этап, на котором фоновые задачи получают сигнал остановки
+// и могут завершить текущую работу до истечения таймаута.
+func (c *shutdownCoordinator) addJobsPhase(timeout time.Duration) {
+	c.addPhase("stop background jobs", timeout, func(ctx context.Context) error {
+		c.stopJobs()
+
+		done := make(chan struct{})
+		go func() {
+			c.jobs.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// run выполняет этапы остановки и возвращает ошибки этапов и фоновых задач.
+func (c *shutdownCoordinator) run() error {
+	c.lg.Info("shutdown started")
+	start := time.Now()
+
+	var errs []error
+	for _, p := range c.phases {
+		if err := c.runPhase(p); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", p.name, err))
+		}
+	}
+
+	c.jobsErrMu.Lock()
+	errs = append(errs, c.jobsErr)
+	c.jobsErrMu.Unlock()
+
+	c.lg.Info("shutdown finished", zap.Duration("duration", time.Since(start)))
+	return errors.Join(errs...)
+}
+
+func (c *shutdownCoordinator) runPhase(p shutdownPhase) error {
+	lg := c.lg.With(zap.String("phase", p.name), zap.Duration("timeout", p.timeout))
+	lg.Info("shutdown phase started")
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	err := p.fn(ctx)
+	if err != nil {
+		lg.Error("shutdown phase failed", zap.Duration("duration", time.Since(start)), zap.Error(err))
+		return err
+	}
+	lg.Info("shutdown phase finished", zap.Duration("duration", time.Since(start)))
+	return nil
+}
+
+// close закрывает ресурсы и сбрасывает логгер, в том числе отправляя накопленные события Sentry.
+// Вызывается и при штатной остановке, и при ошибке запуска, поэтому выполняется один раз.
+func (c *shutdownCoordinator) close() error {
+	c.closeOnce.Do(func() {
+		c.stopJobs()
+
+		var errs []error
+		for i := len(c.closers) - 1; i >= 0; i-- {
+			cl := c.closers[i]
+			if err := cl.fn(); err != nil {
+				c.lg.Error("close resource", zap.String("resource", cl.name), zap.Error(err))
+				errs = append(errs, fmt.Errorf("close %s: %v", cl.name, err))
+				continue
+			}
+			c.lg.Info("resource closed", zap.String("resource", cl.name))
+		}
+		c.closeErr = errors.Join(errs...)
+
+		logger.Sync()
+	})
+	return c.closeErr
+}
+
+// sleepPhase - этап, который просто ждет, пока не истечет таймаут.
+func sleepPhase(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/XSAM/otelsql"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/logger"
+	messagesrepo "github.com/FischukSergey/chat-service/internal/repositories/messages"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+	"github.com/FischukSergey/chat-service/internal/services/encryption"
+	"github.com/FischukSergey/chat-service/internal/services/normalizer"
+	"github.com/FischukSergey/chat-service/internal/services/redactor"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/breadcrumbs"
+)
+
+// useStoreHooks регистрирует хуки журнала аудита и сообщений. encryptor может быть nil, если шифрование отключено.
+func useStoreHooks(
+	storage *store.Client,
+	auditRecorder *audit.Recorder,
+	n *normalizer.Normalizer,
+	redactorCfg config.RedactorConfig,
+	encryptor *encryption.Encryptor,
+) error {
+	// Журнал аудита только дополняется: события нельзя изменить или удалить не по сроку хранения.
+	storage.AuditEvent.Use(audit.Hook())
+
+	// Порядок важен: сначала нормализуем текст, затем маскируем чувствительные данные и шифруем результат.
+	storage.Message.Use(n.Hook())
+
+	if redactorCfg.Enabled {
+		r, err := redactor.New(redactor.NewOptions(redactor.WithKeepOriginal(redactorCfg.KeepOriginal)))
+		if err != nil {
+			return fmt.Errorf("create redactor: %v", err)
+		}
+		storage.Message.Use(r.Hook())
+	}
+
+	if encryptor != nil {
+		storage.Message.Use(encryptor.Hook())
+		storage.Message.Intercept(encryptor.Interceptor())
+	}
+
+	// Хуки событий аудита регистрируются последними, чтобы видеть все поля, измененные другими хуками.
+	storage.Message.Use(auditRecorder.MessageHook())
+	storage.Problem.Use(auditRecorder.ProblemHook())
+
+	return nil
+}
+
+// OpenStore создает клиент к PostgreSQL и применяет миграции. Хуки сообщений не регистрируются.
+func OpenStore(ctx context.Context, cfg config.PSQLConfig) (*store.Client, error) {
+	storage, _, err := openStore(ctx, cfg)
+	return storage, err
+}
+
+// openStore создает клиент к PostgreSQL и применяет миграции.
+// Возвращает и пул соединений database/sql под клиентом - для проверки готовности и метрик пула.
+func openStore(ctx context.Context, cfg config.PSQLConfig) (*store.Client, *sql.DB, error) {
+	db, err := openPgxDB(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open pgx db: %v", err)
+	}
+
+	clientOpts := []store.Option{store.Driver(breadcrumbs.NewDriver(entsql.OpenDB(dialect.Postgres, db)))}
+	if cfg.DebugMode {
+		clientOpts = append(clientOpts, store.Debug())
+	}
+	storage := store.NewClient(clientOpts...)
+
+	if err := storage.Schema.Create(ctx); err != nil {
+		return nil, nil, errors.Join(fmt.Errorf("migrate schema: %v", err), storage.Close())
+	}
+	return storage, db, nil
+}
+
+// openPgxDB открывает пул соединений к PostgreSQL через pgx.
+// Каждый запрос к БД оборачивается в спан OpenTelemetry.
+func openPgxDB(cfg config.PSQLConfig) (*sql.DB, error) {
+	dsn := (&url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.User, cfg.Password),
+		Host:     cfg.Address,
+		Path:     cfg.Database,
+		RawQuery: "sslmode=disable",
+	}).String()
+
+	pgxCfg, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("parse dsn: %v", err)
+	}
+	return otelsql.OpenDB(stdlib.GetConnector(*pgxCfg),
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	), nil
+}
+
+// NewEncryptor загружает мастер-ключи и создает шифратор сообщений.
+// Возвращает nil, если шифрование отключено.
+func NewEncryptor(cfg config.EncryptionConfig) (*encryption.Encryptor, error) {
+	if !cfg.Enabled {
+		return nil, nil //nolint:nilnil // encryption is optional
+	}
+
+	keys := make(map[string][]byte, len(cfg.MasterKeys))
+	for _, k := range cfg.MasterKeys {
+		key, err := encryption.ReadMasterKey(k.File, k.Env)
+		if err != nil {
+			return nil, fmt.Errorf("read master key %q: %v", k.ID, err)
+		}
+		keys[k.ID] = key
+	}
+
+	return encryption.New(encryption.NewOptions(cfg.CurrentKeyID, keys))
+}
+
+// initMessagesRepo создает репозиторий сообщений и структуры полнотекстового поиска.
+// Если шифрование включено, поиск отключается (API отвечает 501): индекс по шифротексту бесполезен,
+// а расшифровывать сообщения для поиска в памяти слишком дорого. История чатов доступна всегда.
+func initMessagesRepo(
+	ctx context.Context,
+	storage *store.Client,
+	storeDialect string,
+	encryptionEnabled bool,
+) (repo *messagesrepo.Repo, searchEnabled bool, err error) {
+	repo, err = messagesrepo.New(messagesrepo.NewOptions(storage, storeDialect))
+	if err != nil {
+		return nil, false, fmt.Errorf("create messages repo: %v", err)
+	}
+
+	if encryptionEnabled {
+		logger.Named("messages-repo").Warn("messages search is disabled because messages encryption is enabled")
+		return repo, false, nil
+	}
+	if err := repo.Migrate(ctx); err != nil {
+		return nil, false, fmt.Errorf("migrate messages search: %v", err)
+	}
+	return repo, true, nil
+}
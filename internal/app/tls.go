@@ -0,0 +1,24 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/FischukSergey/chat-service/internal/servertls"
+)
+
+// initServerTLS создает TLS-конфигурацию сервера. Возвращает nil, если сертификат в конфиге не задан.
+func initServerTLS(name, certFile, keyFile, minVersion, clientCAFile string) (*servertls.CertReloader, error) {
+	if certFile == "" {
+		return nil, nil //nolint:nilnil // TLS is disabled
+	}
+
+	opts := []servertls.OptOptionsSetter{servertls.WithClientCAFile(clientCAFile)}
+	if minVersion != "" {
+		opts = append(opts, servertls.WithMinVersion(minVersion))
+	}
+	certs, err := servertls.New(servertls.NewOptions(name, certFile, keyFile, opts...))
+	if err != nil {
+		return nil, fmt.Errorf("create %s tls: %v", name, err)
+	}
+	return certs, nil
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/tracing"
+)
+
+const (
+	serviceName            = "chat-service"
+	tracingShutdownTimeout = 5 * time.Second
+)
+
+// initTracing настраивает глобальный провайдер трассировки.
+// Возвращает функцию, отправляющую накопленные спаны при остановке. Если трассировка выключена, она ничего не делает.
+func initTracing(ctx context.Context, cfg config.TracingConfig, env string) (func() error, error) {
+	if !cfg.Enabled {
+		return func() error { return nil }, nil
+	}
+
+	tp, err := tracing.Init(ctx, tracing.NewOptions(
+		serviceName,
+		cfg.Exporter,
+		tracing.WithOtlpEndpoint(cfg.OTLPEndpoint),
+		tracing.WithOtlpInsecure(cfg.OTLPInsecure),
+		tracing.WithSamplerRatio(cfg.SamplerRatio),
+		tracing.WithEnv(env),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	return func() error {
+		// Основной контекст к этому моменту отменен.
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+
+		if err := tp.Shutdown(ctx); err != nil {
+			return fmt.Errorf("shutdown tracer provider: %v", err)
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,40 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// BlobStore - хранилище бинарных объектов (вложений).
+type BlobStore interface {
+	// Put сохраняет объект размером size под ключом key. Если size равен -1, размер заранее неизвестен.
+	// Ошибка чтения r прерывает запись: недописанный объект не сохраняется.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get открывает объект на чтение. Возвращает ErrNotFound, если объекта нет.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет объект. Удаление отсутствующего объекта не является ошибкой.
+	Delete(ctx context.Context, key string) error
+	// SignedURL возвращает ссылку на скачивание объекта, действительную в течение ttl.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
+// ValidateKey проверяет, что ключ - относительный путь без выхода за пределы хранилища.
+func ValidateKey(key string) error {
+	if key == "" ||
+		strings.HasPrefix(key, "/") ||
+		strings.Contains(key, "\\") ||
+		path.Clean(key) != key ||
+		key == ".." || strings.HasPrefix(key, "../") {
+		return ErrInvalidKey
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DownloadPath - путь, по которому LocalStore отдает объекты по подписанным ссылкам.
+const DownloadPath = "/v1/attachments/download/"
+
+const (
+	dirPerm  = 0o750
+	filePerm = 0o640
+)
+
+var _ BlobStore = (*LocalStore)(nil)
+
+//go:generate options-gen -out-filename=local_options.gen.go -from-struct=LocalOptions
+type LocalOptions struct {
+	root       string `option:"mandatory" validate:"required"`
+	baseURL    string `option:"mandatory" validate:"required,url"`
+	signingKey []byte `option:"mandatory" validate:"min=16"`
+}
+
+// LocalStore хранит объекты в локальной файловой системе.
+// Подписанные ссылки проверяются самим LocalStore, который реализует http.Handler.
+type LocalStore struct {
+	root       string
+	baseURL    string
+	signingKey []byte
+	now        func() time.Time
+}
+
+func NewLocal(opts LocalOptions) (*LocalStore, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+
+	if err := os.MkdirAll(opts.root, dirPerm); err != nil {
+		return nil, fmt.Errorf("create root dir: %v", err)
+	}
+
+	return &LocalStore{
+		root:       opts.root,
+		baseURL:    strings.TrimSuffix(opts.baseURL, "/"),
+		signingKey: opts.signingKey,
+		now:        time.Now,
+	}, nil
+}
+
+func (s *LocalStore) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) (errReturned error) {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), dirPerm); err != nil {
+		return fmt.Errorf("create dir: %v", err)
+	}
+
+	// Пишем во временный файл и переименовываем, чтобы не отдавать недописанный объект.
+	f, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %v", err)
+	}
+	defer func() {
+		if errReturned != nil {
+			errReturned = errors.Join(errReturned, os.Remove(f.Name()))
+		}
+	}()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Join(fmt.Errorf("write file: %v", err), f.Close())
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close file: %v", err)
+	}
+	if err := os.Chmod(f.Name(), filePerm); err != nil {
+		return fmt.Errorf("chmod file: %v", err)
+	}
+	if err := os.Rename(f.Name(), p); err != nil {
+		return fmt.Errorf("rename file: %v", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("open file: %v", err)
+	}
+	return f, nil
+}
+
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove file: %v", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) SignedURL(_ context.Context, key string, ttl time.Duration) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+
+	expires := strconv.FormatInt(s.now().Add(ttl).Unix(), 10)
+
+	q := url.Values{}
+	q.Set("expires", expires)
+	q.Set("signature", s.sign(key, expires))
+	return s.baseURL + DownloadPath + key + "?" + q.Encode(), nil
+}
+
+// ServeHTTP отдает объект по подписанной ссылке. Ожидает путь вида DownloadPath + key.
+func (s *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, DownloadPath)
+	if err := ValidateKey(key); err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	expires := r.URL.Query().Get("expires")
+	signature := r.URL.Query().Get("signature")
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+	if s.now().Unix() > expiresAt {
+		http.Error(w, "link expired", http.StatusForbidden)
+		return
+	}
+
+	p, err := s.path(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", "attachment")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeFile(w, r, p)
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+func (s *LocalStore) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
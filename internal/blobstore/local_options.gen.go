@@ -0,0 +1,62 @@
+// Code generated by options-gen. DO NOT EDIT.
+package blobstore
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptLocalOptionsSetter func(o *LocalOptions)
+
+func NewLocalOptions(
+	root string,
+	baseURL string,
+	signingKey []byte,
+	options ...OptLocalOptionsSetter,
+) LocalOptions {
+	o := LocalOptions{}
+
+	// Setting defaults from field tag (if present)
+
+	o.root = root
+
+	o.baseURL = baseURL
+
+	o.signingKey = signingKey
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func (o *LocalOptions) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("root", _validate_LocalOptions_root(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("baseURL", _validate_LocalOptions_baseURL(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("signingKey", _validate_LocalOptions_signingKey(o)))
+	return errs.AsError()
+}
+
+func _validate_LocalOptions_root(o *LocalOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.root, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `root` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_LocalOptions_baseURL(o *LocalOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.baseURL, "required,url"); err != nil {
+		return fmt461e464ebed9.Errorf("field `baseURL` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_LocalOptions_signingKey(o *LocalOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.signingKey, "min=16"); err != nil {
+		return fmt461e464ebed9.Errorf("field `signingKey` did not pass the test: %w", err)
+	}
+	return nil
+}
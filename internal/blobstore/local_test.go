@@ -0,0 +1,113 @@
+package blobstore_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/blobstore"
+)
+
+const baseURL = "http://localhost:8080"
+
+func newLocalStore(t *testing.T) *blobstore.LocalStore {
+	t.Helper()
+
+	s, err := blobstore.NewLocal(blobstore.NewLocalOptions(t.TempDir(), baseURL, []byte("0123456789abcdef")))
+	require.NoError(t, err)
+	return s
+}
+
+func TestLocalStore_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	s := newLocalStore(t)
+
+	const key, data = "user/file", "hello"
+	require.NoError(t, s.Put(ctx, key, strings.NewReader(data), int64(len(data)), "text/plain"))
+
+	rc, err := s.Get(ctx, key)
+	require.NoError(t, err)
+	b, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	assert.Equal(t, data, string(b))
+
+	require.NoError(t, s.Delete(ctx, key))
+	_, err = s.Get(ctx, key)
+	require.ErrorIs(t, err, blobstore.ErrNotFound)
+
+	// Повторное удаление не является ошибкой.
+	require.NoError(t, s.Delete(ctx, key))
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	ctx := context.Background()
+	s := newLocalStore(t)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../b", "a\\b", "a//b"} {
+		t.Run(key, func(t *testing.T) {
+			err := s.Put(ctx, key, strings.NewReader("x"), 1, "text/plain")
+			require.ErrorIs(t, err, blobstore.ErrInvalidKey)
+
+			_, err = s.SignedURL(ctx, key, time.Minute)
+			require.ErrorIs(t, err, blobstore.ErrInvalidKey)
+		})
+	}
+}
+
+func TestLocalStore_SignedURL(t *testing.T) {
+	ctx := context.Background()
+	s := newLocalStore(t)
+
+	const key, data = "user/file", "hello"
+	require.NoError(t, s.Put(ctx, key, strings.NewReader(data), int64(len(data)), "text/plain"))
+
+	serve := func(t *testing.T, rawURL string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		require.True(t, strings.HasPrefix(rawURL, baseURL+blobstore.DownloadPath))
+		req := httptest.NewRequest(http.MethodGet, strings.TrimPrefix(rawURL, baseURL), nil)
+		resp := httptest.NewRecorder()
+		s.ServeHTTP(resp, req)
+		return resp
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		u, err := s.SignedURL(ctx, key, time.Minute)
+		require.NoError(t, err)
+
+		resp := serve(t, u)
+		require.Equal(t, http.StatusOK, resp.Code)
+		assert.Equal(t, data, resp.Body.String())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		u, err := s.SignedURL(ctx, key, -time.Minute)
+		require.NoError(t, err)
+
+		resp := serve(t, u)
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		u, err := s.SignedURL(ctx, key, time.Minute)
+		require.NoError(t, err)
+
+		parsed, err := url.Parse(u)
+		require.NoError(t, err)
+		q := parsed.Query()
+		q.Set("expires", "99999999999")
+		parsed.RawQuery = q.Encode()
+
+		resp := serve(t, parsed.String())
+		assert.Equal(t, http.StatusForbidden, resp.Code)
+	})
+}
@@ -0,0 +1,121 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+const (
+	s3ErrNoSuchKey = "NoSuchKey"
+
+	// s3StreamPartSize - размер части при загрузке объекта неизвестного размера. Без него клиент
+	// рассчитывает часть под максимальный размер объекта S3 и выделяет под буфер сотни мегабайт.
+	s3StreamPartSize = 5 << 20
+)
+
+var _ BlobStore = (*S3Store)(nil)
+
+//go:generate options-gen -out-filename=s3_options.gen.go -from-struct=S3Options
+type S3Options struct {
+	endpoint  string `option:"mandatory" validate:"required,hostname_port|hostname"`
+	accessKey string `option:"mandatory" validate:"required"`
+	secretKey string `option:"mandatory" validate:"required"`
+	bucket    string `option:"mandatory" validate:"required"`
+	region    string
+	useSSL    bool
+}
+
+// S3Store хранит объекты в S3-совместимом хранилище (AWS S3, MinIO и т.п.).
+type S3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3(opts S3Options) (*S3Store, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+
+	client, err := minio.New(opts.endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.accessKey, opts.secretKey, ""),
+		Secure: opts.useSSL,
+		Region: opts.region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create s3 client: %v", err)
+	}
+
+	return &S3Store{
+		client: client,
+		bucket: opts.bucket,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	opts := minio.PutObjectOptions{
+		ContentType:        contentType,
+		ContentDisposition: "attachment",
+	}
+	if size < 0 {
+		opts.PartSize = s3StreamPartSize
+	}
+
+	if _, err := s.client.PutObject(ctx, s.bucket, key, r, size, opts); err != nil {
+		return fmt.Errorf("put object: %v", err)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("get object: %v", err)
+	}
+
+	// GetObject ленивый: отсутствие объекта выясняется только при первом обращении.
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+		if minio.ToErrorResponse(err).Code == s3ErrNoSuchKey {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("stat object: %v", err)
+	}
+	return obj, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	if err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return fmt.Errorf("remove object: %v", err)
+	}
+	return nil
+}
+
+func (s *S3Store) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := ValidateKey(key); err != nil {
+		return "", err
+	}
+
+	u, err := s.client.PresignedGetObject(ctx, s.bucket, key, ttl, url.Values{})
+	if err != nil {
+		return "", fmt.Errorf("presign object: %v", err)
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,87 @@
+// Code generated by options-gen. DO NOT EDIT.
+package blobstore
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptS3OptionsSetter func(o *S3Options)
+
+func NewS3Options(
+	endpoint string,
+	accessKey string,
+	secretKey string,
+	bucket string,
+	options ...OptS3OptionsSetter,
+) S3Options {
+	o := S3Options{}
+
+	// Setting defaults from field tag (if present)
+
+	o.endpoint = endpoint
+
+	o.accessKey = accessKey
+
+	o.secretKey = secretKey
+
+	o.bucket = bucket
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithRegion(opt string) OptS3OptionsSetter {
+	return func(o *S3Options) {
+		o.region = opt
+
+	}
+}
+
+func WithUseSSL(opt bool) OptS3OptionsSetter {
+	return func(o *S3Options) {
+		o.useSSL = opt
+
+	}
+}
+
+func (o *S3Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("endpoint", _validate_S3Options_endpoint(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("accessKey", _validate_S3Options_accessKey(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("secretKey", _validate_S3Options_secretKey(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("bucket", _validate_S3Options_bucket(o)))
+	return errs.AsError()
+}
+
+func _validate_S3Options_endpoint(o *S3Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.endpoint, "required,hostname_port|hostname"); err != nil {
+		return fmt461e464ebed9.Errorf("field `endpoint` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_S3Options_accessKey(o *S3Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.accessKey, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `accessKey` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_S3Options_secretKey(o *S3Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.secretKey, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `secretKey` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_S3Options_bucket(o *S3Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.bucket, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `bucket` did not pass the test: %w", err)
+	}
+	return nil
+}
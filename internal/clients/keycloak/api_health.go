@@ -0,0 +1,24 @@
+package keycloakclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CheckHealth проверяет доступность Keycloak, запрашивая OpenID-конфигурацию realm.
+func (c *Client) CheckHealth(ctx context.Context) (errReturned error) {
+	defer func(start time.Time) { c.metrics.observe("well_known", start, errReturned) }(time.Now())
+
+	url := fmt.Sprintf("%s/realms/%s/.well-known/openid-configuration", c.basePath, c.realm)
+
+	resp, err := c.cli.R().SetContext(ctx).Get(url)
+	if err != nil {
+		return fmt.Errorf("send request to keycloak: %v", err)
+	}
+	if resp.StatusCode() != http.StatusOK {
+		return fmt.Errorf("errored keycloak response: %v", resp.Status())
+	}
+	return nil
+}
@@ -5,8 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-resty/resty/v2"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
 )
 
 type IntrospectTokenResult struct {
@@ -60,7 +64,14 @@ func (r *IntrospectTokenResult) UnmarshalJSON(data []byte) error {
 
 // IntrospectToken implements
 // https://www.keycloak.org/docs/latest/authorization_services/index.html#obtaining-information-about-an-rpt
-func (c *Client) IntrospectToken(ctx context.Context, token string) (*IntrospectTokenResult, error) {
+func (c *Client) IntrospectToken(ctx context.Context, token string) (_ *IntrospectTokenResult, errReturned error) {
+	defer func(start time.Time) {
+		c.metrics.observe("introspect_token", start, errReturned)
+		logger.AddBreadcrumb(ctx, "keycloak", "introspect token", start, errReturned)
+		logger.With(ctx, c.lg).Debug("introspect token",
+			zap.Duration("duration", time.Since(start)), zap.Error(errReturned))
+	}(time.Now())
+
 	url := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token/introspect", c.basePath, c.realm)
 
 	resp, err := c.auth(ctx).
@@ -2,8 +2,12 @@ package keycloakclient
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.uber.org/zap"
 )
 
 //go:generate options-gen -out-filename=client_options.gen.go -from-struct=Options
@@ -15,6 +19,10 @@ type Options struct {
 	username     string
 	password     string
 	debugMode    bool
+	// metricsRegisterer - куда регистрировать метрики запросов к Keycloak. Если не задан, метрики не собираются.
+	metricsRegisterer prometheus.Registerer
+	// logger - логгер компонента. Если не задан, используется глобальный.
+	logger *zap.Logger
 }
 
 // Client is a tiny client to the KeyCloak realm operations. UMA configuration:
@@ -31,7 +39,9 @@ type Client struct {
 	password     string
 	debugMode    bool
 
-	cli *resty.Client
+	cli     *resty.Client
+	metrics *metrics
+	lg      *zap.Logger
 }
 
 func New(opts Options) (*Client, error) {
@@ -42,6 +52,22 @@ func New(opts Options) (*Client, error) {
 	cli := resty.New()
 	cli.SetDebug(opts.debugMode)
 	cli.SetBaseURL(opts.basePath)
+	// Каждый запрос к Keycloak - клиентский спан, контекст трассы передается в заголовке traceparent.
+	cli.SetTransport(otelhttp.NewTransport(cli.GetClient().Transport,
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return "keycloak " + r.Method + " " + r.URL.Path
+		}),
+	))
+
+	lg := opts.logger
+	if lg == nil {
+		lg = zap.L()
+	}
+
+	var m *metrics
+	if opts.metricsRegisterer != nil {
+		m = newMetrics(opts.metricsRegisterer)
+	}
 
 	return &Client{
 		// opts: opts,
@@ -55,6 +81,8 @@ func New(opts Options) (*Client, error) {
 		password:     opts.password,
 		debugMode:    opts.debugMode,
 
-		cli: cli,
+		cli:     cli,
+		metrics: m,
+		lg:      lg,
 	}, nil
 }
@@ -6,6 +6,8 @@ import (
 
 	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
 	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
 )
 
 type OptOptionsSetter func(o *Options)
@@ -56,6 +58,22 @@ func WithDebugMode(opt bool) OptOptionsSetter {
 	}
 }
 
+// metricsRegisterer - куда регистрировать метрики запросов к Keycloak. Если не задан, метрики не собираются.
+func WithMetricsRegisterer(opt prometheus.Registerer) OptOptionsSetter {
+	return func(o *Options) {
+		o.metricsRegisterer = opt
+
+	}
+}
+
+// logger - логгер компонента. Если не задан, используется глобальный.
+func WithLogger(opt *zap.Logger) OptOptionsSetter {
+	return func(o *Options) {
+		o.logger = opt
+
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("basePath", _validate_Options_basePath(o)))
@@ -54,3 +54,7 @@ func (s *KeycloakSuite) TestIntrospectTokenWithInvalidSignature() {
 	s.Require().NoError(err)
 	s.False(result.Active)
 }
+
+func (s *KeycloakSuite) TestCheckHealth() {
+	s.Require().NoError(s.kc.CheckHealth(s.Ctx))
+}
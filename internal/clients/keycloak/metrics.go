@@ -0,0 +1,44 @@
+package keycloakclient
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type metrics struct {
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+func newMetrics(reg prometheus.Registerer) *metrics {
+	m := &metrics{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "chat_service",
+			Subsystem: "keycloak",
+			Name:      "request_duration_seconds",
+			Help:      "Keycloak request duration in seconds.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "chat_service",
+			Subsystem: "keycloak",
+			Name:      "request_errors_total",
+			Help:      "Total number of failed Keycloak requests.",
+		}, []string{"operation"}),
+	}
+	reg.MustRegister(m.duration, m.errors)
+	return m
+}
+
+// observe записывает длительность и результат вызова Keycloak. Безопасен для nil.
+func (m *metrics) observe(operation string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+
+	m.duration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(operation).Inc()
+	}
+}
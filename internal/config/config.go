@@ -1,62 +1,333 @@
 package config
 
+import "time"
+
 // Config представляет конфигурацию приложения.
+// Поля с тегом reload:"true" применяются без перезапуска при перечитывании конфига.
 type Config struct {
-	Global  GlobalConfig  `toml:"global"`
-	Log     LogConfig     `toml:"log"`
-	Servers ServersConfig `toml:"servers"`
-	Sentry  SentryConfig  `toml:"sentry"`
-	Clients ClientsConfig `toml:"clients"`
+	Global   GlobalConfig   `toml:"global" env-prefix:"CHAT_SERVICE_GLOBAL_"`
+	Log      LogConfig      `toml:"log" env-prefix:"CHAT_SERVICE_LOG_"`
+	Servers  ServersConfig  `toml:"servers" env-prefix:"CHAT_SERVICE_SERVERS_"`
+	Sentry   SentryConfig   `toml:"sentry" env-prefix:"CHAT_SERVICE_SENTRY_"`
+	Clients  ClientsConfig  `toml:"clients" env-prefix:"CHAT_SERVICE_CLIENTS_"`
+	Services ServicesConfig `toml:"services" env-prefix:"CHAT_SERVICE_SERVICES_"`
+	Health   HealthConfig   `toml:"health" env-prefix:"CHAT_SERVICE_HEALTH_"`
+	Tracing  TracingConfig  `toml:"tracing" env-prefix:"CHAT_SERVICE_TRACING_"`
+	Shutdown ShutdownConfig `toml:"shutdown" env-prefix:"CHAT_SERVICE_SHUTDOWN_"`
 }
 
 // GlobalConfig представляет глобальные настройки.
 type GlobalConfig struct {
 	// добавляем валидацию: обязательное поле, значения из {"dev", "stage", "prod"}.
-	Env string `toml:"env" validate:"required,oneof=dev stage prod"`
+	Env string `toml:"env" env:"ENV" validate:"required,oneof=dev stage prod"`
 }
 
 // LogConfig представляет настройки логирования.
 type LogConfig struct {
 	// добавляем валидацию: обязательное поле, значения из {"debug", "info", "warn", "error"}.
-	Level          string `toml:"level" validate:"required,oneof=debug info warn error"`
-	ProductionMode bool   `toml:"production_mode"`
+	Level          string `toml:"level" env:"LEVEL" validate:"required,oneof=debug info warn error" reload:"true"`
+	ProductionMode bool   `toml:"production_mode" env:"PRODUCTION_MODE"`
+	// RedactFields - ключи полей лога, значения которых маскируются до отправки в любой sink, включая Sentry.
+	// Поля маскируются и внутри объектов, массивов и значений zap.Any на любой глубине.
+	RedactFields []string          `toml:"redact_fields" env:"REDACT_FIELDS" validate:"dive,required"`
+	File         LogFileConfig     `toml:"file" env-prefix:"FILE_"`
+	Sampling     LogSamplingConfig `toml:"sampling" env-prefix:"SAMPLING_"`
+}
+
+// LogFileConfig представляет настройки записи логов в файл с ротацией.
+type LogFileConfig struct {
+	Enabled bool   `toml:"enabled" env:"ENABLED"`
+	Path    string `toml:"path" env:"PATH" validate:"required_if=Enabled true"`
+	// MaxSizeMB - размер файла, после которого он ротируется.
+	MaxSizeMB int `toml:"max_size_mb" env:"MAX_SIZE_MB" validate:"min=0"`
+	// MaxAgeDays - сколько дней хранить ротированные файлы, 0 - не удалять по возрасту.
+	MaxAgeDays int `toml:"max_age_days" env:"MAX_AGE_DAYS" validate:"min=0"`
+	// MaxBackups - сколько ротированных файлов хранить, 0 - не удалять по количеству.
+	MaxBackups int  `toml:"max_backups" env:"MAX_BACKUPS" validate:"min=0"`
+	Compress   bool `toml:"compress" env:"COMPRESS"`
+}
+
+// LogSamplingConfig представляет настройки семплирования логов уровня INFO и ниже:
+// за Tick каждое сообщение пишется первые Initial раз, затем каждое Thereafter-е.
+type LogSamplingConfig struct {
+	Enabled    bool          `toml:"enabled" env:"ENABLED"`
+	Tick       time.Duration `toml:"tick" env:"TICK" validate:"required_if=Enabled true"`
+	Initial    int           `toml:"initial" env:"INITIAL" validate:"required_if=Enabled true,min=0"`
+	Thereafter int           `toml:"thereafter" env:"THEREAFTER" validate:"min=0"`
 }
 
 // ServersConfig представляет настройки серверов.
 type ServersConfig struct {
-	Debug  DebugServerConfig  `toml:"debug"`
-	Client ClientServerConfig `toml:"client"`
+	// DrainDelay - устаревший ключ, используйте shutdown.drain_delay. Учитывается, если тот не задан.
+	DrainDelay time.Duration      `toml:"drain_delay" env:"DRAIN_DELAY" validate:"min=0" deprecated:"true"`
+	Debug      DebugServerConfig  `toml:"debug" env-prefix:"DEBUG_"`
+	Client     ClientServerConfig `toml:"client" env-prefix:"CLIENT_"`
+}
+
+// ShutdownConfig представляет этапы остановки сервиса. Этапы выполняются по порядку,
+// каждому дается свой таймаут, после которого остановка переходит к следующему этапу.
+// Секция необязательна: у всех полей есть значения по умолчанию.
+type ShutdownConfig struct {
+	// DrainDelay - сколько серверы продолжают работать после начала остановки,
+	// отвечая на readiness-пробу отказом, чтобы трафик успел уйти с сервиса.
+	DrainDelay time.Duration `toml:"drain_delay" env:"DRAIN_DELAY" validate:"min=0"`
+	// HTTPTimeout - сколько ждать завершения активных запросов после закрытия слушающих сокетов.
+	HTTPTimeout time.Duration `toml:"http_timeout" env:"HTTP_TIMEOUT" env-default:"10s" validate:"required,min=1ms"`
+	// RealtimeTimeout - сколько ждать, пока realtime-клиенты получат кадр закрытия и отключатся.
+	RealtimeTimeout time.Duration `toml:"realtime_timeout" env:"REALTIME_TIMEOUT" env-default:"5s" validate:"required,min=1ms"`
+	// JobsTimeout - сколько ждать завершения фоновых задач.
+	JobsTimeout time.Duration `toml:"jobs_timeout" env:"JOBS_TIMEOUT" env-default:"10s" validate:"required,min=1ms"`
 }
 
 // DebugServerConfig представляет настройки отладочного сервера.
 type DebugServerConfig struct {
 	// добавляем валидацию: обязательное поле, значение должно быть в формате "host:port".
-	Addr string `toml:"addr" validate:"required,hostname_port"`
+	Addr string `toml:"addr" env:"ADDR" validate:"required,hostname_port"`
+	// BasicAuth - учетные данные для доступа к отладочному серверу. Если не заданы, доступ открыт.
+	BasicAuth BasicAuthConfig `toml:"basic_auth" env-prefix:"BASIC_AUTH_"`
+	// TLSCertFile и TLSKeyFile включают TLS, файлы перечитываются при изменении.
+	TLSCertFile   string `toml:"tls_cert_file" env:"TLS_CERT_FILE" validate:"required_with=TLSKeyFile,omitempty,file"`
+	TLSKeyFile    string `toml:"tls_key_file" env:"TLS_KEY_FILE" validate:"required_with=TLSCertFile,omitempty,file"`
+	TLSMinVersion string `toml:"tls_min_version" env:"TLS_MIN_VERSION" validate:"omitempty,oneof=1.2 1.3"`
+	// TLSClientCAFile включает mTLS: все маршруты, кроме проб /health/*, доступны только клиентам
+	// с сертификатом, подписанным этим CA. Пробы оркестратора обращаются к серверу без сертификата.
+	TLSClientCAFile string `toml:"tls_client_ca_file" env:"TLS_CLIENT_CA_FILE" validate:"excluded_without=TLSCertFile,omitempty,file"` //nolint:lll
+	// H2C включает HTTP/2 без TLS для внутреннего трафика.
+	H2C bool `toml:"h2c" env:"H2C" validate:"excluded_with=TLSCertFile"`
+}
+
+// BasicAuthConfig представляет учетные данные HTTP Basic-аутентификации.
+type BasicAuthConfig struct {
+	Username string `toml:"username" env:"USERNAME" validate:"required_with=Password"`
+	Password string `toml:"password" env:"PASSWORD" validate:"required_with=Username" secret:"true"`
 }
 
 // SentryConfig представляет настройки Sentry.
 type SentryConfig struct {
 	// DSN - URL для отправки отчетов в Sentry.
 	// добавляем валидацию: значение должно быть в формате URL, не работает если поле пустое
-	DSN string `toml:"dsn" validate:"omitempty,url"`
+	DSN string `toml:"dsn" env:"DSN" validate:"omitempty,url" secret:"true"`
+	// CAFile - PEM-файл с корневыми сертификатами для проверки сервера Sentry. Если не задан, используются системные.
+	CAFile string `toml:"ca_file" env:"CA_FILE" validate:"omitempty,file"`
 }
 
 // ClientServerConfig представляет настройки клиентского сервера.
 type ClientServerConfig struct {
-	Addr         string   `toml:"addr" validate:"required,hostname_port"`
-	AllowOrigins []string `toml:"allow_origins" validate:"required,dive,uri"`
+	Addr         string          `toml:"addr" env:"ADDR" validate:"required,hostname_port"`
+	AllowOrigins []string        `toml:"allow_origins" env:"ALLOW_ORIGINS" validate:"required,dive,uri" reload:"true"`
+	RateLimit    RateLimitConfig `toml:"rate_limit" env-prefix:"RATE_LIMIT_"`
+	// TrustedProxies - сети (CIDR) обратных прокси, от которых принимается адрес клиента из X-Forwarded-For.
+	// Если не заданы, адрес клиента берется из соединения, а заголовки X-Forwarded-For и X-Real-IP игнорируются.
+	TrustedProxies []string `toml:"trusted_proxies" env:"TRUSTED_PROXIES" validate:"dive,cidr"`
+	// TLSCertFile и TLSKeyFile включают TLS, файлы перечитываются при изменении.
+	TLSCertFile   string `toml:"tls_cert_file" env:"TLS_CERT_FILE" validate:"required_with=TLSKeyFile,omitempty,file"`
+	TLSKeyFile    string `toml:"tls_key_file" env:"TLS_KEY_FILE" validate:"required_with=TLSCertFile,omitempty,file"`
+	TLSMinVersion string `toml:"tls_min_version" env:"TLS_MIN_VERSION" validate:"omitempty,oneof=1.2 1.3"`
+	// H2C включает HTTP/2 без TLS для внутреннего трафика.
+	H2C bool `toml:"h2c" env:"H2C" validate:"excluded_with=TLSCertFile"`
+	// CORS и SecureHeaders - общие настройки маршрутов, AllowOrigins задается отдельно и перечитывается на лету.
+	CORS          CORSConfig          `toml:"cors" env-prefix:"CORS_"`
+	SecureHeaders SecureHeadersConfig `toml:"secure_headers" env-prefix:"SECURE_HEADERS_"`
+	// BodyLimit - ограничение размера тела запроса в байтах. Для загрузки вложений
+	// ограничение по умолчанию определяется attachments.max_size.
+	BodyLimit int64 `toml:"body_limit" env:"BODY_LIMIT" env-default:"13312" validate:"required,min=1"`
+	// Routes - переопределения настроек по операциям: путь без префикса "/v1/", например "uploadAttachment".
+	Routes map[string]ClientRouteConfig `toml:"routes" validate:"dive"`
+}
+
+// CORSConfig представляет настройки CORS.
+type CORSConfig struct {
+	AllowMethods     []string `toml:"allow_methods" env:"ALLOW_METHODS" env-default:"GET,POST,OPTIONS" validate:"required,dive,oneof=GET HEAD POST PUT PATCH DELETE OPTIONS"` //nolint:lll
+	AllowHeaders     []string `toml:"allow_headers" env:"ALLOW_HEADERS" validate:"dive,required"`
+	ExposeHeaders    []string `toml:"expose_headers" env:"EXPOSE_HEADERS" validate:"dive,required"`
+	AllowCredentials bool     `toml:"allow_credentials" env:"ALLOW_CREDENTIALS"`
+	// MaxAge - сколько браузер может кешировать ответ на preflight-запрос, округляется до секунд.
+	MaxAge time.Duration `toml:"max_age" env:"MAX_AGE" validate:"min=0"`
+}
+
+// SecureHeadersConfig представляет заголовки безопасности ответов. Пустые значения не отправляются.
+type SecureHeadersConfig struct {
+	// HSTSMaxAge включает Strict-Transport-Security для запросов по HTTPS, округляется до секунд.
+	HSTSMaxAge            time.Duration `toml:"hsts_max_age" env:"HSTS_MAX_AGE" validate:"min=0"`
+	HSTSIncludeSubdomains bool          `toml:"hsts_include_subdomains" env:"HSTS_INCLUDE_SUBDOMAINS"`
+	HSTSPreload           bool          `toml:"hsts_preload" env:"HSTS_PRELOAD"`
+	ContentSecurityPolicy string        `toml:"content_security_policy" env:"CONTENT_SECURITY_POLICY"`
+	// ContentTypeNosniff отправляет X-Content-Type-Options: nosniff.
+	ContentTypeNosniff bool   `toml:"content_type_nosniff" env:"CONTENT_TYPE_NOSNIFF"`
+	FrameOptions       string `toml:"frame_options" env:"FRAME_OPTIONS" validate:"omitempty,oneof=DENY SAMEORIGIN"`
+	ReferrerPolicy     string `toml:"referrer_policy" env:"REFERRER_POLICY"`
+}
+
+// ClientRouteConfig представляет переопределения настроек маршрута клиентского сервера.
+type ClientRouteConfig struct {
+	// BodyLimit - ограничение размера тела запроса в байтах, 0 - общее ограничение.
+	BodyLimit int64 `toml:"body_limit" validate:"min=0"`
+	// CORS и SecureHeaders, если заданы, заменяют общие настройки целиком.
+	CORS          *CORSConfig          `toml:"cors"`
+	SecureHeaders *SecureHeadersConfig `toml:"secure_headers"`
+}
+
+// RateLimitConfig представляет настройки ограничения частоты запросов к API.
+type RateLimitConfig struct {
+	// Enabled применяется только при запуске, политики PerIP, Default и Operations перечитываются на лету.
+	Enabled bool `toml:"enabled" env:"ENABLED"`
+	// PerIP - общая для всех операций политика запросов с одного IP-адреса. Проверяется до аутентификации,
+	// чтобы поток запросов с неверными токенами не доходил до Keycloak и журнала аудита.
+	PerIP IPRateLimitPolicyConfig `toml:"per_ip" env-prefix:"PER_IP_" reload:"true"`
+	// Default - политика операций, для которых не задана собственная.
+	Default RateLimitPolicyConfig `toml:"default" env-prefix:"DEFAULT_" reload:"true"`
+	// Operations - политики операций по именам: путь без префикса "/v1/", например "getHistory".
+	Operations map[string]RateLimitPolicyConfig `toml:"operations" validate:"dive" reload:"true"`
+}
+
+// RateLimitPolicyConfig - корзина токенов: Rate запросов в секунду, не больше Burst подряд.
+// Незаданные значения политики Default берутся по умолчанию, политики операций задаются целиком.
+type RateLimitPolicyConfig struct {
+	Rate  float64 `toml:"rate" env:"RATE" env-default:"10" validate:"gt=0"`
+	Burst int     `toml:"burst" env:"BURST" env-default:"20" validate:"min=1"`
+}
+
+// IPRateLimitPolicyConfig - корзина токенов IP-адреса. Значения по умолчанию выше, чем у пользователя:
+// за одним адресом могут работать несколько пользователей.
+type IPRateLimitPolicyConfig struct {
+	Rate  float64 `toml:"rate" env:"RATE" env-default:"50" validate:"gt=0"`
+	Burst int     `toml:"burst" env:"BURST" env-default:"100" validate:"min=1"`
+}
+
+// HealthConfig представляет настройки проверок готовности сервиса.
+type HealthConfig struct {
+	// CheckTimeout - таймаут каждой проверки зависимости.
+	CheckTimeout time.Duration `toml:"check_timeout" env:"CHECK_TIMEOUT" validate:"required,min=1ms"`
+	// AuditQueueMaxLag - сколько событие может ждать в очереди записи журнала аудита, прежде чем сервис станет не готов.
+	AuditQueueMaxLag time.Duration `toml:"audit_queue_max_lag" env:"AUDIT_QUEUE_MAX_LAG" env-default:"1m" validate:"required,min=1s"`
+}
+
+// TracingConfig представляет настройки трассировки OpenTelemetry.
+type TracingConfig struct {
+	Enabled bool `toml:"enabled" env:"ENABLED"`
+	// Exporter - "otlp" для отправки в коллектор или "stdout" для локальной отладки: спаны пишутся в stderr,
+	// чтобы не перемешиваться с логами.
+	Exporter     string `toml:"exporter" env:"EXPORTER" validate:"required_if=Enabled true,omitempty,oneof=otlp stdout"`
+	OTLPEndpoint string `toml:"otlp_endpoint" env:"OTLP_ENDPOINT" validate:"omitempty,hostname_port"`
+	OTLPInsecure bool   `toml:"otlp_insecure" env:"OTLP_INSECURE"`
+	// SamplerRatio - доля трассируемых корневых запросов, по умолчанию 1. Ноль не отличить от незаданного значения,
+	// поэтому минимальная доля задается небольшим положительным числом.
+	SamplerRatio float64 `toml:"sampler_ratio" env:"SAMPLER_RATIO" env-default:"1" validate:"gt=0,max=1"`
 }
 
-// ClientsConfig представляет настройки для Keycloak.
+// ClientsConfig представляет настройки внешних клиентов.
 type ClientsConfig struct {
-	Keycloak KeycloakConfig `toml:"keycloak"`
+	Keycloak KeycloakConfig `toml:"keycloak" env-prefix:"KEYCLOAK_"`
+	PSQL     PSQLConfig     `toml:"psql" env-prefix:"PSQL_"`
 }
 
 // KeycloakConfig представляет настройки для Keycloak.
 type KeycloakConfig struct {
-	BasePath     string `toml:"base_path" validate:"required,url"`
-	Realm        string `toml:"realm" validate:"required"`
-	ClientID     string `toml:"client_id" validate:"required"`
-	ClientSecret string `toml:"client_secret" validate:"required"`
-	DebugMode    bool   `toml:"debug_mode"`
+	BasePath     string `toml:"base_path" env:"BASE_PATH" validate:"required,url"`
+	Realm        string `toml:"realm" env:"REALM" validate:"required"`
+	ClientID     string `toml:"client_id" env:"CLIENT_ID" validate:"required"`
+	ClientSecret string `toml:"client_secret" env:"CLIENT_SECRET" validate:"required" secret:"true"`
+	DebugMode    bool   `toml:"debug_mode" env:"DEBUG_MODE"`
+}
+
+// PSQLConfig представляет настройки подключения к PostgreSQL.
+type PSQLConfig struct {
+	Address   string `toml:"address" env:"ADDRESS" validate:"required,hostname_port"`
+	User      string `toml:"user" env:"USER" validate:"required"`
+	Password  string `toml:"password" env:"PASSWORD" validate:"required" secret:"true"`
+	Database  string `toml:"database" env:"DATABASE" validate:"required"`
+	DebugMode bool   `toml:"debug_mode" env:"DEBUG_MODE"`
+}
+
+// ServicesConfig представляет настройки внутренних сервисов.
+type ServicesConfig struct {
+	Messages    MessagesConfig    `toml:"messages" env-prefix:"MESSAGES_"`
+	Redactor    RedactorConfig    `toml:"redactor" env-prefix:"REDACTOR_"`
+	Encryption  EncryptionConfig  `toml:"encryption" env-prefix:"ENCRYPTION_"`
+	Attachments AttachmentsConfig `toml:"attachments" env-prefix:"ATTACHMENTS_"`
+	Presence    PresenceConfig    `toml:"presence" env-prefix:"PRESENCE_"`
+	Typing      TypingConfig      `toml:"typing" env-prefix:"TYPING_"`
+	Audit       AuditConfig       `toml:"audit" env-prefix:"AUDIT_"`
+}
+
+// MessagesConfig представляет ограничения на сообщения.
+type MessagesConfig struct {
+	// MaxBodyLength - максимальная длина тела сообщения в символах.
+	MaxBodyLength int `toml:"max_body_length" env:"MAX_BODY_LENGTH" validate:"required,min=1"`
+}
+
+// RedactorConfig представляет настройки маскирования чувствительных данных в сообщениях.
+type RedactorConfig struct {
+	Enabled bool `toml:"enabled" env:"ENABLED"`
+	// KeepOriginal - сохранять ли исходный текст сообщения. По умолчанию исходный текст не сохраняется.
+	// Требует включенного шифрования: исходный текст хранится только зашифрованным.
+	KeepOriginal bool `toml:"keep_original" env:"KEEP_ORIGINAL"`
+}
+
+// EncryptionConfig представляет настройки шифрования текста сообщений в хранилище.
+type EncryptionConfig struct {
+	// Enabled включает шифрование. Полнотекстовый поиск по зашифрованному тексту невозможен,
+	// поэтому при включенном шифровании операции поиска сообщений отвечают 501.
+	Enabled bool `toml:"enabled" env:"ENABLED"`
+	// CurrentKeyID - идентификатор мастер-ключа, которым шифруются новые значения.
+	CurrentKeyID string            `toml:"current_key_id" env:"CURRENT_KEY_ID" validate:"required_if=Enabled true"`
+	MasterKeys   []MasterKeyConfig `toml:"master_keys" validate:"required_if=Enabled true,dive"`
+}
+
+// MasterKeyConfig описывает источник мастер-ключа: файл или переменную окружения с ключом в base64.
+type MasterKeyConfig struct {
+	ID   string `toml:"id" validate:"required,excludesall=:"`
+	File string `toml:"file" validate:"required_without=Env"`
+	Env  string `toml:"env" validate:"required_without=File"`
+}
+
+// AttachmentsConfig представляет настройки загрузки вложений.
+type AttachmentsConfig struct {
+	// MaxSize - максимальный размер вложения в байтах.
+	MaxSize          int64    `toml:"max_size" env:"MAX_SIZE" validate:"required,min=1"`
+	AllowedMIMETypes []string `toml:"allowed_mime_types" env:"ALLOWED_MIME_TYPES" validate:"required,dive,required"`
+	// URLTTL - время жизни ссылки на скачивание, например "15m".
+	URLTTL  time.Duration          `toml:"url_ttl" env:"URL_TTL" validate:"required,min=1s"`
+	Storage string                 `toml:"storage" env:"STORAGE" validate:"required,oneof=local s3"`
+	Local   LocalBlobStorageConfig `toml:"local" env-prefix:"LOCAL_"`
+	S3      S3BlobStorageConfig    `toml:"s3" env-prefix:"S3_"`
+}
+
+// LocalBlobStorageConfig представляет настройки хранения вложений в локальной файловой системе.
+type LocalBlobStorageConfig struct {
+	Dir string `toml:"dir" env:"DIR"`
+	// BaseURL - внешний адрес клиентского сервера, от которого строятся ссылки на скачивание.
+	BaseURL string `toml:"base_url" env:"BASE_URL" validate:"omitempty,url"`
+	// SigningKey - секрет для подписи ссылок на скачивание.
+	SigningKey string `toml:"signing_key" env:"SIGNING_KEY" validate:"omitempty,min=16" secret:"true"`
+}
+
+// S3BlobStorageConfig представляет настройки S3-совместимого хранилища вложений.
+type S3BlobStorageConfig struct {
+	Endpoint  string `toml:"endpoint" env:"ENDPOINT"`
+	Region    string `toml:"region" env:"REGION"`
+	Bucket    string `toml:"bucket" env:"BUCKET"`
+	AccessKey string `toml:"access_key" env:"ACCESS_KEY" secret:"true"`
+	SecretKey string `toml:"secret_key" env:"SECRET_KEY" secret:"true"`
+	UseSSL    bool   `toml:"use_ssl" env:"USE_SSL"`
+}
+
+// PresenceConfig представляет настройки отслеживания присутствия пользователей.
+type PresenceConfig struct {
+	// OnlineTTL - сколько пользователь считается онлайн после последней активности.
+	OnlineTTL time.Duration `toml:"online_ttl" env:"ONLINE_TTL" validate:"required,min=1s"`
+	// Retention - сколько хранится время последней активности пользователя.
+	Retention time.Duration `toml:"retention" env:"RETENTION" validate:"required,gtefield=OnlineTTL"`
+}
+
+// TypingConfig представляет настройки сигналов о наборе текста.
+type TypingConfig struct {
+	// Throttle - минимальный интервал между сигналами от одного пользователя в одном чате.
+	Throttle time.Duration `toml:"throttle" env:"THROTTLE" validate:"required,min=100ms"`
+}
+
+// AuditConfig представляет настройки журнала аудита.
+type AuditConfig struct {
+	// Retention - сколько хранятся события аудита.
+	Retention time.Duration `toml:"retention" env:"RETENTION" validate:"required,min=1h"`
 }
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Change - изменившееся поле конфига.
+type Change struct {
+	// Path - путь к полю в TOML, например "servers.client.allow_origins".
+	Path string
+	// Reloadable - поле помечено тегом reload:"true" (или лежит внутри такого поля)
+	// и может быть применено без перезапуска сервиса.
+	Reloadable bool
+}
+
+// Diff возвращает поля, значения которых в newCfg отличаются от oldCfg, в порядке объявления.
+func Diff(oldCfg, newCfg Config) []Change {
+	var changes []Change
+	diffValues(reflect.ValueOf(oldCfg), reflect.ValueOf(newCfg), nil, false, &changes)
+	return changes
+}
+
+func diffValues(a, b reflect.Value, path []string, reloadable bool, changes *[]Change) {
+	if a.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+			*changes = append(*changes, Change{Path: strings.Join(path, "."), Reloadable: reloadable})
+		}
+		return
+	}
+
+	t := a.Type()
+	for i := range a.NumField() {
+		f := t.Field(i)
+		diffValues(a.Field(i), b.Field(i), append(path, f.Tag.Get("toml")),
+			reloadable || f.Tag.Get("reload") == "true", changes)
+	}
+}
@@ -0,0 +1,43 @@
+package config
+
+import "reflect"
+
+// MaskedValue - значение, которым заменяются секреты в Masked.
+const MaskedValue = "***"
+
+// Masked возвращает копию конфига, в которой непустые строковые поля с тегом secret:"true"
+// заменены на MaskedValue. Используется, когда конфиг нужно показать или вывести.
+func Masked(cfg Config) Config {
+	masked := cfg
+	maskSecrets(reflect.ValueOf(&masked).Elem())
+	return masked
+}
+
+func maskSecrets(v reflect.Value) {
+	switch v.Kind() { //nolint:exhaustive // secrets are only strings inside structs and slices
+	case reflect.Struct:
+		t := v.Type()
+		for i := range v.NumField() {
+			f := v.Field(i)
+			if t.Field(i).Tag.Get("secret") == "true" && f.Kind() == reflect.String {
+				if f.String() != "" {
+					f.SetString(MaskedValue)
+				}
+				continue
+			}
+			maskSecrets(f)
+		}
+
+	case reflect.Slice:
+		// Срез копируется, чтобы не изменить исходный конфиг.
+		if v.Len() == 0 || v.Type().Elem().Kind() != reflect.Struct {
+			return
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(cp, v)
+		for i := range cp.Len() {
+			maskSecrets(cp.Index(i))
+		}
+		v.Set(cp)
+	}
+}
@@ -0,0 +1,30 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+)
+
+func TestMasked(t *testing.T) {
+	cfg, err := config.ParseAndValidate(configExamplePath)
+	require.NoError(t, err)
+	cfg.Servers.Debug.BasicAuth = config.BasicAuthConfig{Username: "admin", Password: "admin-password"}
+
+	masked := config.Masked(cfg)
+
+	assert.Equal(t, config.MaskedValue, masked.Clients.Keycloak.ClientSecret)
+	assert.Equal(t, config.MaskedValue, masked.Clients.PSQL.Password)
+	assert.Equal(t, config.MaskedValue, masked.Services.Attachments.Local.SigningKey)
+	assert.Equal(t, config.MaskedValue, masked.Servers.Debug.BasicAuth.Password)
+	assert.Equal(t, "admin", masked.Servers.Debug.BasicAuth.Username)
+	// Пустые секреты остаются пустыми, чтобы было видно, что они не заданы.
+	assert.Empty(t, masked.Services.Attachments.S3.SecretKey)
+	assert.Empty(t, masked.Sentry.DSN)
+	// Не секретные поля и исходный конфиг не меняются.
+	assert.Equal(t, cfg.Clients.PSQL.User, masked.Clients.PSQL.User)
+	assert.Equal(t, "chat-service", cfg.Clients.PSQL.Password)
+}
@@ -2,13 +2,21 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"reflect"
+	"strings"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
-// ParseAndValidate декодирует .toml файл в конфиг и валидирует его.
+// SecretFileSuffix - суффикс переменной окружения с путем к файлу секрета,
+// например CHAT_SERVICE_CLIENTS_KEYCLOAK_CLIENT_SECRET_FILE.
+const SecretFileSuffix = "_FILE"
+
+// ParseAndValidate декодирует .toml файл в конфиг, накладывает поверх него переменные окружения и валидирует его.
+// Переменные окружения называются по пути до поля: CHAT_SERVICE_<секция>_..._<ключ> в верхнем регистре.
+// Секреты также можно передать файлом через переменную с суффиксом SecretFileSuffix.
 func ParseAndValidate(filename string) (Config, error) {
 	if filename == "" {
 		return Config{}, errors.New("filename is required")
@@ -18,13 +26,65 @@ func ParseAndValidate(filename string) (Config, error) {
 	}
 
 	cfg := Config{}
-	// 1) Декодим .toml файл в конфиг.
+	// 1) Декодим .toml файл в конфиг и накладываем переменные окружения.
 	if err := cleanenv.ReadConfig(filename, &cfg); err != nil {
 		return Config{}, err
 	}
-	// 2) Валидируем валидатором из internal/validator.
-	if err := validator.New().Struct(cfg); err != nil {
+	// 2) Читаем секреты из файлов.
+	if err := readSecretFiles(reflect.ValueOf(&cfg).Elem(), ""); err != nil {
+		return Config{}, err
+	}
+	// 3) Учитываем устаревшие ключи, если их замена не задана.
+	applyDeprecated(&cfg)
+	// 4) Валидируем поля и правила, связывающие несколько полей.
+	if err := Validate(cfg); err != nil {
 		return Config{}, err
 	}
 	return cfg, nil
 }
+
+// applyDeprecated переносит значения устаревших ключей на их новое место.
+func applyDeprecated(cfg *Config) {
+	// servers.drain_delay перенесен в shutdown.drain_delay.
+	if cfg.Shutdown.DrainDelay == 0 {
+		cfg.Shutdown.DrainDelay = cfg.Servers.DrainDelay
+	}
+}
+
+// readSecretFiles заполняет секреты из файлов, указанных в переменных окружения <env>_FILE.
+// Имена переменных строятся по тегам env-prefix и env так же, как это делает cleanenv.
+func readSecretFiles(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := range v.NumField() {
+		f, sf := v.Field(i), t.Field(i)
+
+		if f.Kind() == reflect.Struct {
+			if err := readSecretFiles(f, prefix+sf.Tag.Get(cleanenv.TagEnvPrefix)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		env := sf.Tag.Get(cleanenv.TagEnv)
+		if sf.Tag.Get("secret") != "true" || f.Kind() != reflect.String || env == "" {
+			continue
+		}
+
+		env = prefix + env
+		path, ok := os.LookupEnv(env + SecretFileSuffix)
+		if !ok {
+			continue
+		}
+		if _, ok := os.LookupEnv(env); ok {
+			return fmt.Errorf("both %s and %s%s are set", env, env, SecretFileSuffix)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s%s: %v", env, SecretFileSuffix, err)
+		}
+		// Файлы секретов обычно заканчиваются переводом строки, который не относится к значению.
+		f.SetString(strings.TrimRight(string(data), "\r\n"))
+	}
+	return nil
+}
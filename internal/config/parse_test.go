@@ -1,9 +1,13 @@
 package config_test
 
 import (
+	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -23,3 +27,139 @@ func TestParseAndValidate(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, cfg.Log.Level)
 }
+
+func TestParseAndValidate_EnvOverrides(t *testing.T) {
+	t.Setenv("CHAT_SERVICE_LOG_LEVEL", "warn")
+	t.Setenv("CHAT_SERVICE_CLIENTS_KEYCLOAK_CLIENT_SECRET", "secret-from-env")
+	t.Setenv("CHAT_SERVICE_SERVICES_AUDIT_RETENTION", "48h")
+	t.Setenv("CHAT_SERVICE_SERVERS_CLIENT_ALLOW_ORIGINS", "https://a.example.com,https://b.example.com")
+
+	cfg, err := config.ParseAndValidate(configExamplePath)
+	require.NoError(t, err)
+	assert.Equal(t, "warn", cfg.Log.Level)
+	assert.Equal(t, "secret-from-env", cfg.Clients.Keycloak.ClientSecret)
+	assert.Equal(t, 48*time.Hour, cfg.Services.Audit.Retention)
+	assert.Equal(t, []string{"https://a.example.com", "https://b.example.com"}, cfg.Servers.Client.AllowOrigins)
+}
+
+func TestParseAndValidate_SecretFiles(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "psql-password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("secret-from-file\n"), 0o600))
+
+	t.Run("read from file", func(t *testing.T) {
+		t.Setenv("CHAT_SERVICE_CLIENTS_PSQL_PASSWORD_FILE", secretFile)
+
+		cfg, err := config.ParseAndValidate(configExamplePath)
+		require.NoError(t, err)
+		assert.Equal(t, "secret-from-file", cfg.Clients.PSQL.Password)
+	})
+
+	t.Run("both value and file", func(t *testing.T) {
+		t.Setenv("CHAT_SERVICE_CLIENTS_PSQL_PASSWORD", "secret-from-env")
+		t.Setenv("CHAT_SERVICE_CLIENTS_PSQL_PASSWORD_FILE", secretFile)
+
+		_, err := config.ParseAndValidate(configExamplePath)
+		require.Error(t, err)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Setenv("CHAT_SERVICE_CLIENTS_PSQL_PASSWORD_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := config.ParseAndValidate(configExamplePath)
+		require.Error(t, err)
+	})
+
+	t.Run("not a secret", func(t *testing.T) {
+		// Для несекретных полей переменная с суффиксом _FILE не читается.
+		t.Setenv("CHAT_SERVICE_CLIENTS_PSQL_USER_FILE", secretFile)
+
+		cfg, err := config.ParseAndValidate(configExamplePath)
+		require.NoError(t, err)
+		assert.Equal(t, "chat-service", cfg.Clients.PSQL.User)
+	})
+}
+
+func TestParseAndValidate_Defaults(t *testing.T) {
+	path := exampleWithoutSections(t, "[servers.client.rate_limit]", "[servers.client.rate_limit.operations]")
+
+	cfg, err := config.ParseAndValidate(path)
+	require.NoError(t, err)
+
+	rateLimit := cfg.Servers.Client.RateLimit
+	assert.False(t, rateLimit.Enabled)
+	assert.Equal(t, config.RateLimitPolicyConfig{Rate: 10, Burst: 20}, rateLimit.Default)
+	assert.Equal(t, config.IPRateLimitPolicyConfig{Rate: 50, Burst: 100}, rateLimit.PerIP)
+}
+
+func TestParseAndValidate_ClientServerDefaults(t *testing.T) {
+	path := exampleWithoutSections(t, "[servers.client.cors]")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "body_limit = 13312\n")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(data), "body_limit = 13312\n", "", 1)), 0o600))
+
+	cfg, err := config.ParseAndValidate(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(13312), cfg.Servers.Client.BodyLimit)
+	assert.Equal(t, []string{"GET", "POST", "OPTIONS"}, cfg.Servers.Client.CORS.AllowMethods)
+}
+
+func TestParseAndValidate_TracingSamplerRatioDefault(t *testing.T) {
+	path := exampleWithoutSections(t)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "sampler_ratio = 1.0\n")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(data), "sampler_ratio = 1.0\n", "", 1)), 0o600))
+
+	cfg, err := config.ParseAndValidate(path)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, cfg.Tracing.SamplerRatio, 0)
+}
+
+func TestParseAndValidate_ShutdownDefaults(t *testing.T) {
+	path := exampleWithoutSections(t, "[shutdown]")
+
+	cfg, err := config.ParseAndValidate(path)
+	require.NoError(t, err)
+	assert.Equal(t, config.ShutdownConfig{
+		HTTPTimeout:     10 * time.Second,
+		RealtimeTimeout: 5 * time.Second,
+		JobsTimeout:     10 * time.Second,
+	}, cfg.Shutdown)
+
+	t.Run("deprecated servers.drain_delay", func(t *testing.T) {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path,
+			[]byte(strings.Replace(string(data), "[servers]\n", "[servers]\ndrain_delay = \"3s\"\n", 1)), 0o600))
+
+		cfg, err := config.ParseAndValidate(path)
+		require.NoError(t, err)
+		assert.Equal(t, 3*time.Second, cfg.Shutdown.DrainDelay)
+	})
+}
+
+// exampleWithoutSections записывает во временный файл пример конфига без указанных секций.
+func exampleWithoutSections(t *testing.T, sections ...string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(configExamplePath)
+	require.NoError(t, err)
+
+	var (
+		lines []string
+		skip  bool
+	)
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.HasPrefix(line, "[") {
+			skip = slices.Contains(sections, line)
+		}
+		if !skip {
+			lines = append(lines, line)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o600))
+	return path
+}
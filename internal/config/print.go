@@ -0,0 +1,16 @@
+package config
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/BurntSushi/toml"
+)
+
+// WriteMasked записывает конфиг в формате TOML, заменив секреты на MaskedValue.
+func WriteMasked(w io.Writer, cfg Config) error {
+	if err := toml.NewEncoder(w).Encode(Masked(cfg)); err != nil {
+		return fmt.Errorf("encode config: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package config_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+)
+
+func TestWriteMasked(t *testing.T) {
+	cfg, err := config.ParseAndValidate(configExamplePath)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, config.WriteMasked(&buf, cfg))
+	assert.Contains(t, buf.String(), `password = "***"`)
+
+	// Вывод - валидный TOML, который читается обратно в тот же конфиг с замаскированными секретами.
+	var printed config.Config
+	_, err = toml.Decode(buf.String(), &printed)
+	require.NoError(t, err)
+	assert.Equal(t, config.Masked(cfg), printed)
+}
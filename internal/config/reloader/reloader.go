@@ -0,0 +1,156 @@
+package reloader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/filewatch"
+	"github.com/FischukSergey/chat-service/internal/logger"
+)
+
+var ErrNotReloadable = errors.New("changes require restart")
+
+// Subscriber применяет новый конфиг. Вызывается после каждого перечитывания, в котором что-то изменилось,
+// поэтому сам проверяет, изменились ли нужные ему поля.
+type Subscriber func(ctx context.Context, oldCfg, newCfg config.Config) error
+
+//go:generate options-gen -out-filename=reloader_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	path    string        `option:"mandatory" validate:"required"`
+	current config.Config `option:"mandatory"`
+	// debounce - сколько ждать после последнего изменения файла: редакторы сохраняют файл несколькими операциями.
+	debounce time.Duration `validate:"min=0"`
+	parse    func(filename string) (config.Config, error)
+	// onApplied вызывается с примененными изменениями, когда новый конфиг уже стал действующим.
+	// Ошибки не возвращает и не должен блокироваться: вызывается под блокировкой Reload.
+	onApplied func(changes []config.Change)
+}
+
+var defaultOptions = Options{
+	debounce: 500 * time.Millisecond,
+	parse:    config.ParseAndValidate,
+}
+
+// Reloader перечитывает конфиг при изменении файла и по сигналу SIGHUP.
+// Применяются только поля, помеченные reload:"true"; если изменилось что-то еще,
+// новый конфиг отклоняется целиком.
+type Reloader struct {
+	Options
+	lg *zap.Logger
+
+	mu          sync.Mutex
+	subscribers []subscriber
+}
+
+type subscriber struct {
+	name string
+	fn   Subscriber
+}
+
+func New(opts Options) (*Reloader, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &Reloader{Options: opts, lg: logger.Named("config-reloader")}, nil
+}
+
+// Subscribe добавляет подписчика на изменения конфига.
+func (r *Reloader) Subscribe(name string, fn Subscriber) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscribers = append(r.subscribers, subscriber{name: name, fn: fn})
+}
+
+// Current возвращает действующий конфиг.
+func (r *Reloader) Current() config.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.current
+}
+
+// Reload перечитывает конфиг и передает его подписчикам. Возвращает примененные изменения.
+// Если изменились поля, требующие перезапуска, возвращает ErrNotReloadable и ничего не применяет.
+// Новый конфиг становится действующим, только если его применили все подписчики. Если подписчик
+// вернул ошибку, уже применившие конфиг подписчики в обратном порядке возвращаются к прежнему конфигу.
+func (r *Reloader) Reload(ctx context.Context) ([]config.Change, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newCfg, err := r.parse(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("parse and validate config %q: %v", r.path, err)
+	}
+
+	changes := config.Diff(r.current, newCfg)
+	var notReloadable []string
+	for _, c := range changes {
+		if !c.Reloadable {
+			notReloadable = append(notReloadable, c.Path)
+		}
+	}
+	if len(notReloadable) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotReloadable, strings.Join(notReloadable, ", "))
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	oldCfg := r.current
+	for i, s := range r.subscribers {
+		if err := s.fn(ctx, oldCfg, newCfg); err != nil {
+			errs := []error{fmt.Errorf("%s: %v", s.name, err)}
+			for _, applied := range slices.Backward(r.subscribers[:i]) {
+				if err := applied.fn(ctx, newCfg, oldCfg); err != nil {
+					errs = append(errs, fmt.Errorf("rollback %s: %v", applied.name, err))
+				}
+			}
+			return nil, errors.Join(errs...)
+		}
+	}
+
+	r.current = newCfg
+	if r.onApplied != nil {
+		r.onApplied(changes)
+	}
+	return changes, nil
+}
+
+// Run следит за файлом конфига и сигналом SIGHUP, пока не завершится контекст.
+func (r *Reloader) Run(ctx context.Context) error {
+	w, err := filewatch.New(filewatch.NewOptions(r.lg, []string{r.path}, r.reload,
+		filewatch.WithDebounce(r.debounce),
+		filewatch.WithSignals([]os.Signal{syscall.SIGHUP}),
+	))
+	if err != nil {
+		return fmt.Errorf("create watcher: %v", err)
+	}
+	return w.Run(ctx)
+}
+
+func (r *Reloader) reload(ctx context.Context, trigger string) {
+	changes, err := r.Reload(ctx)
+	if err != nil {
+		r.lg.Error("config reload", zap.String("trigger", trigger), zap.Error(err))
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	paths := make([]string, 0, len(changes))
+	for _, c := range changes {
+		paths = append(paths, c.Path)
+	}
+	r.lg.Info("config reloaded", zap.String("trigger", trigger), zap.Strings("changed", paths))
+}
@@ -0,0 +1,86 @@
+// Code generated by options-gen. DO NOT EDIT.
+package reloader
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	path string,
+	current config.Config,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.path = defaultOptions.path
+
+	o.current = defaultOptions.current
+
+	o.debounce = defaultOptions.debounce
+
+	o.parse = defaultOptions.parse
+
+	o.onApplied = defaultOptions.onApplied
+
+	o.path = path
+
+	o.current = current
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// debounce - сколько ждать после последнего изменения файла: редакторы сохраняют файл несколькими операциями.
+func WithDebounce(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.debounce = opt
+
+	}
+}
+
+func WithParse(opt func(filename string) (config.Config, error)) OptOptionsSetter {
+	return func(o *Options) {
+		o.parse = opt
+
+	}
+}
+
+// onApplied вызывается с примененными изменениями, когда новый конфиг уже стал действующим.
+// Ошибки не возвращает и не должен блокироваться: вызывается под блокировкой Reload.
+func WithOnApplied(opt func(changes []config.Change)) OptOptionsSetter {
+	return func(o *Options) {
+		o.onApplied = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("path", _validate_Options_path(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("debounce", _validate_Options_debounce(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_path(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.path, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `path` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_debounce(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.debounce, "min=0"); err != nil {
+		return fmt461e464ebed9.Errorf("field `debounce` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+package reloader_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/config/reloader"
+)
+
+func TestReloader_Reload(t *testing.T) {
+	ctx := context.Background()
+	path := copyExampleConfig(t)
+	var applied [][]config.Change
+	r := newReloader(t, path, reloader.WithOnApplied(func(changes []config.Change) {
+		applied = append(applied, changes)
+	}))
+
+	var levels []string
+	r.Subscribe("log-level", func(_ context.Context, oldCfg, newCfg config.Config) error {
+		levels = append(levels, oldCfg.Log.Level+"->"+newCfg.Log.Level)
+		return nil
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		changes, err := r.Reload(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Empty(t, levels)
+	})
+
+	t.Run("reloadable", func(t *testing.T) {
+		replaceInFile(t, path, `level = "info"`, `level = "debug"`)
+		replaceInFile(t, path, `allow_origins = ["http://localhost:3000"]`,
+			`allow_origins = ["http://localhost:3000", "https://chat.example.com"]`)
+
+		changes, err := r.Reload(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []config.Change{
+			{Path: "log.level", Reloadable: true},
+			{Path: "servers.client.allow_origins", Reloadable: true},
+		}, changes)
+		assert.Equal(t, []string{"info->debug"}, levels)
+		assert.Equal(t, "debug", r.Current().Log.Level)
+		assert.Equal(t, [][]config.Change{changes}, applied)
+	})
+
+	t.Run("not reloadable", func(t *testing.T) {
+		replaceInFile(t, path, `level = "debug"`, `level = "warn"`)
+		replaceInFile(t, path, `addr = ":8080"`, `addr = ":8081"`)
+
+		_, err := r.Reload(ctx)
+		require.ErrorIs(t, err, reloader.ErrNotReloadable)
+		assert.Contains(t, err.Error(), "servers.client.addr")
+		// Конфиг отклоняется целиком, включая поля, которые можно было применить.
+		assert.Equal(t, "debug", r.Current().Log.Level)
+		assert.Len(t, levels, 1)
+		assert.Len(t, applied, 1)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		replaceInFile(t, path, `addr = ":8081"`, `addr = ":8080"`)
+		replaceInFile(t, path, `level = "warn"`, `level = "verbose"`)
+
+		_, err := r.Reload(ctx)
+		require.Error(t, err)
+		assert.Equal(t, "debug", r.Current().Log.Level)
+	})
+}
+
+func TestReloader_Reload_SubscriberFailed(t *testing.T) {
+	ctx := context.Background()
+	path := copyExampleConfig(t)
+	r := newReloader(t, path, reloader.WithOnApplied(func([]config.Change) {
+		t.Error("rejected config must not be reported as applied")
+	}))
+
+	var applied []string
+	r.Subscribe("log-level", func(_ context.Context, _, newCfg config.Config) error {
+		applied = append(applied, newCfg.Log.Level)
+		return nil
+	})
+	r.Subscribe("broken", func(context.Context, config.Config, config.Config) error {
+		return errors.New("cannot apply")
+	})
+
+	replaceInFile(t, path, `level = "info"`, `level = "debug"`)
+	changes, err := r.Reload(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken: cannot apply")
+	assert.Empty(t, changes)
+
+	// Применивший конфиг подписчик возвращается к прежнему, действующим остается прежний конфиг.
+	assert.Equal(t, []string{"debug", "info"}, applied)
+	assert.Equal(t, "info", r.Current().Log.Level)
+}
+
+func TestReloader_Run(t *testing.T) {
+	path := copyExampleConfig(t)
+	r := newReloader(t, path)
+
+	levels := make(chan string, 10)
+	r.Subscribe("log-level", func(_ context.Context, _, newCfg config.Config) error {
+		levels <- newCfg.Log.Level
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- r.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	t.Run("file changed", func(t *testing.T) {
+		// Наблюдение начинается асинхронно, поэтому файл меняется, пока изменение не будет замечено.
+		replaceInFile(t, path, `level = "info"`, `level = "debug"`)
+		require.Eventually(t, func() bool {
+			select {
+			case lvl := <-levels:
+				assert.Equal(t, "debug", lvl)
+				return true
+			default:
+				touch(t, path)
+				return false
+			}
+		}, 5*time.Second, 100*time.Millisecond)
+	})
+
+	t.Run("sighup", func(t *testing.T) {
+		// Подменяем файл переименованием, как это делают редакторы.
+		tmp := path + ".tmp"
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(tmp, []byte(strings.Replace(string(data),
+			`level = "debug"`, `level = "warn"`, 1)), 0o600))
+		require.NoError(t, os.Rename(tmp, path))
+
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+		select {
+		case lvl := <-levels:
+			assert.Equal(t, "warn", lvl)
+		case <-time.After(5 * time.Second):
+			t.Fatal("config was not reloaded")
+		}
+	})
+}
+
+func newReloader(t *testing.T, path string, opts ...reloader.OptOptionsSetter) *reloader.Reloader {
+	t.Helper()
+
+	cfg, err := config.ParseAndValidate(path)
+	require.NoError(t, err)
+
+	opts = append([]reloader.OptOptionsSetter{reloader.WithDebounce(50 * time.Millisecond)}, opts...)
+	r, err := reloader.New(reloader.NewOptions(path, cfg, opts...))
+	require.NoError(t, err)
+	return r
+}
+
+func copyExampleConfig(t *testing.T) string {
+	t.Helper()
+
+	_, currentFile, _, _ := runtime.Caller(0)
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(currentFile), "..", "..", "..", "configs", "config.example.toml"))
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func replaceInFile(t *testing.T, path, old, new string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(data), old)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(data), old, new, 1)), 0o600))
+}
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const durationPattern = `^([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+// JSONSchema возвращает JSON Schema конфига для проверки и автодополнения в редакторах.
+// Схема строится по тегам toml, validate, env-default и deprecated. Обязательными отмечаются только
+// несекретные поля без значения по умолчанию: секреты обычно передаются через переменные окружения.
+func JSONSchema() ([]byte, error) {
+	schema := typeSchema(reflect.TypeOf(Config{}), nil)
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = "chat-service config"
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+func typeSchema(t reflect.Type, rules []string) map[string]any {
+	// Правила после dive относятся к элементам среза или значениям словаря.
+	var elemRules []string
+	if i := slices.Index(rules, "dive"); i >= 0 {
+		rules, elemRules = rules[:i], rules[i+1:]
+	}
+
+	// Необязательные секции задаются указателями.
+	if t.Kind() == reflect.Pointer {
+		return typeSchema(t.Elem(), rules)
+	}
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return map[string]any{"type": "string", "pattern": durationPattern}
+	}
+
+	s := make(map[string]any)
+	switch t.Kind() { //nolint:exhaustive // config uses only these kinds
+	case reflect.Struct:
+		props := make(map[string]any, t.NumField())
+		var required []string
+		for i := range t.NumField() {
+			f := t.Field(i)
+			name := f.Tag.Get("toml")
+			fieldRules := strings.Split(f.Tag.Get("validate"), ",")
+			fieldSchema := typeSchema(f.Type, fieldRules)
+			def, hasDefault := f.Tag.Lookup("env-default")
+			if hasDefault {
+				fieldSchema["default"] = defaultValue(f.Type, def)
+			}
+			if f.Tag.Get("deprecated") == "true" {
+				fieldSchema["deprecated"] = true
+			}
+			props[name] = fieldSchema
+			if slices.Contains(fieldRules, "required") && !hasDefault && f.Tag.Get("secret") != "true" {
+				required = append(required, name)
+			}
+		}
+		s["type"] = "object"
+		s["properties"] = props
+		s["additionalProperties"] = false
+		if len(required) > 0 {
+			s["required"] = required
+		}
+
+	case reflect.Map:
+		s["type"] = "object"
+		s["additionalProperties"] = typeSchema(t.Elem(), elemRules)
+
+	case reflect.Slice:
+		s["type"] = "array"
+		s["items"] = typeSchema(t.Elem(), elemRules)
+		if slices.Contains(rules, "required") {
+			s["minItems"] = 1
+		}
+		if n, ok := ruleParam(rules, "min"); ok {
+			s["minItems"], _ = strconv.Atoi(n)
+		}
+
+	case reflect.String:
+		s["type"] = "string"
+		if values, ok := ruleParam(rules, "oneof"); ok {
+			s["enum"] = strings.Fields(values)
+		}
+		if slices.Contains(rules, "url") || slices.Contains(rules, "uri") {
+			s["format"] = "uri"
+		}
+
+	case reflect.Bool:
+		s["type"] = "boolean"
+
+	case reflect.Int, reflect.Int64:
+		s["type"] = "integer"
+		setNumberBounds(s, rules)
+
+	case reflect.Float64:
+		s["type"] = "number"
+		setNumberBounds(s, rules)
+	}
+	return s
+}
+
+// defaultValue переводит значение тега env-default в значение JSON того же типа, что и поле.
+// Элементы срезов разделяются запятыми, как в cleanenv.
+func defaultValue(t reflect.Type, def string) any {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return def
+	}
+	switch t.Kind() { //nolint:exhaustive // config uses only these kinds
+	case reflect.Slice:
+		values := make([]any, 0)
+		for _, v := range strings.Split(def, ",") {
+			values = append(values, defaultValue(t.Elem(), v))
+		}
+		return values
+	case reflect.Bool:
+		v, _ := strconv.ParseBool(def)
+		return v
+	case reflect.Int, reflect.Int64:
+		v, _ := strconv.ParseInt(def, 10, 64)
+		return v
+	case reflect.Float64:
+		v, _ := strconv.ParseFloat(def, 64)
+		return v
+	}
+	return def
+}
+
+func setNumberBounds(s map[string]any, rules []string) {
+	for rule, keyword := range map[string]string{
+		"min": "minimum",
+		"max": "maximum",
+		"gte": "minimum",
+		"lte": "maximum",
+		"gt":  "exclusiveMinimum",
+		"lt":  "exclusiveMaximum",
+	} {
+		if p, ok := ruleParam(rules, rule); ok {
+			if v, err := strconv.ParseFloat(p, 64); err == nil {
+				s[keyword] = v
+			}
+		}
+	}
+}
+
+// ruleParam возвращает параметр правила validate, например "1" для "min=1".
+func ruleParam(rules []string, name string) (string, bool) {
+	for _, r := range rules {
+		if p, ok := strings.CutPrefix(r, name+"="); ok {
+			return p, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,52 @@
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+)
+
+func TestJSONSchema(t *testing.T) {
+	schema, err := config.JSONSchema()
+	require.NoError(t, err)
+
+	var s map[string]any
+	require.NoError(t, json.Unmarshal(schema, &s))
+
+	prop := func(path ...string) any {
+		var v any = s
+		for _, p := range path {
+			v = v.(map[string]any)["properties"].(map[string]any)[p]
+		}
+		return v
+	}
+
+	assert.Equal(t, map[string]any{"type": "string", "enum": []any{"dev", "stage", "prod"}}, prop("global", "env"))
+	assert.Equal(t, map[string]any{"type": "number", "exclusiveMinimum": 0.0, "maximum": 1.0, "default": 1.0},
+		prop("tracing", "sampler_ratio"))
+	assert.Equal(t, "string", prop("health", "check_timeout").(map[string]any)["type"])
+	assert.Equal(t, map[string]any{
+		"type":     "array",
+		"minItems": 1.0,
+		"items":    map[string]any{"type": "string", "format": "uri"},
+	}, prop("servers", "client", "allow_origins"))
+	assert.Equal(t, map[string]any{"type": "number", "exclusiveMinimum": 0.0, "default": 50.0},
+		prop("servers", "client", "rate_limit", "per_ip", "rate"))
+	// Секреты не обязательны в файле: их передают через переменные окружения.
+	assert.Equal(t, []any{"base_path", "realm", "client_id"}, prop("clients", "keycloak").(map[string]any)["required"])
+}
+
+func TestJSONSchema_UpToDate(t *testing.T) {
+	schema, err := config.JSONSchema()
+	require.NoError(t, err)
+
+	committed, err := os.ReadFile(filepath.Join(filepath.Dir(configExamplePath), "config.schema.json"))
+	require.NoError(t, err)
+	assert.Equal(t, string(schema)+"\n", string(committed), "run `task gen:config-schema`")
+}
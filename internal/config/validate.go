@@ -0,0 +1,167 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Правила, которые проверяют несколько полей конфига сразу.
+const (
+	ruleNotInProd       = "not_in_prod"
+	ruleStorageRequired = "required_for_storage"
+	ruleKnownKeyID      = "known_key_id"
+	ruleDistinctAddrs   = "distinct_addrs"
+	ruleRequiresEnabled = "requires_enabled"
+)
+
+// FieldError - ошибка валидации поля конфига.
+type FieldError struct {
+	// Path - путь к полю в TOML, например "clients.keycloak.client_secret".
+	Path string
+	// Rule - нарушенное правило, например "required" или "oneof=dev stage prod".
+	Rule    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError содержит все ошибки валидации конфига.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Error())
+	}
+	return "invalid config:\n\t" + strings.Join(msgs, "\n\t")
+}
+
+// Validate проверяет конфиг: теги validate полей и правила, связывающие несколько полей.
+// Возвращает *ValidationError со всеми найденными ошибками.
+func Validate(cfg Config) error {
+	v := validator.New()
+	// Поля называются по ключам TOML, чтобы ошибки указывали на место в файле конфига.
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		return f.Tag.Get("toml")
+	})
+	v.RegisterStructValidation(validateCrossFields, Config{})
+
+	err := v.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	res := &ValidationError{Fields: make([]FieldError, 0, len(verrs))}
+	for _, fe := range verrs {
+		res.Fields = append(res.Fields, newFieldError(fe))
+	}
+	return res
+}
+
+// validateCrossFields проверяет правила, которые нельзя выразить тегами отдельных полей.
+func validateCrossFields(sl validator.StructLevel) {
+	cfg := sl.Current().Interface().(Config) //nolint:forcetypeassert // registered for Config only
+
+	// Отладочный режим клиентов пишет в лог токены и запросы с данными пользователей.
+	if cfg.Global.Env == "prod" {
+		if cfg.Clients.Keycloak.DebugMode {
+			sl.ReportError(cfg.Clients.Keycloak.DebugMode, "clients.keycloak.debug_mode", "DebugMode", ruleNotInProd, "")
+		}
+		if cfg.Clients.PSQL.DebugMode {
+			sl.ReportError(cfg.Clients.PSQL.DebugMode, "clients.psql.debug_mode", "DebugMode", ruleNotInProd, "")
+		}
+	}
+
+	if cfg.Servers.Debug.Addr != "" && cfg.Servers.Debug.Addr == cfg.Servers.Client.Addr {
+		sl.ReportError(cfg.Servers.Debug.Addr, "servers.debug.addr", "Addr", ruleDistinctAddrs, "servers.client.addr")
+	}
+
+	// Настройки выбранного хранилища вложений обязательны.
+	att := cfg.Services.Attachments
+	var required [][2]string // путь и значение
+	switch att.Storage {
+	case "local":
+		required = [][2]string{
+			{"services.attachments.local.dir", att.Local.Dir},
+			{"services.attachments.local.base_url", att.Local.BaseURL},
+			{"services.attachments.local.signing_key", att.Local.SigningKey},
+		}
+	case "s3":
+		required = [][2]string{
+			{"services.attachments.s3.endpoint", att.S3.Endpoint},
+			{"services.attachments.s3.bucket", att.S3.Bucket},
+		}
+	}
+	for _, r := range required {
+		if r[1] == "" {
+			sl.ReportError(r[1], r[0], r[0], ruleStorageRequired, att.Storage)
+		}
+	}
+
+	enc := cfg.Services.Encryption
+	// Исходный текст содержит замаскированные номера карт и CVV, в открытом виде его хранить нельзя.
+	if cfg.Services.Redactor.KeepOriginal && !enc.Enabled {
+		sl.ReportError(cfg.Services.Redactor.KeepOriginal, "services.redactor.keep_original", "KeepOriginal",
+			ruleRequiresEnabled, "services.encryption")
+	}
+	if enc.Enabled && enc.CurrentKeyID != "" {
+		known := false
+		for _, k := range enc.MasterKeys {
+			known = known || k.ID == enc.CurrentKeyID
+		}
+		if !known {
+			sl.ReportError(enc.CurrentKeyID, "services.encryption.current_key_id", "CurrentKeyID",
+				ruleKnownKeyID, "services.encryption.master_keys")
+		}
+	}
+}
+
+func newFieldError(fe validator.FieldError) FieldError {
+	rule := fe.Tag()
+	if fe.Param() != "" {
+		rule += "=" + fe.Param()
+	}
+	return FieldError{
+		Path:    strings.TrimPrefix(fe.Namespace(), "Config."),
+		Rule:    rule,
+		Message: fieldErrorMessage(fe),
+	}
+}
+
+func fieldErrorMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_if", "required_with", "required_without":
+		return fmt.Sprintf("is required (%s=%s)", fe.Tag(), fe.Param())
+	case "oneof":
+		return fmt.Sprintf("must be one of [%s], got %q", fe.Param(), fmt.Sprint(fe.Value()))
+	case ruleNotInProd:
+		return `must be disabled when global.env = "prod"`
+	case ruleStorageRequired:
+		return fmt.Sprintf("is required when services.attachments.storage = %q", fe.Param())
+	case ruleKnownKeyID:
+		return fmt.Sprintf("must match an id in %s", fe.Param())
+	case ruleDistinctAddrs:
+		return fmt.Sprintf("must differ from %s", fe.Param())
+	case ruleRequiresEnabled:
+		return fmt.Sprintf("requires %s.enabled = true", fe.Param())
+	}
+	if fe.Param() != "" {
+		return fmt.Sprintf("failed %s=%s validation", fe.Tag(), fe.Param())
+	}
+	return fmt.Sprintf("failed %s validation", fe.Tag())
+}
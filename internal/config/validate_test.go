@@ -0,0 +1,161 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+)
+
+func TestValidate(t *testing.T) {
+	valid, err := config.ParseAndValidate(configExamplePath)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		modify func(cfg *config.Config)
+		errors []config.FieldError
+	}{
+		{
+			name:   "valid",
+			modify: func(*config.Config) {},
+		},
+		{
+			name: "field rules use toml paths",
+			modify: func(cfg *config.Config) {
+				cfg.Global.Env = "qa"
+				cfg.Clients.Keycloak.ClientSecret = ""
+				cfg.Servers.Client.RateLimit.Operations = map[string]config.RateLimitPolicyConfig{"getHistory": {Rate: 0, Burst: 1}}
+			},
+			errors: []config.FieldError{
+				{Path: "global.env", Rule: "oneof=dev stage prod", Message: `must be one of [dev stage prod], got "qa"`},
+				{Path: "servers.client.rate_limit.operations[getHistory].rate", Rule: "gt=0", Message: "failed gt=0 validation"},
+				{Path: "clients.keycloak.client_secret", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name: "debug mode in prod",
+			modify: func(cfg *config.Config) {
+				cfg.Global.Env = "prod"
+				cfg.Clients.Keycloak.DebugMode = true
+				cfg.Clients.PSQL.DebugMode = true
+			},
+			errors: []config.FieldError{
+				{Path: "clients.keycloak.debug_mode", Rule: "not_in_prod", Message: `must be disabled when global.env = "prod"`},
+				{Path: "clients.psql.debug_mode", Rule: "not_in_prod", Message: `must be disabled when global.env = "prod"`},
+			},
+		},
+		{
+			name: "debug mode outside prod",
+			modify: func(cfg *config.Config) {
+				cfg.Global.Env = "stage"
+				cfg.Clients.Keycloak.DebugMode = true
+			},
+		},
+		{
+			name: "same server addresses",
+			modify: func(cfg *config.Config) {
+				cfg.Servers.Debug.Addr = cfg.Servers.Client.Addr
+			},
+			errors: []config.FieldError{
+				{Path: "servers.debug.addr", Rule: "distinct_addrs=servers.client.addr", Message: "must differ from servers.client.addr"},
+			},
+		},
+		{
+			name: "tls",
+			modify: func(cfg *config.Config) {
+				cfg.Servers.Debug.TLSCertFile = configExamplePath
+				cfg.Servers.Debug.H2C = true
+				cfg.Servers.Client.TLSMinVersion = "1.1"
+			},
+			errors: []config.FieldError{
+				{Path: "servers.debug.tls_key_file", Rule: "required_with=TLSCertFile", Message: "is required (required_with=TLSCertFile)"},
+				{Path: "servers.debug.h2c", Rule: "excluded_with=TLSCertFile", Message: "failed excluded_with=TLSCertFile validation"},
+				{Path: "servers.client.tls_min_version", Rule: "oneof=1.2 1.3", Message: `must be one of [1.2 1.3], got "1.1"`},
+			},
+		},
+		{
+			name: "cors and route overrides",
+			modify: func(cfg *config.Config) {
+				cfg.Servers.Client.CORS.AllowMethods = []string{"POST", "TRACE"}
+				cfg.Servers.Client.Routes = map[string]config.ClientRouteConfig{
+					"uploadAttachment": {
+						BodyLimit:     -1,
+						CORS:          &config.CORSConfig{MaxAge: time.Minute},
+						SecureHeaders: &config.SecureHeadersConfig{FrameOptions: "ALLOW"},
+					},
+				}
+			},
+			errors: []config.FieldError{
+				{
+					Path:    "servers.client.cors.allow_methods[1]",
+					Rule:    "oneof=GET HEAD POST PUT PATCH DELETE OPTIONS",
+					Message: `must be one of [GET HEAD POST PUT PATCH DELETE OPTIONS], got "TRACE"`,
+				},
+				{Path: "servers.client.routes[uploadAttachment].body_limit", Rule: "min=0", Message: "failed min=0 validation"},
+				{Path: "servers.client.routes[uploadAttachment].cors.allow_methods", Rule: "required", Message: "is required"},
+				{
+					Path:    "servers.client.routes[uploadAttachment].secure_headers.frame_options",
+					Rule:    "oneof=DENY SAMEORIGIN",
+					Message: `must be one of [DENY SAMEORIGIN], got "ALLOW"`,
+				},
+			},
+		},
+		{
+			name: "settings of selected storage",
+			modify: func(cfg *config.Config) {
+				cfg.Services.Attachments.Storage = "s3"
+				cfg.Services.Attachments.S3.Bucket = ""
+			},
+			errors: []config.FieldError{{
+				Path:    "services.attachments.s3.bucket",
+				Rule:    "required_for_storage=s3",
+				Message: `is required when services.attachments.storage = "s3"`,
+			}},
+		},
+		{
+			name: "original body without encryption",
+			modify: func(cfg *config.Config) {
+				cfg.Services.Redactor.KeepOriginal = true
+				cfg.Services.Encryption.Enabled = false
+			},
+			errors: []config.FieldError{{
+				Path:    "services.redactor.keep_original",
+				Rule:    "requires_enabled=services.encryption",
+				Message: "requires services.encryption.enabled = true",
+			}},
+		},
+		{
+			name: "unknown current key",
+			modify: func(cfg *config.Config) {
+				cfg.Services.Encryption.Enabled = true
+				cfg.Services.Encryption.CurrentKeyID = "2025-01"
+			},
+			errors: []config.FieldError{{
+				Path:    "services.encryption.current_key_id",
+				Rule:    "known_key_id=services.encryption.master_keys",
+				Message: "must match an id in services.encryption.master_keys",
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := valid
+			tc.modify(&cfg)
+
+			err := config.Validate(cfg)
+			if len(tc.errors) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var verr *config.ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Equal(t, tc.errors, verr.Fields)
+		})
+	}
+}
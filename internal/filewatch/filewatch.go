@@ -0,0 +1,115 @@
+package filewatch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// Причины вызова обработчика.
+const (
+	TriggerFile   = "file"
+	TriggerSignal = "signal"
+)
+
+//go:generate options-gen -out-filename=filewatch_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	lg    *zap.Logger `option:"mandatory" validate:"required"`
+	files []string    `option:"mandatory" validate:"min=1"`
+	// onChange вызывается после изменения файлов или получения сигнала с причиной TriggerFile или TriggerSignal.
+	onChange func(ctx context.Context, trigger string) `option:"mandatory" validate:"required"`
+	// debounce - сколько ждать после последнего изменения файлов: редакторы сохраняют файл несколькими операциями,
+	// а сертификат и ключ обычно обновляются по очереди.
+	debounce time.Duration `validate:"min=0"`
+	// signals - сигналы, по которым обработчик вызывается сразу, например SIGHUP.
+	signals []os.Signal
+}
+
+var defaultOptions = Options{
+	debounce: 500 * time.Millisecond,
+}
+
+// Watcher вызывает обработчик при изменении файлов. Следит за каталогами, а не за файлами:
+// редакторы и Kubernetes (например, для секретов) заменяют файл переименованием,
+// после чего наблюдение за самим файлом теряется.
+type Watcher struct {
+	Options
+}
+
+func New(opts Options) (*Watcher, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &Watcher{Options: opts}, nil
+}
+
+// Run следит за файлами и сигналами, пока не завершится контекст.
+// Обработчик вызывается из той же горутины, поэтому вызовы не пересекаются.
+func (w *Watcher) Run(ctx context.Context) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create watcher: %v", err)
+	}
+	defer func() {
+		if err := fw.Close(); err != nil {
+			w.lg.Warn("close watcher", zap.Error(err))
+		}
+	}()
+
+	var dirs []string
+	for _, f := range w.files {
+		if dir := filepath.Dir(f); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	for _, dir := range dirs {
+		if err := fw.Add(dir); err != nil {
+			return fmt.Errorf("watch %q: %v", dir, err)
+		}
+	}
+
+	sig := make(chan os.Signal, 1)
+	if len(w.signals) > 0 {
+		signal.Notify(sig, w.signals...)
+		defer signal.Stop(sig)
+	}
+
+	debounce := time.NewTimer(w.debounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-sig:
+			w.onChange(ctx, TriggerSignal)
+
+		case ev, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			if ev.Has(fsnotify.Chmod) {
+				continue
+			}
+			debounce.Reset(w.debounce)
+
+		case <-debounce.C:
+			w.onChange(ctx, TriggerFile)
+
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			w.lg.Warn("watch files", zap.Error(err))
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Code generated by options-gen. DO NOT EDIT.
+package filewatch
+
+import (
+	"context"
+	fmt461e464ebed9 "fmt"
+	"os"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+	"go.uber.org/zap"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	lg *zap.Logger,
+	files []string,
+	onChange func(ctx context.Context, trigger string),
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.lg = defaultOptions.lg
+
+	o.files = defaultOptions.files
+
+	o.onChange = defaultOptions.onChange
+
+	o.debounce = defaultOptions.debounce
+
+	o.signals = defaultOptions.signals
+
+	o.lg = lg
+
+	o.files = files
+
+	o.onChange = onChange
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// debounce - сколько ждать после последнего изменения файлов: редакторы сохраняют файл несколькими операциями,
+// а сертификат и ключ обычно обновляются по очереди.
+func WithDebounce(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.debounce = opt
+
+	}
+}
+
+// signals - сигналы, по которым обработчик вызывается сразу, например SIGHUP.
+func WithSignals(opt []os.Signal) OptOptionsSetter {
+	return func(o *Options) {
+		o.signals = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("lg", _validate_Options_lg(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("files", _validate_Options_files(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("onChange", _validate_Options_onChange(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("debounce", _validate_Options_debounce(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_lg(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.lg, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `lg` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_files(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.files, "min=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `files` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_onChange(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.onChange, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `onChange` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_debounce(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.debounce, "min=0"); err != nil {
+		return fmt461e464ebed9.Errorf("field `debounce` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package filewatch_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/filewatch"
+)
+
+func TestWatcher_Run(t *testing.T) {
+	dir := t.TempDir()
+	cert, key := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	for _, f := range []string{cert, key} {
+		require.NoError(t, os.WriteFile(f, []byte("v1"), 0o600))
+	}
+
+	triggers := make(chan string, 10)
+	w, err := filewatch.New(filewatch.NewOptions(zap.NewNop(), []string{cert, key},
+		func(_ context.Context, trigger string) { triggers <- trigger },
+		filewatch.WithDebounce(50*time.Millisecond),
+		filewatch.WithSignals([]os.Signal{syscall.SIGUSR1}),
+	))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- w.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	t.Run("changes are debounced", func(t *testing.T) {
+		// Наблюдение начинается асинхронно, поэтому файлы меняются реже debounce, пока изменение не будет замечено.
+		require.Eventually(t, func() bool {
+			select {
+			case trigger := <-triggers:
+				assert.Equal(t, filewatch.TriggerFile, trigger)
+				return true
+			default:
+				require.NoError(t, os.WriteFile(cert, []byte("v2"), 0o600))
+				require.NoError(t, os.WriteFile(key, []byte("v2"), 0o600))
+				return false
+			}
+		}, 5*time.Second, 200*time.Millisecond)
+
+		// Сертификат и ключ, измененные друг за другом, дают один вызов.
+		for len(triggers) > 0 {
+			<-triggers
+		}
+		require.NoError(t, os.WriteFile(cert, []byte("v3"), 0o600))
+		require.NoError(t, os.WriteFile(key, []byte("v3"), 0o600))
+		select {
+		case trigger := <-triggers:
+			assert.Equal(t, filewatch.TriggerFile, trigger)
+		case <-time.After(5 * time.Second):
+			t.Fatal("change was not noticed")
+		}
+		select {
+		case <-triggers:
+			t.Fatal("changes were not debounced")
+		case <-time.After(300 * time.Millisecond):
+		}
+	})
+
+	t.Run("signal", func(t *testing.T) {
+		require.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1))
+		select {
+		case trigger := <-triggers:
+			assert.Equal(t, filewatch.TriggerSignal, trigger)
+		case <-time.After(5 * time.Second):
+			t.Fatal("signal was not handled")
+		}
+	})
+}
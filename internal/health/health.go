@@ -0,0 +1,155 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+var ErrShuttingDown = errors.New("service is shutting down")
+
+// CheckFunc проверяет доступность зависимости.
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name    string
+	timeout time.Duration
+	fn      CheckFunc
+}
+
+//go:generate options-gen -out-filename=health_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	// defaultTimeout - таймаут проверки, если он не задан при регистрации.
+	defaultTimeout time.Duration `validate:"min=1ms"`
+}
+
+var defaultOptions = Options{
+	defaultTimeout: time.Second,
+}
+
+// Health агрегирует проверки зависимостей для readiness-пробы.
+type Health struct {
+	Options
+
+	mu     sync.RWMutex
+	checks []check
+
+	shuttingDown atomic.Bool
+}
+
+type CheckResult struct {
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Error  string                 `json:"error,omitempty"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+func New(opts Options) (*Health, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &Health{Options: opts}, nil
+}
+
+// Register добавляет проверку. Если timeout не положительный, используется таймаут по умолчанию.
+func (h *Health) Register(name string, timeout time.Duration, fn CheckFunc) {
+	if timeout <= 0 {
+		timeout = h.defaultTimeout
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks = append(h.checks, check{name: name, timeout: timeout, fn: fn})
+}
+
+// SetShuttingDown переводит сервис в состояние "не готов", чтобы балансировщик перестал
+// направлять на него трафик до остановки серверов.
+func (h *Health) SetShuttingDown() {
+	h.shuttingDown.Store(true)
+}
+
+// Ready выполняет все проверки параллельно, каждую со своим таймаутом.
+func (h *Health) Ready(ctx context.Context) Report {
+	if h.shuttingDown.Load() {
+		return Report{Status: StatusFail, Error: ErrShuttingDown.Error()}
+	}
+
+	h.mu.RLock()
+	checks := h.checks
+	h.mu.RUnlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+	for i, c := range checks {
+		report.Checks[c.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// LiveHandler отвечает, что процесс жив. Зависимости не проверяются.
+func (h *Health) LiveHandler(eCtx echo.Context) error {
+	return eCtx.JSON(http.StatusOK, Report{Status: StatusOK})
+}
+
+// ReadyHandler отвечает 200, если все проверки прошли, и 503 в противном случае.
+func (h *Health) ReadyHandler(eCtx echo.Context) error {
+	report := h.Ready(eCtx.Request().Context())
+	if report.Status != StatusOK {
+		return eCtx.JSON(http.StatusServiceUnavailable, report)
+	}
+	return eCtx.JSON(http.StatusOK, report)
+}
+
+func (c check) run(ctx context.Context) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() { errCh <- c.fn(ctx) }()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		// Проверка может не уважать контекст, не ждем ее дольше таймаута.
+		err = ctx.Err()
+	}
+
+	res := CheckResult{Status: StatusOK, Duration: time.Since(start).String()}
+	if err != nil {
+		res.Status = StatusFail
+		res.Error = err.Error()
+	}
+	return res
+}
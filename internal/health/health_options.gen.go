@@ -0,0 +1,47 @@
+// Code generated by options-gen. DO NOT EDIT.
+package health
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.defaultTimeout = defaultOptions.defaultTimeout
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// defaultTimeout - таймаут проверки, если он не задан при регистрации.
+func WithDefaultTimeout(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.defaultTimeout = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("defaultTimeout", _validate_Options_defaultTimeout(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_defaultTimeout(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.defaultTimeout, "min=1ms"); err != nil {
+		return fmt461e464ebed9.Errorf("field `defaultTimeout` did not pass the test: %w", err)
+	}
+	return nil
+}
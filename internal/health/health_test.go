@@ -0,0 +1,83 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/health"
+)
+
+func TestHealth_Ready(t *testing.T) {
+	ctx := context.Background()
+
+	h, err := health.New(health.NewOptions(health.WithDefaultTimeout(50 * time.Millisecond)))
+	require.NoError(t, err)
+
+	// Без проверок сервис готов.
+	assert.Equal(t, health.StatusOK, h.Ready(ctx).Status)
+
+	h.Register("ok", 0, func(context.Context) error { return nil })
+	report := h.Ready(ctx)
+	assert.Equal(t, health.StatusOK, report.Status)
+	assert.Equal(t, health.StatusOK, report.Checks["ok"].Status)
+
+	h.Register("failed", 0, func(context.Context) error { return errors.New("connection refused") })
+	// Проверка, игнорирующая контекст, не должна задерживать пробу дольше своего таймаута.
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+	h.Register("hanging", 10*time.Millisecond, func(context.Context) error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	report = h.Ready(ctx)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	assert.Equal(t, health.StatusFail, report.Status)
+	assert.Equal(t, health.StatusOK, report.Checks["ok"].Status)
+	assert.Equal(t, "connection refused", report.Checks["failed"].Error)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["hanging"].Error)
+}
+
+func TestHealth_Handlers(t *testing.T) {
+	h, err := health.New(health.NewOptions())
+	require.NoError(t, err)
+	h.Register("db", 0, func(context.Context) error { return nil })
+
+	e := echo.New()
+	e.GET("/health/live", h.LiveHandler)
+	e.GET("/health/ready", h.ReadyHandler)
+
+	get := func(path string) (int, health.Report) {
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, path, nil))
+
+		var report health.Report
+		require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &report))
+		return resp.Code, report
+	}
+
+	code, report := get("/health/ready")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.StatusOK, report.Checks["db"].Status)
+
+	h.SetShuttingDown()
+
+	code, report = get("/health/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, health.ErrShuttingDown.Error(), report.Error)
+
+	// Liveness не зависит от остановки.
+	code, _ = get("/health/live")
+	assert.Equal(t, http.StatusOK, code)
+}
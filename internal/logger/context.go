@@ -0,0 +1,45 @@
+package logger
+
+import (
+	"context"
+	"slices"
+
+	"go.uber.org/zap"
+)
+
+type ctxKey struct{}
+
+type ctxValue struct {
+	lg     *zap.Logger
+	fields []zap.Field
+}
+
+// NewContext возвращает контекст с логгером запроса lg.With(fields...).
+// Поля запоминаются вместе с полями, уже лежащими в контексте, чтобы их могли добавить
+// логгеры других компонентов (см. With).
+func NewContext(ctx context.Context, lg *zap.Logger, fields ...zap.Field) context.Context {
+	var all []zap.Field
+	if v, ok := ctx.Value(ctxKey{}).(ctxValue); ok {
+		all = slices.Clone(v.fields)
+	}
+	all = append(all, fields...)
+
+	return context.WithValue(ctx, ctxKey{}, ctxValue{lg: lg.With(fields...), fields: all})
+}
+
+// FromContext возвращает логгер запроса или глобальный логгер, если в контексте его нет.
+func FromContext(ctx context.Context) *zap.Logger {
+	if v, ok := ctx.Value(ctxKey{}).(ctxValue); ok {
+		return v.lg
+	}
+	return zap.L()
+}
+
+// With возвращает логгер компонента lg, дополненный полями запроса из контекста.
+// Уровень логирования при этом остается уровнем компонента.
+func With(ctx context.Context, lg *zap.Logger) *zap.Logger {
+	if v, ok := ctx.Value(ctxKey{}).(ctxValue); ok && len(v.fields) > 0 {
+		return lg.With(v.fields...)
+	}
+	return lg
+}
@@ -0,0 +1,172 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// InheritLevel - значение уровня компонента, при котором компонент следует глобальному уровню.
+const InheritLevel = "inherit"
+
+var ErrUnknownComponent = errors.New("unknown component")
+
+// baseLogger - логгер без фильтрации по уровню, от него строятся логгеры компонентов. Задается в Init.
+var baseLogger atomic.Pointer[zap.Logger]
+
+var components = struct {
+	sync.RWMutex
+	levels map[string]*componentLevel
+}{levels: make(map[string]*componentLevel)}
+
+// ComponentLevel описывает текущий уровень логирования компонента.
+type ComponentLevel struct {
+	Component string `json:"component"`
+	Level     string `json:"level"`
+	// Inherited - компонент следует глобальному уровню.
+	Inherited bool `json:"inherited"`
+}
+
+// Named возвращает логгер компонента и регистрирует компонент,
+// чтобы его уровень можно было менять независимо от глобального.
+// Пока уровень компонента не задан, он следует GlobalLevel.
+//
+// Логгер строится от логгера, заданного Init к моменту вызова, и не меняется при следующих Init.
+// До Init возвращается логгер от глобального логгера zap (по умолчанию ничего не пишущего),
+// поэтому Init вызывается при запуске до создания компонентов.
+func Named(component string) *zap.Logger {
+	lvl := registerComponent(component)
+	base := baseLogger.Load()
+	if base == nil {
+		return zap.L().Named(component)
+	}
+	return base.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return newLevelCore(c, lvl)
+	})).Named(component)
+}
+
+// Components возвращает уровни всех зарегистрированных компонентов, отсортированные по имени.
+func Components() []ComponentLevel {
+	components.RLock()
+	defer components.RUnlock()
+
+	res := make([]ComponentLevel, 0, len(components.levels))
+	for name, lvl := range components.levels {
+		res = append(res, lvl.describe(name))
+	}
+	slices.SortFunc(res, func(a, b ComponentLevel) int {
+		switch {
+		case a.Component < b.Component:
+			return -1
+		case a.Component > b.Component:
+			return 1
+		}
+		return 0
+	})
+	return res
+}
+
+// GetComponentLevel возвращает уровень логирования компонента.
+func GetComponentLevel(component string) (ComponentLevel, error) {
+	components.RLock()
+	defer components.RUnlock()
+
+	lvl, ok := components.levels[component]
+	if !ok {
+		return ComponentLevel{}, fmt.Errorf("%q: %w", component, ErrUnknownComponent)
+	}
+	return lvl.describe(component), nil
+}
+
+// SetComponentLevel меняет уровень логирования компонента.
+// Значение InheritLevel возвращает компонент к глобальному уровню.
+func SetComponentLevel(component, level string) error {
+	components.RLock()
+	lvl, ok := components.levels[component]
+	components.RUnlock()
+	if !ok {
+		return fmt.Errorf("%q: %w", component, ErrUnknownComponent)
+	}
+
+	if level == InheritLevel {
+		lvl.override.Store(nil)
+		return nil
+	}
+
+	l, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	lvl.override.Store(&l)
+	return nil
+}
+
+func registerComponent(component string) *componentLevel {
+	components.Lock()
+	defer components.Unlock()
+
+	lvl, ok := components.levels[component]
+	if !ok {
+		lvl = new(componentLevel)
+		components.levels[component] = lvl
+	}
+	return lvl
+}
+
+// componentLevel - уровень компонента: собственный, если задан, иначе глобальный.
+type componentLevel struct {
+	override atomic.Pointer[zapcore.Level]
+}
+
+func (l *componentLevel) Level() zapcore.Level {
+	if lvl := l.override.Load(); lvl != nil {
+		return *lvl
+	}
+	return GlobalLevel.Level()
+}
+
+func (l *componentLevel) Enabled(lvl zapcore.Level) bool {
+	return lvl >= l.Level()
+}
+
+func (l *componentLevel) describe(component string) ComponentLevel {
+	return ComponentLevel{
+		Component: component,
+		Level:     l.Level().String(),
+		Inherited: l.override.Load() == nil,
+	}
+}
+
+// levelCore отбрасывает записи ниже уровня enabler, остальные передает в обернутое ядро.
+type levelCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+}
+
+func newLevelCore(core zapcore.Core, enabler zapcore.LevelEnabler) zapcore.Core {
+	return &levelCore{Core: core, enabler: enabler}
+}
+
+func (c *levelCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.enabler)
+}
+
+func (c *levelCore) Enabled(lvl zapcore.Level) bool {
+	return c.enabler.Enabled(lvl) && c.Core.Enabled(lvl)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), enabler: c.enabler}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.enabler.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
@@ -0,0 +1,72 @@
+package logger_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+)
+
+func ExampleSetComponentLevel() {
+	if err := logger.Init(logger.NewOptions(
+		"info",
+		logger.WithProductionMode(true),
+		logger.WithClock(fakeClock{}),
+	)); err != nil {
+		panic(err)
+	}
+
+	kcLogger := logger.Named("example-keycloak")
+	srvLogger := logger.Named("example-server")
+
+	if err := logger.SetComponentLevel("example-keycloak", "debug"); err != nil {
+		panic(err)
+	}
+	kcLogger.Debug("token introspected")
+	srvLogger.Debug("request handled")
+	zap.L().Debug("global debug")
+
+	if err := logger.SetComponentLevel("example-keycloak", logger.InheritLevel); err != nil {
+		panic(err)
+	}
+	kcLogger.Debug("token introspected again")
+	kcLogger.Info("still logged")
+
+	// Output:
+	// {"level":"DEBUG","T":"2024-01-01T00:00:01.000Z","component":"example-keycloak","msg":"token introspected"}
+	// {"level":"INFO","T":"2024-01-01T00:00:01.000Z","component":"example-keycloak","msg":"still logged"}
+}
+
+func TestInit_ConcurrentWithComponents(t *testing.T) {
+	require.NoError(t, logger.Init(logger.NewOptions("error")))
+
+	// Повторный Init, например в тестах, не должен гоняться с логгерами компонентов, созданными раньше.
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lg := logger.Named("concurrent-init")
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				lg.Debug("not logged")
+				logger.Named("concurrent-init").Debug("not logged")
+			}
+		}
+	}()
+
+	for range 10 {
+		require.NoError(t, logger.Init(logger.NewOptions("error")))
+	}
+	close(stop)
+	<-done
+
+	lvl, err := logger.GetComponentLevel("concurrent-init")
+	require.NoError(t, err)
+	assert.Equal(t, "error", lvl.Level)
+}
@@ -21,7 +21,16 @@ type Options struct {
 	productionMode bool
 	clock          zapcore.Clock
 	dsnSentry      string `validate:"omitempty,url"`
-	env            string `validate:"required,oneof=dev stage prod"`
+	// sentryCAFile - набор корневых сертификатов для проверки сервера Sentry. Если не задан, используются системные.
+	sentryCAFile string
+	env          string `validate:"required,oneof=dev stage prod"`
+	// fileSink - дополнительная запись логов в файл с ротацией.
+	fileSink FileSink
+	// sampling - семплирование многочисленных логов уровня INFO и ниже.
+	sampling Sampling
+	// redactFields - ключи полей, значения которых маскируются до записи в любое ядро, включая Sentry,
+	// в том числе во вложенных объектах.
+	redactFields []string
 }
 
 // type jsonWriter struct {
@@ -35,8 +44,9 @@ var defaultOptions = Options{
 	env:   "dev",                // По умолчанию используем окружение dev
 }
 
-// GlobalLevel - глобальный уровень логирования.
-var GlobalLevel zap.AtomicLevel
+// GlobalLevel - глобальный уровень логирования. Init меняет уровень, но не заменяет сам GlobalLevel,
+// поэтому логгеры компонентов и перечитывание конфига могут читать его одновременно с Init.
+var GlobalLevel = zap.NewAtomicLevel()
 
 // SentryClient - клиент для отправки отчетов в Sentry.
 var SentryClient *sentry.Client
@@ -51,14 +61,19 @@ func MustInit(opts Options) {
 
 // Init - инициализирует логгер с заданными опциями.
 // Если опции не валидны, то функция вернет ошибку.
+// Init вызывается до Named: логгеры, полученные раньше, не узнают о новом логгере (см. Named).
 func Init(opts Options) error {
 	if err := opts.Validate(); err != nil {
 		return fmt.Errorf("validate options: %v", err)
 	}
+	if err := opts.fileSink.validate(); err != nil {
+		return fmt.Errorf("validate options: %v", err)
+	}
+	if err := opts.sampling.validate(); err != nil {
+		return fmt.Errorf("validate options: %v", err)
+	}
 
 	// парсим log level.
-	GlobalLevel = zap.NewAtomicLevel()
-
 	switch opts.level {
 	case "debug":
 		GlobalLevel.SetLevel(zapcore.DebugLevel)
@@ -92,6 +107,9 @@ func Init(opts Options) error {
 		EncodeLevel:    zapcore.CapitalLevelEncoder,
 	}
 
+	// в файл всегда пишем JSON без цвета
+	fileEncoder := zapcore.NewJSONEncoder(encoderConfig)
+
 	// выбираем формат вывода лога
 	var encoder zapcore.Encoder
 	if opts.productionMode {
@@ -102,14 +120,33 @@ func Init(opts Options) error {
 		encoder = zapcore.NewConsoleEncoder(encoderConfig)
 	}
 
-	// создаём новый zapcore.Core на базе STDOUT.
-	cores := []zapcore.Core{
-		zapcore.NewCore(
-			encoder,
-			// zapcore.AddSync(newJSONWriter(os.Stdout)),
-			zapcore.AddSync(os.Stdout),
-			GlobalLevel,
-		),
+	var fileSync zapcore.WriteSyncer
+	if opts.fileSink.enabled() {
+		fileSync = openFileSink(opts.fileSink)
+	}
+
+	// создаём ядра вывода на базе STDOUT и, если задан, файла.
+	// Уровень фильтруется выше: глобальным уровнем или уровнем компонента (см. Named).
+	newOutputCores := func(enabler zapcore.LevelEnabler) []zapcore.Core {
+		cores := []zapcore.Core{
+			zapcore.NewCore(
+				encoder,
+				// zapcore.AddSync(newJSONWriter(os.Stdout)),
+				zapcore.AddSync(os.Stdout),
+				enabler,
+			),
+		}
+		if fileSync != nil {
+			cores = append(cores, zapcore.NewCore(fileEncoder, fileSync, enabler))
+		}
+		return cores
+	}
+
+	var cores []zapcore.Core
+	if opts.sampling.enabled() {
+		cores = append(cores, sampled(opts.sampling, newOutputCores))
+	} else {
+		cores = append(cores, newOutputCores(zapcore.DebugLevel)...)
 	}
 
 	// Если указан DSN для Sentry, настраиваем интеграцию с Sentry
@@ -120,6 +157,7 @@ func Init(opts Options) error {
 			opts.dsnSentry,
 			opts.env,
 			buildinfo.BuildInfo.Main.Version,
+			opts.sentryCAFile,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to initialize Sentry client: %v", err)
@@ -137,11 +175,15 @@ func Init(opts Options) error {
 			return fmt.Errorf("failed to initialize Sentry core: %v", err)
 		}
 		// Добавляем ядро Sentry к существующим ядрам
-		cores = append(cores, core)
+		cores = append(cores, skipSentryCore{Core: core})
 	}
 
-	// создаём новый логгер
-	l := zap.New(zapcore.NewTee(cores...), zap.WithClock(opts.clock))
+	// создаём новый логгер; поля маскируются один раз для всех приёмников
+	base := zap.New(newRedactCore(zapcore.NewTee(cores...), opts.redactFields), zap.WithClock(opts.clock))
+	baseLogger.Store(base)
+	l := base.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return newLevelCore(c, GlobalLevel)
+	}))
 
 	// заменяем глобальный логгер на новый
 	zap.ReplaceGlobals(l)
@@ -154,6 +196,7 @@ func Sync() {
 	if err := zap.L().Sync(); err != nil && !errors.Is(err, syscall.ENOTTY) {
 		stdlog.Printf("cannot sync logger: %v", err)
 	}
+	FlushSentry()
 }
 
 // для логирования в формате JSON.
@@ -26,8 +26,16 @@ func NewOptions(
 
 	o.dsnSentry = defaultOptions.dsnSentry
 
+	o.sentryCAFile = defaultOptions.sentryCAFile
+
 	o.env = defaultOptions.env
 
+	o.fileSink = defaultOptions.fileSink
+
+	o.sampling = defaultOptions.sampling
+
+	o.redactFields = defaultOptions.redactFields
+
 	o.level = level
 
 	for _, opt := range options {
@@ -57,6 +65,14 @@ func WithDsnSentry(opt string) OptOptionsSetter {
 	}
 }
 
+// sentryCAFile - набор корневых сертификатов для проверки сервера Sentry. Если не задан, используются системные.
+func WithSentryCAFile(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.sentryCAFile = opt
+
+	}
+}
+
 func WithEnv(opt string) OptOptionsSetter {
 	return func(o *Options) {
 		o.env = opt
@@ -64,6 +80,31 @@ func WithEnv(opt string) OptOptionsSetter {
 	}
 }
 
+// fileSink - дополнительная запись логов в файл с ротацией.
+func WithFileSink(opt FileSink) OptOptionsSetter {
+	return func(o *Options) {
+		o.fileSink = opt
+
+	}
+}
+
+// sampling - семплирование многочисленных логов уровня INFO и ниже.
+func WithSampling(opt Sampling) OptOptionsSetter {
+	return func(o *Options) {
+		o.sampling = opt
+
+	}
+}
+
+// redactFields - ключи полей, значения которых маскируются до записи в любое ядро, включая Sentry,
+// в том числе во вложенных объектах.
+func WithRedactFields(opt []string) OptOptionsSetter {
+	return func(o *Options) {
+		o.redactFields = opt
+
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("level", _validate_Options_level(o)))
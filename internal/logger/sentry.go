@@ -1,22 +1,124 @@
 package logger
 
 import (
+	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/getsentry/sentry-go"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
-func NewSentryClient(dsn, env, version string) (*sentry.Client, error) {
-	return sentry.NewClient(sentry.ClientOptions{
+const sentryFlushTimeout = 5 * time.Second
+
+// NewSentryClient создает клиент Sentry. Если задан caFile, сертификат сервера Sentry
+// проверяется по этому набору корневых сертификатов, иначе - по системным.
+func NewSentryClient(dsn, env, version, caFile string) (*sentry.Client, error) {
+	opts := sentry.ClientOptions{
 		Dsn:              dsn,
 		Release:          version,
 		Environment:      env,
 		AttachStacktrace: true,
-		HTTPTransport: &http.Transport{
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: true, //nolint:gosec // non-prod solution
-			},
-		},
-	})
+	}
+
+	if caFile != "" {
+		pool, err := loadCAPool(caFile)
+		if err != nil {
+			return nil, err
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{
+			RootCAs:    pool,
+			MinVersion: tls.VersionTLS12,
+		}
+		opts.HTTPTransport = transport
+	}
+
+	return sentry.NewClient(opts)
+}
+
+func loadCAPool(caFile string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("read CA bundle: %v", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.New("CA bundle contains no PEM certificates")
+	}
+	return pool, nil
+}
+
+// FlushSentry отправляет накопленные события Sentry. Ничего не делает, если Sentry не настроен.
+func FlushSentry() {
+	if SentryClient != nil {
+		SentryClient.Flush(sentryFlushTimeout)
+	}
+}
+
+// AddBreadcrumb добавляет хлебную крошку в хаб Sentry запроса, если он есть в контексте.
+// Крошки попадают в события, отправленные в рамках этого запроса.
+func AddBreadcrumb(ctx context.Context, category, message string, start time.Time, err error) {
+	hub := sentry.GetHubFromContext(ctx)
+	if hub == nil {
+		return
+	}
+
+	b := &sentry.Breadcrumb{
+		Type:      "default",
+		Category:  category,
+		Message:   message,
+		Level:     sentry.LevelInfo,
+		Timestamp: start,
+		Data:      map[string]any{"duration": time.Since(start).String()},
+	}
+	if err != nil {
+		b.Level = sentry.LevelError
+		b.Data["error"] = err.Error()
+	}
+	hub.AddBreadcrumb(b, nil)
+}
+
+const skipSentryKey = "_skip_sentry_"
+
+// SkipSentry помечает запись лога, которую не нужно отправлять в Sentry,
+// например, потому что событие уже отправлено в Sentry напрямую.
+func SkipSentry() zap.Field {
+	return zap.Field{Key: skipSentryKey, Type: zapcore.SkipType}
+}
+
+// skipSentryCore пропускает записи, помеченные SkipSentry.
+type skipSentryCore struct {
+	zapcore.Core
+}
+
+func (c skipSentryCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.Core)
+}
+
+func (c skipSentryCore) With(fields []zapcore.Field) zapcore.Core {
+	return skipSentryCore{Core: c.Core.With(fields)}
+}
+
+func (c skipSentryCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Core.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c skipSentryCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	for _, f := range fields {
+		if f.Key == skipSentryKey && f.Type == zapcore.SkipType {
+			return nil
+		}
+	}
+	return c.Core.Write(ent, fields)
 }
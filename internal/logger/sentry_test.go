@@ -0,0 +1,35 @@
+package logger_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+)
+
+const testSentryDSN = "https://public@sentry.example.com/1"
+
+func TestNewSentryClient_CAFile(t *testing.T) {
+	t.Run("system roots", func(t *testing.T) {
+		client, err := logger.NewSentryClient(testSentryDSN, "dev", "v0.0.1", "")
+		require.NoError(t, err)
+		assert.Nil(t, client.Options().HTTPTransport)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := logger.NewSentryClient(testSentryDSN, "dev", "v0.0.1", filepath.Join(t.TempDir(), "ca.pem"))
+		require.Error(t, err)
+	})
+
+	t.Run("no certificates in file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ca.pem")
+		require.NoError(t, os.WriteFile(path, []byte("not a certificate"), 0o600))
+
+		_, err := logger.NewSentryClient(testSentryDSN, "dev", "v0.0.1", path)
+		require.Error(t, err)
+	})
+}
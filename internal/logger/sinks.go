@@ -0,0 +1,270 @@
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// RedactedValue - значение, которым заменяются маскируемые поля.
+const RedactedValue = "[REDACTED]"
+
+// FileSink - настройки записи логов в файл с ротацией. Пустой Path отключает запись в файл.
+type FileSink struct {
+	Path string
+	// MaxSizeMB - размер файла в мегабайтах, после которого он ротируется.
+	MaxSizeMB int
+	// MaxAgeDays - сколько дней хранить ротированные файлы, 0 - не удалять по возрасту.
+	MaxAgeDays int
+	// MaxBackups - сколько ротированных файлов хранить, 0 - не удалять по количеству.
+	MaxBackups int
+	Compress   bool
+}
+
+func (f FileSink) enabled() bool {
+	return f.Path != ""
+}
+
+func (f FileSink) validate() error {
+	if !f.enabled() {
+		return nil
+	}
+	if f.MaxSizeMB < 0 || f.MaxAgeDays < 0 || f.MaxBackups < 0 {
+		return errors.New("file sink limits must not be negative")
+	}
+	return nil
+}
+
+// Sampling - настройки семплирования логов уровня INFO и ниже: в течение Tick каждое сообщение
+// пишется первые Initial раз, затем - каждое Thereafter-е. Нулевой Initial отключает семплирование.
+// Логи уровня WARN и выше не семплируются.
+type Sampling struct {
+	Tick       time.Duration
+	Initial    int
+	Thereafter int
+}
+
+func (s Sampling) enabled() bool {
+	return s.Initial > 0
+}
+
+func (s Sampling) validate() error {
+	if !s.enabled() {
+		return nil
+	}
+	if s.Tick <= 0 {
+		return errors.New("sampling tick must be positive")
+	}
+	if s.Thereafter < 0 {
+		return errors.New("sampling thereafter must not be negative")
+	}
+	return nil
+}
+
+// fileWriter - текущий файл логов, закрывается при повторной инициализации логгера.
+var fileWriter struct {
+	sync.Mutex
+	w *lumberjack.Logger
+}
+
+func openFileSink(f FileSink) zapcore.WriteSyncer {
+	fileWriter.Lock()
+	defer fileWriter.Unlock()
+
+	if fileWriter.w != nil {
+		_ = fileWriter.w.Close()
+	}
+	fileWriter.w = &lumberjack.Logger{
+		Filename:   f.Path,
+		MaxSize:    f.MaxSizeMB,
+		MaxAge:     f.MaxAgeDays,
+		MaxBackups: f.MaxBackups,
+		Compress:   f.Compress,
+		LocalTime:  true,
+	}
+	return zapcore.AddSync(fileWriter.w)
+}
+
+// sampled семплирует записи уровня ниже WARN, более важные записи проходят без ограничений.
+// newCores создает ядра вывода с заданным фильтром уровня.
+func sampled(s Sampling, newCores func(zapcore.LevelEnabler) []zapcore.Core) zapcore.Core {
+	low := zap.LevelEnablerFunc(func(l zapcore.Level) bool { return l < zapcore.WarnLevel })
+	high := zap.LevelEnablerFunc(func(l zapcore.Level) bool { return l >= zapcore.WarnLevel })
+
+	return zapcore.NewTee(
+		zapcore.NewSamplerWithOptions(zapcore.NewTee(newCores(low)...), s.Tick, s.Initial, s.Thereafter),
+		zapcore.NewTee(newCores(high)...),
+	)
+}
+
+// redactCore маскирует значения полей с заданными ключами до того, как их увидит обернутое ядро.
+// Оборачивает Tee всех приёмников, поэтому поля маскируются один раз на запись, а не в каждом приёмнике.
+type redactCore struct {
+	zapcore.Core
+	keys map[string]struct{}
+}
+
+func newRedactCore(core zapcore.Core, keys []string) zapcore.Core {
+	if len(keys) == 0 {
+		return core
+	}
+
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(k)] = struct{}{}
+	}
+	return &redactCore{Core: core, keys: set}
+}
+
+func (c *redactCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.Core)
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redact(fields)), keys: c.keys}
+}
+
+// Check спрашивает обернутое ядро, какие приёмники примут запись: так сохраняются их собственные
+// фильтры (сэмплирование, уровень Sentry), а маскирование выполняется один раз перед записью в них.
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	inner := c.Core.Check(ent, nil)
+	if inner == nil {
+		return ce
+	}
+	inner.ErrorOutput = zapcore.Lock(os.Stderr)
+	return ce.AddCore(ent, &redactedEntry{Core: c.Core, redact: c.redact, inner: inner})
+}
+
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.redact(fields))
+}
+
+// redactedEntry дописывает замаскированные поля в запись, уже проверенную обернутым ядром.
+type redactedEntry struct {
+	zapcore.Core
+	redact func([]zapcore.Field) []zapcore.Field
+	inner  *zapcore.CheckedEntry
+}
+
+func (e *redactedEntry) Write(_ zapcore.Entry, fields []zapcore.Field) error {
+	e.inner.Write(e.redact(fields)...)
+	return nil
+}
+
+func (c *redactCore) redact(fields []zapcore.Field) []zapcore.Field {
+	var res []zapcore.Field
+	for i, f := range fields {
+		redacted, ok := c.redactField(f)
+		if !ok {
+			continue
+		}
+		// Копируем срез только при первом совпадении, чтобы не менять поля вызывающего.
+		if res == nil {
+			res = make([]zapcore.Field, len(fields))
+			copy(res, fields)
+		}
+		res[i] = redacted
+	}
+	if res == nil {
+		return fields
+	}
+	return res
+}
+
+// redactField маскирует поле с заданным ключом, а в объектах, массивах и значениях zap.Any -
+// вложенные поля с заданными ключами на любой глубине. Возвращает false, если маскировать нечего.
+func (c *redactCore) redactField(f zapcore.Field) (zapcore.Field, bool) {
+	if _, ok := c.keys[strings.ToLower(f.Key)]; ok {
+		return zap.String(f.Key, RedactedValue), true
+	}
+
+	switch f.Type {
+	case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType, zapcore.ReflectType:
+	default:
+		return f, false
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	v, changed := c.redactValue(enc.Fields[f.Key])
+	if !changed {
+		return f, false
+	}
+	// Замаскированное значение пишется как обычный JSON, без кодировщиков zap исходного объекта.
+	return zap.Reflect(f.Key, v), true
+}
+
+// redactValue возвращает копию значения с замаскированными полями или само значение, если маскировать нечего.
+func (c *redactCore) redactValue(v any) (any, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		var res map[string]any
+		for k, item := range v {
+			var redacted any = RedactedValue
+			changed := true
+			if _, ok := c.keys[strings.ToLower(k)]; !ok {
+				redacted, changed = c.redactValue(item)
+			}
+			if !changed {
+				continue
+			}
+			if res == nil {
+				res = maps.Clone(v)
+			}
+			res[k] = redacted
+		}
+		if res == nil {
+			return v, false
+		}
+		return res, true
+
+	case []any:
+		var res []any
+		for i, item := range v {
+			redacted, changed := c.redactValue(item)
+			if !changed {
+				continue
+			}
+			if res == nil {
+				res = slices.Clone(v)
+			}
+			res[i] = redacted
+		}
+		if res == nil {
+			return v, false
+		}
+		return res, true
+	}
+
+	// Произвольные структуры и словари из zap.Any приводим к map[string]any и []any через JSON,
+	// как их и записал бы кодировщик.
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array, reflect.Pointer:
+	default:
+		return v, false
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v, false
+	}
+	var plain any
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return v, false
+	}
+	if _, ok := plain.(map[string]any); !ok {
+		if _, ok := plain.([]any); !ok {
+			return v, false
+		}
+	}
+	return c.redactValue(plain)
+}
@@ -0,0 +1,166 @@
+package logger_test
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+)
+
+func ExampleWithRedactFields() {
+	if err := logger.Init(logger.NewOptions(
+		"info",
+		logger.WithProductionMode(true),
+		logger.WithClock(fakeClock{}),
+		logger.WithRedactFields([]string{"token", "Body"}),
+	)); err != nil {
+		panic(err)
+	}
+
+	zap.L().With(zap.String("token", "secret")).Info("introspect", zap.String("body", "card 4111"), zap.Int("size", 9))
+
+	// Output:
+	// {"level":"INFO","T":"2024-01-01T00:00:01.000Z","msg":"introspect","token":"[REDACTED]","body":"[REDACTED]","size":9}
+}
+
+func ExampleWithRedactFields_nested() {
+	if err := logger.Init(logger.NewOptions(
+		"info",
+		logger.WithProductionMode(true),
+		logger.WithClock(fakeClock{}),
+		logger.WithRedactFields([]string{"token", "body"}),
+	)); err != nil {
+		panic(err)
+	}
+
+	type message struct {
+		ID   int    `json:"id"`
+		Body string `json:"body"`
+	}
+	lg := zap.L()
+	lg.Info("dict", zap.Dict("headers", zap.String("Token", "secret"), zap.String("accept", "json")))
+	lg.Info("map", zap.Any("payload", map[string]any{"chat": map[string]any{"body": "card 4111", "id": 1}}))
+	lg.Info("structs", zap.Any("messages", []message{{ID: 2, Body: "card 4111"}}))
+	lg.Info("array", zap.Strings("tags", []string{"card"}))
+
+	// Output:
+	// {"level":"INFO","T":"2024-01-01T00:00:01.000Z","msg":"dict","headers":{"Token":"[REDACTED]","accept":"json"}}
+	// {"level":"INFO","T":"2024-01-01T00:00:01.000Z","msg":"map","payload":{"chat":{"body":"[REDACTED]","id":1}}}
+	// {"level":"INFO","T":"2024-01-01T00:00:01.000Z","msg":"structs","messages":[{"body":"[REDACTED]","id":2}]}
+	// {"level":"INFO","T":"2024-01-01T00:00:01.000Z","msg":"array","tags":["card"]}
+}
+
+func TestInit_FileSinkConcurrent(t *testing.T) {
+	dir := t.TempDir()
+
+	// Повторные Init закрывают предыдущий файл логов и не должны гоняться между собой.
+	errs := make(chan error, 2)
+	for i := range 2 {
+		go func() {
+			errs <- logger.Init(logger.NewOptions(
+				"info",
+				logger.WithFileSink(logger.FileSink{Path: filepath.Join(dir, fmt.Sprintf("%d.log", i))}),
+			))
+		}()
+	}
+	for range 2 {
+		require.NoError(t, <-errs)
+	}
+	zap.L().Info("written")
+	logger.Sync()
+}
+
+func TestInit_FileSinkWithSampling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chat-service.log")
+
+	err := logger.Init(logger.NewOptions(
+		"info",
+		logger.WithClock(fakeClock{}),
+		logger.WithFileSink(logger.FileSink{Path: path, MaxSizeMB: 1}),
+		logger.WithSampling(logger.Sampling{Tick: time.Hour, Initial: 2, Thereafter: 0}),
+		logger.WithRedactFields([]string{"token"}),
+	))
+	require.NoError(t, err)
+
+	for range 5 {
+		zap.L().Info("noisy", zap.String("token", "secret"))
+		zap.L().Warn("important")
+	}
+	logger.Sync()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var noisy, important int
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		assert.NotContains(t, line, "secret")
+		switch {
+		case strings.Contains(line, `"msg":"noisy"`):
+			noisy++
+		case strings.Contains(line, `"msg":"important"`):
+			important++
+		}
+	}
+	require.NoError(t, sc.Err())
+
+	assert.Equal(t, 2, noisy, "info logs must be sampled")
+	assert.Equal(t, 5, important, "warn logs must not be sampled")
+}
+
+type countingObject struct {
+	calls *atomic.Int32
+}
+
+func (o countingObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	o.calls.Add(1)
+	enc.AddString("id", "1")
+	return nil
+}
+
+func TestInit_RedactOnceForAllSinks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chat-service.log")
+
+	err := logger.Init(logger.NewOptions(
+		"info",
+		logger.WithClock(fakeClock{}),
+		logger.WithFileSink(logger.FileSink{Path: path}),
+		logger.WithRedactFields([]string{"token"}),
+	))
+	require.NoError(t, err)
+
+	var calls atomic.Int32
+	zap.L().Info("object", zap.Object("payload", countingObject{calls: &calls}), zap.String("token", "secret"))
+	logger.Sync()
+
+	// Один проход маскирования и по одной записи в stdout и файл.
+	assert.Equal(t, int32(3), calls.Load())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"payload":{"id":"1"}`)
+	assert.Contains(t, string(data), `"token":"[REDACTED]"`)
+}
+
+func TestInit_InvalidSinks(t *testing.T) {
+	err := logger.Init(logger.NewOptions("info",
+		logger.WithFileSink(logger.FileSink{Path: "x.log", MaxBackups: -1})))
+	require.Error(t, err)
+
+	err = logger.Init(logger.NewOptions("info",
+		logger.WithSampling(logger.Sampling{Initial: 1})))
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// AuditRecorder ставит события в очередь на запись в журнал аудита, см. audit.Recorder.Enqueue.
+type AuditRecorder interface {
+	Enqueue(e audit.Event)
+}
+
+// NewAuthAudit записывает в журнал аудита отказы в аутентификации NewKeycloakTokenAuth.
+// Должен выполняться до middleware аутентификации. Запрос не ждет записи в базу:
+// событие ставится в очередь, которая при потоке отказов может отбрасывать события.
+func NewAuthAudit(recorder AuditRecorder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			authErr, ok := c.Get(authErrCtxKey).(error)
+			if !ok {
+				return err
+			}
+
+			recorder.Enqueue(audit.Event{
+				Action:    audit.ActionAuthFailed,
+				Target:    "route:" + c.Path(),
+				Metadata:  map[string]any{"reason": authErr.Error()},
+				RequestID: RequestID(c),
+				IP:        c.RealIP(),
+			})
+			return err
+		}
+	}
+}
+
+// RequestID возвращает идентификатор запроса, выставленный NewRequestID.
+func RequestID(c echo.Context) types.RequestID {
+	s, _ := c.Get(requestIDCtxKey).(string)
+	requestID, err := types.Parse[types.RequestID](s)
+	if err != nil {
+		return types.RequestIDNil
+	}
+	return requestID
+}
@@ -0,0 +1,84 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	keycloakclient "github.com/FischukSergey/chat-service/internal/clients/keycloak"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	middlewaresmocks "github.com/FischukSergey/chat-service/internal/middlewares/mocks"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestNewAuthAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	introspector := middlewaresmocks.NewMockIntrospector(ctrl)
+	recorder := new(auditRecorder)
+
+	e := echo.New()
+	e.Use(middlewares.NewRequestID(zap.NewNop()))
+	e.GET("/v1/getHistory", func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+		middlewares.NewAuthAudit(recorder),
+		middlewares.NewKeycloakTokenAuth(introspector, requiredResource, requiredRole),
+	)
+
+	t.Run("inactive token", func(t *testing.T) {
+		introspector.EXPECT().IntrospectToken(gomock.Any(), "inactive").
+			Return(&keycloakclient.IntrospectTokenResult{Active: false}, nil)
+
+		requestID := types.NewRequestID()
+		req := httptest.NewRequest(http.MethodGet, "/v1/getHistory", nil)
+		req.Header.Set(echo.HeaderAuthorization, bearerPrefix+"inactive")
+		req.Header.Set(middlewares.HeaderRequestID, requestID.String())
+		req.Header.Set(echo.HeaderXRealIP, "10.0.0.1")
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusUnauthorized, resp.Code)
+
+		events := recorder.recorded()
+		require.Len(t, events, 1)
+		assert.Equal(t, audit.Event{
+			Action:    audit.ActionAuthFailed,
+			Target:    "route:/v1/getHistory",
+			Metadata:  map[string]any{"reason": middlewares.ErrTokenNotActive.Error()},
+			RequestID: requestID,
+			IP:        "10.0.0.1",
+		}, events[0])
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/v1/getHistory", nil))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+
+		events := recorder.recorded()
+		require.Len(t, events, 2)
+		assert.Equal(t, audit.ActionAuthFailed, events[1].Action)
+	})
+}
+
+type auditRecorder struct {
+	mu     sync.Mutex
+	events []audit.Event
+}
+
+func (r *auditRecorder) Enqueue(e audit.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+func (r *auditRecorder) recorded() []audit.Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]audit.Event(nil), r.events...)
+}
@@ -3,6 +3,7 @@ package middlewares
 import (
 	"context"
 	"errors"
+	"net/http"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/labstack/echo/v4"
@@ -14,7 +15,10 @@ import (
 
 //go:generate mockgen -source=$GOFILE -destination=mocks/introspector_mock.gen.go -package=middlewaresmocks Introspector
 
-const tokenCtxKey = "user-token"
+const (
+	tokenCtxKey   = "user-token"
+	authErrCtxKey = "auth-error"
+)
 
 var (
 	ErrNoRequiredResourceRole = errors.New("no required resource role")
@@ -67,8 +71,23 @@ func NewKeycloakTokenAuth(introspector Introspector, resource, role string) echo
 				token = &jwt.Token{Claims: claims}
 			}
 			eCtx.Set(tokenCtxKey, token)
+			setRequestUser(eCtx, claims.UserID())
 			return true, nil
 		},
+		// Ответы совпадают с ответами KeyAuth по умолчанию, причина отказа запоминается для аудита.
+		ErrorHandler: func(err error, eCtx echo.Context) error {
+			eCtx.Set(authErrCtxKey, err)
+
+			var missing *middleware.ErrKeyAuthMissing
+			if errors.As(err, &missing) {
+				return echo.NewHTTPError(http.StatusBadRequest, missing.Error())
+			}
+			return &echo.HTTPError{
+				Code:     http.StatusUnauthorized,
+				Message:  "Unauthorized",
+				Internal: err,
+			}
+		},
 	})
 }
 
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewMetrics создает middleware, собирающее RED-метрики HTTP-запросов:
+// количество запросов и их длительность в разрезе маршрута, метода и статуса ответа.
+// Должен стоять перед Recovery, чтобы учитывать и запросы, завершившиеся паникой.
+func NewMetrics(reg prometheus.Registerer, subsystem string) echo.MiddlewareFunc {
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "chat_service",
+		Subsystem: subsystem,
+		Name:      "http_requests_total",
+		Help:      "Total number of HTTP requests.",
+	}, []string{"route", "method", "status"})
+
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "chat_service",
+		Subsystem: subsystem,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request duration in seconds.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	reg.MustRegister(requests, duration)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			// Маршрут берется из шаблона, а не из фактического пути, чтобы не раздувать кардинальность.
+			route := c.Path()
+			if route == "" {
+				route = "unknown"
+			}
+
+			status := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				status = http.StatusInternalServerError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					status = httpErr.Code
+				}
+			}
+
+			labels := prometheus.Labels{
+				"route":  route,
+				"method": c.Request().Method,
+				"status": strconv.Itoa(status),
+			}
+			requests.With(labels).Inc()
+			duration.With(labels).Observe(time.Since(start).Seconds())
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+)
+
+func TestNewMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+
+	e := echo.New()
+	e.Use(middlewares.NewMetrics(reg, "test"), middlewares.NewRecovery(zap.NewNop()))
+	e.GET("/ok/:id", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/bad", func(_ echo.Context) error { return echo.NewHTTPError(http.StatusBadRequest) })
+	e.GET("/panic", func(_ echo.Context) error { panic("boom") })
+
+	for _, path := range []string{"/ok/1", "/ok/2", "/bad", "/panic"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	expected := `
+# HELP chat_service_test_http_requests_total Total number of HTTP requests.
+# TYPE chat_service_test_http_requests_total counter
+chat_service_test_http_requests_total{method="GET",route="/bad",status="400"} 1
+chat_service_test_http_requests_total{method="GET",route="/ok/:id",status="200"} 2
+chat_service_test_http_requests_total{method="GET",route="/panic",status="500"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "chat_service_test_http_requests_total"))
+
+	n, err := testutil.GatherAndCount(reg, "chat_service_test_http_request_duration_seconds")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+}
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+type PresenceTracker interface {
+	Touch(userID types.UserID)
+}
+
+// NewPresence отмечает активность авторизованного пользователя на каждый запрос.
+// Должен стоять после middleware авторизации.
+func NewPresence(tracker PresenceTracker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if uid, ok := userID(c); ok {
+				tracker.Touch(uid)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package middlewares
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/ratelimit"
+)
+
+// RateLimitStore хранит корзины токенов, см. ratelimit.Store.
+type RateLimitStore interface {
+	Take(ctx context.Context, key string, p ratelimit.Policy) (ok bool, retryAfter time.Duration, err error)
+}
+
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewRateLimit ограничивает частоту запросов операции operation корзиной токенов с политикой,
+// которую возвращает policy. Политика запрашивается на каждый запрос, чтобы ее можно было менять на лету.
+// Корзина своя у каждого пользователя, для запросов без аутентификации - у каждого IP-адреса,
+// поэтому middleware должен выполняться после аутентификации.
+// Если хранилище недоступно, запрос пропускается.
+func NewRateLimit(store RateLimitStore, operation string, policy func() ratelimit.Policy) echo.MiddlewareFunc {
+	return rateLimit(store, operation, policy, func(c echo.Context) string {
+		if uid, ok := userID(c); ok {
+			return operation + ":user:" + uid.String()
+		}
+		return operation + ":ip:" + c.RealIP()
+	})
+}
+
+// NewIPRateLimit ограничивает частоту запросов с одного IP-адреса ко всем операциям, на которые навешен.
+// Должен выполняться до аутентификации, чтобы отсекать поток запросов с неверными токенами
+// до обращения к Keycloak и записи в журнал аудита. Нулевая политика не ограничивает запросы.
+func NewIPRateLimit(store RateLimitStore, policy func() ratelimit.Policy) echo.MiddlewareFunc {
+	limit := rateLimit(store, "ip", policy, func(c echo.Context) string {
+		return "ip:" + c.RealIP()
+	})
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		limited := limit(next)
+		return func(c echo.Context) error {
+			if policy() == (ratelimit.Policy{}) {
+				return next(c)
+			}
+			return limited(c)
+		}
+	}
+}
+
+func rateLimit(
+	store RateLimitStore,
+	name string,
+	policy func() ratelimit.Policy,
+	key func(c echo.Context) string,
+) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+
+			ok, retryAfter, err := store.Take(ctx, key(c), policy())
+			if err != nil {
+				logger.FromContext(ctx).Error("rate limit store", zap.String("operation", name), zap.Error(err))
+				return next(c)
+			}
+			if ok {
+				return next(c)
+			}
+
+			// Нулевое ожидание означает, что политика не пропускает запросы вовсе.
+			if retryAfter > 0 {
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+			}
+			return c.JSON(http.StatusTooManyRequests, errorResponse{Error: errorBody{
+				Code:    http.StatusTooManyRequests,
+				Message: "too many requests",
+			}})
+		}
+	}
+}
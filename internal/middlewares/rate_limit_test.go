@@ -0,0 +1,102 @@
+package middlewares_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	"github.com/FischukSergey/chat-service/internal/ratelimit"
+)
+
+func TestNewRateLimit(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store, err := ratelimit.NewMemoryStore(ratelimit.NewMemoryStoreOptions(
+		ratelimit.WithNow(func() time.Time { return now })))
+	require.NoError(t, err)
+
+	policy := ratelimit.Policy{Rate: 0.5, Burst: 1}
+	e := echo.New()
+	e.GET("/v1/getHistory", func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+		middlewares.NewRateLimit(store, "getHistory", func() ratelimit.Policy { return policy }))
+
+	do := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/getHistory", nil)
+		req.Header.Set(echo.HeaderXRealIP, ip)
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+		return resp
+	}
+
+	require.Equal(t, http.StatusOK, do("10.0.0.1").Code)
+
+	resp := do("10.0.0.1")
+	require.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, "2", resp.Header().Get(echo.HeaderRetryAfter))
+	assert.JSONEq(t, `{"error": {"code": 429, "message": "too many requests"}}`, resp.Body.String())
+
+	// Запросы без аутентификации считаются по IP-адресам.
+	assert.Equal(t, http.StatusOK, do("10.0.0.2").Code)
+
+	// Новая политика применяется без пересоздания middleware.
+	policy = ratelimit.Policy{Rate: 0.5, Burst: 3}
+	assert.Equal(t, http.StatusOK, do("10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, do("10.0.0.1").Code)
+}
+
+func TestNewRateLimit_StoreError(t *testing.T) {
+	e := echo.New()
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+		middlewares.NewRateLimit(failingStore{}, "getHistory", func() ratelimit.Policy { return ratelimit.Policy{Rate: 1, Burst: 1} }))
+
+	resp := httptest.NewRecorder()
+	e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+func TestNewIPRateLimit(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	store, err := ratelimit.NewMemoryStore(ratelimit.NewMemoryStoreOptions(
+		ratelimit.WithNow(func() time.Time { return now })))
+	require.NoError(t, err)
+
+	policy := ratelimit.Policy{Rate: 1, Burst: 2}
+	ipLimit := middlewares.NewIPRateLimit(store, func() ratelimit.Policy { return policy })
+	// Отказ в аутентификации не мешает ограничению: лимит проверяется раньше.
+	unauthorized := func(echo.Context) error { return echo.ErrUnauthorized }
+
+	e := echo.New()
+	e.GET("/v1/getHistory", unauthorized, ipLimit)
+	e.GET("/v1/searchMessages", unauthorized, ipLimit)
+
+	do := func(path, ip string) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set(echo.HeaderXRealIP, ip)
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+		return resp.Code
+	}
+
+	// Корзина IP-адреса общая для всех операций.
+	assert.Equal(t, http.StatusUnauthorized, do("/v1/getHistory", "10.0.0.1"))
+	assert.Equal(t, http.StatusUnauthorized, do("/v1/searchMessages", "10.0.0.1"))
+	assert.Equal(t, http.StatusTooManyRequests, do("/v1/getHistory", "10.0.0.1"))
+	assert.Equal(t, http.StatusUnauthorized, do("/v1/getHistory", "10.0.0.2"))
+
+	// Нулевая политика не ограничивает запросы.
+	policy = ratelimit.Policy{}
+	assert.Equal(t, http.StatusUnauthorized, do("/v1/getHistory", "10.0.0.1"))
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Policy) (bool, time.Duration, error) {
+	return false, 0, errors.New("store is unavailable")
+}
@@ -5,17 +5,33 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/getsentry/sentry-go"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
 )
 
 // NewRecovery создает middleware для восстановления после паники в запросах.
-// Логирует случившуюся ошибку и стек вызовов.
-func NewRecovery(logger *zap.Logger) echo.MiddlewareFunc {
+// Логирует случившуюся ошибку и стек вызовов. Если в контексте запроса есть хаб Sentry (см. NewSentry),
+// паника отправляется в Sentry отдельным событием с полным стеком.
+func NewRecovery(lg *zap.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			defer func() {
 				if r := recover(); r != nil {
+					fields := []zap.Field{
+						zap.Any("error", r),
+						zap.String("url", c.Request().URL.String()),
+						zap.String("method", c.Request().Method),
+					}
+					// Событие о панике уже отправлено через хаб запроса, дублировать его из лога не нужно.
+					if hub := sentry.GetHubFromContext(c.Request().Context()); hub != nil {
+						if eventID := hub.RecoverWithContext(c.Request().Context(), r); eventID != nil {
+							fields = append(fields, zap.String("sentry_event_id", string(*eventID)), logger.SkipSentry())
+						}
+					}
+
 					// Получаем стек вызовов
 					buf := make([]byte, 4096)
 					n := runtime.Stack(buf, false)
@@ -28,24 +44,19 @@ func NewRecovery(logger *zap.Logger) echo.MiddlewareFunc {
 					for i, line := range lines {
 						lineNum := i + 1
 						if _, err := formattedStack.WriteString(fmt.Sprintf("%3d: %s\n", lineNum, line)); err != nil {
-							logger.Error("failed to write stack trace", zap.Error(err))
+							lg.Error("failed to write stack trace", zap.Error(err))
 						}
 					}
 
 					// Логируем ошибку и стек вызовов
-					logger.Error("panic recovered",
-						zap.Any("error", r),
-						zap.String("stack_trace", formattedStack.String()),
-						zap.String("url", c.Request().URL.String()),
-						zap.String("method", c.Request().Method),
-					)
+					lg.Error("panic recovered", append(fields, zap.String("stack_trace", formattedStack.String()))...)
 
 					// Возвращаем 500 Internal Server Error
 					err := c.JSON(500, map[string]any{
 						"error": "Internal Server Error",
 					})
 					if err != nil {
-						logger.Error("failed to send error response", zap.Error(err))
+						lg.Error("failed to send error response", zap.Error(err))
 					}
 				}
 			}()
@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const (
+	HeaderRequestID = "X-Request-ID"
+	requestIDCtxKey = "request_id"
+)
+
+// NewRequestID берет идентификатор запроса из заголовка X-Request-ID или генерирует новый,
+// возвращает его в ответе и кладет в контекст запроса дочерний логгер с полем request_id.
+func NewRequestID(lg *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			header := req.Header.Get(HeaderRequestID)
+			requestID, err := types.Parse[types.RequestID](header)
+			if err != nil || requestID.IsZero() {
+				requestID = types.NewRequestID()
+			}
+			// Заголовок обязателен по OpenAPI-спецификации: если его нет, подставляем сгенерированный.
+			// Невалидный заголовок оставляем как есть, его отклонит валидатор.
+			if header == "" {
+				req.Header.Set(HeaderRequestID, requestID.String())
+			}
+
+			c.Set(requestIDCtxKey, requestID.String())
+			c.Response().Header().Set(HeaderRequestID, requestID.String())
+
+			ctx := logger.NewContext(req.Context(), lg, zap.String("request_id", requestID.String()))
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestNewRequestID(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	var headerInHandler string
+	e := echo.New()
+	e.Use(middlewares.NewRequestID(zap.New(core)))
+	e.GET("/", func(c echo.Context) error {
+		headerInHandler = c.Request().Header.Get(middlewares.HeaderRequestID)
+		logger.FromContext(c.Request().Context()).Info("handled")
+		return c.NoContent(http.StatusOK)
+	})
+
+	t.Run("request id from header", func(t *testing.T) {
+		logs.TakeAll()
+		requestID := types.NewRequestID()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(middlewares.HeaderRequestID, requestID.String())
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+
+		assert.Equal(t, requestID.String(), resp.Header().Get(middlewares.HeaderRequestID))
+		assert.Equal(t, requestID.String(), headerInHandler)
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		assert.Equal(t, requestID.String(), entries[0].ContextMap()["request_id"])
+	})
+
+	t.Run("generated request id", func(t *testing.T) {
+		logs.TakeAll()
+
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		requestID, err := types.Parse[types.RequestID](resp.Header().Get(middlewares.HeaderRequestID))
+		require.NoError(t, err)
+		assert.False(t, requestID.IsZero())
+		assert.Equal(t, requestID.String(), headerInHandler)
+
+		entries := logs.TakeAll()
+		require.Len(t, entries, 1)
+		assert.Equal(t, requestID.String(), entries[0].ContextMap()["request_id"])
+	})
+
+	t.Run("invalid header is kept for validator", func(t *testing.T) {
+		logs.TakeAll()
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(middlewares.HeaderRequestID, "not-a-uuid")
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+
+		assert.Equal(t, "not-a-uuid", headerInHandler)
+		_, err := types.Parse[types.RequestID](resp.Header().Get(middlewares.HeaderRequestID))
+		require.NoError(t, err)
+	})
+}
@@ -5,12 +5,14 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 // NewRequestLogger создает middleware для логирования HTTP запросов.
 // Игнорирует OPTIONS запросы.
 // Логирует: latency, remote_ip, host, method, path, request_id, user_agent и status.
+// Если запрос трассируется, то логирует trace_id и span_id.
 // Если в контексте есть user_id, то логирует и его.
 // Если последующий в цепочке хендлер вернул ошибку, то добавляет в лог и её.
 func NewRequestLogger(logger *zap.Logger) echo.MiddlewareFunc {
@@ -37,7 +39,7 @@ func NewRequestLogger(logger *zap.Logger) echo.MiddlewareFunc {
 
 			// Получаем request_id из контекста
 			requestID := ""
-			if reqIDVal := c.Get("request_id"); reqIDVal != nil {
+			if reqIDVal := c.Get(requestIDCtxKey); reqIDVal != nil {
 				if reqIDStr, ok := reqIDVal.(string); ok {
 					requestID = reqIDStr
 				}
@@ -55,6 +57,14 @@ func NewRequestLogger(logger *zap.Logger) echo.MiddlewareFunc {
 				zap.Int("status", res.Status),
 			}
 
+			// Добавляем идентификаторы трассировки, если запрос трассируется
+			if sc := trace.SpanContextFromContext(req.Context()); sc.IsValid() {
+				fields = append(fields,
+					zap.String("trace_id", sc.TraceID().String()),
+					zap.String("span_id", sc.SpanID().String()),
+				)
+			}
+
 			// Добавляем user_id из контекста, если есть
 			userID, ok := userID(c)
 			if ok {
@@ -0,0 +1,94 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/TheZeroSlave/zapsentry"
+	"github.com/getsentry/sentry-go"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const filteredHeaderValue = "[Filtered]"
+
+// sensitiveHeaders - заголовки, значения которых не отправляются в Sentry.
+var sensitiveHeaders = map[string]struct{}{
+	"Authorization":       {},
+	"Proxy-Authorization": {},
+	"Cookie":              {},
+	"Set-Cookie":          {},
+	"X-Api-Key":           {},
+	"X-Forwarded-For":     {},
+	"X-Real-Ip":           {},
+}
+
+// NewSentry создает для каждого запроса отдельный хаб Sentry с тегами request_id и route
+// и очищенными от секретов заголовками. Хаб кладется в контекст запроса, а логгер запроса
+// привязывается к его scope, чтобы WARN+ логи запроса попадали в Sentry с этим контекстом.
+// Должен стоять после NewRequestID. Если client не задан, ничего не делает.
+func NewSentry(client *sentry.Client) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		if client == nil {
+			return next
+		}
+
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			hub := sentry.NewHub(client, sentry.NewScope())
+			scope := hub.Scope()
+			scope.SetTag("route", c.Path())
+			if requestID, ok := c.Get(requestIDCtxKey).(string); ok {
+				scope.SetTag("request_id", requestID)
+			}
+			sentryReq := sanitizedRequest(req)
+			scope.AddEventProcessor(func(event *sentry.Event, _ *sentry.EventHint) *sentry.Event {
+				if event.Request == nil {
+					event.Request = sentryReq
+				}
+				return event
+			})
+
+			ctx := sentry.SetHubOnContext(req.Context(), hub)
+			ctx = logger.NewContext(ctx, logger.FromContext(ctx), zapsentry.NewScopeFromScope(scope))
+			c.SetRequest(req.WithContext(ctx))
+
+			return next(c)
+		}
+	}
+}
+
+// setRequestUser добавляет пользователя к логгеру запроса и к хабу Sentry запроса.
+func setRequestUser(c echo.Context, userID types.UserID) {
+	req := c.Request()
+	if hub := sentry.GetHubFromContext(req.Context()); hub != nil {
+		hub.Scope().SetUser(sentry.User{ID: userID.String()})
+	}
+
+	ctx := logger.NewContext(req.Context(), logger.FromContext(req.Context()), zap.String("user_id", userID.String()))
+	c.SetRequest(req.WithContext(ctx))
+}
+
+// sanitizedRequest описывает запрос для Sentry без тела и значений чувствительных заголовков.
+func sanitizedRequest(r *http.Request) *sentry.Request {
+	headers := make(map[string]string, len(r.Header)+1)
+	for k, v := range r.Header {
+		if _, ok := sensitiveHeaders[http.CanonicalHeaderKey(k)]; ok {
+			headers[k] = filteredHeaderValue
+			continue
+		}
+		headers[k] = strings.Join(v, ",")
+	}
+	headers["Host"] = r.Host
+
+	return &sentry.Request{
+		URL:         r.URL.Path,
+		Method:      r.Method,
+		QueryString: r.URL.RawQuery,
+		Headers:     headers,
+	}
+}
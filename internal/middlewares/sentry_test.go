@@ -0,0 +1,113 @@
+package middlewares_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestNewSentry(t *testing.T) {
+	transport := new(eventsRecorder)
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:              "https://public@sentry.example.com/1",
+		Transport:        transport,
+		AttachStacktrace: true,
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(
+		middlewares.NewRecovery(zap.NewNop()),
+		middlewares.NewRequestID(zap.NewNop()),
+		middlewares.NewSentry(client),
+	)
+	e.GET("/panic/:id", func(_ echo.Context) error { panic("boom") })
+	e.GET("/error", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		logger.AddBreadcrumb(ctx, "keycloak", "introspect token", time.Now(), errors.New("timeout"))
+		sentry.GetHubFromContext(ctx).CaptureMessage("handler failed")
+		return c.NoContent(http.StatusOK)
+	})
+
+	t.Run("panic is reported with request context", func(t *testing.T) {
+		requestID := types.NewRequestID()
+		req := httptest.NewRequest(http.MethodGet, "/panic/1", nil)
+		req.Header.Set(middlewares.HeaderRequestID, requestID.String())
+		req.Header.Set(echo.HeaderAuthorization, "Bearer secret-token")
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, req)
+		require.Equal(t, http.StatusInternalServerError, resp.Code)
+
+		event := transport.last(t)
+		assert.Equal(t, "boom", event.Message)
+		assert.Equal(t, requestID.String(), event.Tags["request_id"])
+		assert.Equal(t, "/panic/:id", event.Tags["route"])
+		require.NotNil(t, event.Request)
+		assert.Equal(t, "[Filtered]", event.Request.Headers[echo.HeaderAuthorization])
+		require.NotEmpty(t, event.Threads)
+		assert.NotEmpty(t, event.Threads[0].Stacktrace.Frames)
+	})
+
+	t.Run("breadcrumbs are attached to request events", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/error", nil))
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		event := transport.last(t)
+		assert.Equal(t, "handler failed", event.Message)
+		require.Len(t, event.Breadcrumbs, 1)
+		assert.Equal(t, "keycloak", event.Breadcrumbs[0].Category)
+		assert.Equal(t, sentry.LevelError, event.Breadcrumbs[0].Level)
+		assert.Equal(t, "timeout", event.Breadcrumbs[0].Data["error"])
+	})
+}
+
+func TestNewSentry_NoClient(t *testing.T) {
+	e := echo.New()
+	e.Use(middlewares.NewSentry(nil))
+	e.GET("/", func(c echo.Context) error {
+		assert.Nil(t, sentry.GetHubFromContext(c.Request().Context()))
+		return c.NoContent(http.StatusOK)
+	})
+
+	resp := httptest.NewRecorder()
+	e.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, resp.Code)
+}
+
+type eventsRecorder struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (r *eventsRecorder) Flush(time.Duration) bool { return true }
+
+func (r *eventsRecorder) Configure(sentry.ClientOptions) {}
+
+func (r *eventsRecorder) SendEvent(event *sentry.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *eventsRecorder) last(t *testing.T) *sentry.Event {
+	t.Helper()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	require.NotEmpty(t, r.events)
+	return r.events[len(r.events)-1]
+}
@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+const headerWebSocketProtocol = "Sec-WebSocket-Protocol"
+
+// NewWebSocketToken переносит токен из заголовка Sec-WebSocket-Protocol "<protocol>, <token>"
+// в Authorization: браузер не может задать Authorization при открытии WebSocket.
+// Должен стоять перед middleware авторизации. Заданный заголовок Authorization не меняется.
+func NewWebSocketToken(protocol string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if req.Header.Get(echo.HeaderAuthorization) != "" {
+				return next(c)
+			}
+
+			var values []string
+			for _, h := range req.Header.Values(headerWebSocketProtocol) {
+				for _, v := range strings.Split(h, ",") {
+					values = append(values, strings.TrimSpace(v))
+				}
+			}
+			if len(values) == 2 && values[0] == protocol && values[1] != "" {
+				req.Header.Set(echo.HeaderAuthorization, "Bearer "+values[1])
+			}
+			return next(c)
+		}
+	}
+}
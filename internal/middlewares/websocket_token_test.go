@@ -0,0 +1,75 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+)
+
+func TestNewWebSocketToken(t *testing.T) {
+	var authorization string
+	e := echo.New()
+	e.Use(middlewares.NewWebSocketToken("chat-service-protocol"))
+	e.GET("/ws", func(c echo.Context) error {
+		authorization = c.Request().Header.Get(echo.HeaderAuthorization)
+		return c.NoContent(http.StatusOK)
+	})
+
+	cases := []struct {
+		name          string
+		authorization string
+		protocols     []string
+		expected      string
+	}{
+		{
+			name:      "token from subprotocol",
+			protocols: []string{"chat-service-protocol, token"},
+			expected:  "Bearer token",
+		},
+		{
+			name:      "token from repeated header",
+			protocols: []string{"chat-service-protocol", "token"},
+			expected:  "Bearer token",
+		},
+		{
+			name:          "authorization header wins",
+			authorization: "Bearer header-token",
+			protocols:     []string{"chat-service-protocol, token"},
+			expected:      "Bearer header-token",
+		},
+		{
+			name:      "unknown protocol",
+			protocols: []string{"other, token"},
+		},
+		{
+			name:      "no token",
+			protocols: []string{"chat-service-protocol"},
+		},
+		{
+			name:      "extra values",
+			protocols: []string{"chat-service-protocol, token, other"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			authorization = ""
+
+			req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			for _, p := range tt.protocols {
+				req.Header.Add("Sec-WebSocket-Protocol", p)
+			}
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.expected, authorization)
+		})
+	}
+}
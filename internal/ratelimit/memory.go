@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+//go:generate options-gen -out-filename=memory_options.gen.go -from-struct=MemoryStoreOptions -defaults-from=var
+type MemoryStoreOptions struct {
+	// idleTTL - через сколько после последнего запроса корзина удаляется.
+	// Должен быть не меньше времени наполнения корзины, иначе лимит сбрасывается раньше срока.
+	idleTTL         time.Duration `validate:"min=1s"`
+	cleanupInterval time.Duration `validate:"min=1s"`
+	now             func() time.Time
+}
+
+var defaultMemoryStoreOptions = MemoryStoreOptions{
+	idleTTL:         10 * time.Minute,
+	cleanupInterval: time.Minute,
+	now:             time.Now,
+}
+
+// MemoryStore хранит корзины токенов в памяти процесса: лимиты считаются отдельно для каждой реплики.
+type MemoryStore struct {
+	MemoryStoreOptions
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	policy   Policy
+	lastSeen time.Time
+}
+
+func NewMemoryStore(opts MemoryStoreOptions) (*MemoryStore, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &MemoryStore{
+		MemoryStoreOptions: opts,
+		buckets:            make(map[string]*bucket),
+	}, nil
+}
+
+func (s *MemoryStore) Take(_ context.Context, key string, p Policy) (bool, time.Duration, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	// Если политика изменилась, корзина создается заново.
+	if !ok || b.policy != p {
+		b = &bucket{limiter: rate.NewLimiter(p.Rate, p.Burst), policy: p}
+		s.buckets[key] = b
+	}
+	b.lastSeen = now
+
+	r := b.limiter.ReserveN(now, 1)
+	if !r.OK() {
+		return false, 0, nil
+	}
+	if delay := r.DelayFrom(now); delay > 0 {
+		r.CancelAt(now)
+		return false, delay, nil
+	}
+	return true, 0, nil
+}
+
+// Run периодически удаляет неиспользуемые корзины, пока не завершится контекст.
+func (s *MemoryStore) Run(ctx context.Context) error {
+	t := time.NewTicker(s.cleanupInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			s.cleanup()
+		}
+	}
+}
+
+// Len возвращает количество корзин в памяти.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets)
+}
+
+func (s *MemoryStore) cleanup() {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, b := range s.buckets {
+		if now.Sub(b.lastSeen) > s.idleTTL {
+			delete(s.buckets, key)
+		}
+	}
+}
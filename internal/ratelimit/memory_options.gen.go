@@ -0,0 +1,74 @@
+// Code generated by options-gen. DO NOT EDIT.
+package ratelimit
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptMemoryStoreOptionsSetter func(o *MemoryStoreOptions)
+
+func NewMemoryStoreOptions(
+	options ...OptMemoryStoreOptionsSetter,
+) MemoryStoreOptions {
+	o := MemoryStoreOptions{}
+
+	// Setting defaults from variable
+	o.idleTTL = defaultMemoryStoreOptions.idleTTL
+
+	o.cleanupInterval = defaultMemoryStoreOptions.cleanupInterval
+
+	o.now = defaultMemoryStoreOptions.now
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// idleTTL - через сколько после последнего запроса корзина удаляется.
+// Должен быть не меньше времени наполнения корзины, иначе лимит сбрасывается раньше срока.
+func WithIdleTTL(opt time.Duration) OptMemoryStoreOptionsSetter {
+	return func(o *MemoryStoreOptions) {
+		o.idleTTL = opt
+
+	}
+}
+
+func WithCleanupInterval(opt time.Duration) OptMemoryStoreOptionsSetter {
+	return func(o *MemoryStoreOptions) {
+		o.cleanupInterval = opt
+
+	}
+}
+
+func WithNow(opt func() time.Time) OptMemoryStoreOptionsSetter {
+	return func(o *MemoryStoreOptions) {
+		o.now = opt
+
+	}
+}
+
+func (o *MemoryStoreOptions) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("idleTTL", _validate_MemoryStoreOptions_idleTTL(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("cleanupInterval", _validate_MemoryStoreOptions_cleanupInterval(o)))
+	return errs.AsError()
+}
+
+func _validate_MemoryStoreOptions_idleTTL(o *MemoryStoreOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.idleTTL, "min=1s"); err != nil {
+		return fmt461e464ebed9.Errorf("field `idleTTL` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_MemoryStoreOptions_cleanupInterval(o *MemoryStoreOptions) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.cleanupInterval, "min=1s"); err != nil {
+		return fmt461e464ebed9.Errorf("field `cleanupInterval` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package ratelimit_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/ratelimit"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store, err := ratelimit.NewMemoryStore(ratelimit.NewMemoryStoreOptions(
+		ratelimit.WithNow(func() time.Time { return now })))
+	require.NoError(t, err)
+
+	policy := ratelimit.Policy{Rate: 2, Burst: 3}
+
+	t.Run("burst then throttle", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			ok, _, err := store.Take(ctx, "a", policy)
+			require.NoError(t, err)
+			assert.True(t, ok, "request %d", i)
+		}
+
+		ok, retryAfter, err := store.Take(ctx, "a", policy)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Equal(t, 500*time.Millisecond, retryAfter)
+	})
+
+	t.Run("keys are independent", func(t *testing.T) {
+		ok, _, err := store.Take(ctx, "b", policy)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("tokens are refilled", func(t *testing.T) {
+		now = now.Add(500 * time.Millisecond)
+
+		ok, _, err := store.Take(ctx, "a", policy)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, _, err = store.Take(ctx, "a", policy)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("policy change resets bucket", func(t *testing.T) {
+		ok, _, err := store.Take(ctx, "a", ratelimit.Policy{Rate: 2, Burst: 10})
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("zero burst denies all", func(t *testing.T) {
+		ok, retryAfter, err := store.Take(ctx, "c", ratelimit.Policy{Rate: 1, Burst: 0})
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Zero(t, retryAfter)
+	})
+}
+
+func TestMemoryStore_Run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var now atomic.Int64
+	now.Store(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).UnixNano())
+
+	store, err := ratelimit.NewMemoryStore(ratelimit.NewMemoryStoreOptions(
+		ratelimit.WithIdleTTL(time.Minute),
+		ratelimit.WithCleanupInterval(time.Second),
+		ratelimit.WithNow(func() time.Time { return time.Unix(0, now.Load()) }),
+	))
+	require.NoError(t, err)
+
+	_, _, err = store.Take(ctx, "a", ratelimit.Policy{Rate: 1, Burst: 1})
+	require.NoError(t, err)
+	require.Equal(t, 1, store.Len())
+
+	done := make(chan error)
+	go func() { done <- store.Run(ctx) }()
+
+	now.Add(int64(2 * time.Minute))
+	assert.Eventually(t, func() bool { return store.Len() == 0 }, 3*time.Second, 100*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Policy - политика корзины токенов: Rate токенов в секунду, не больше Burst накопленных.
+type Policy struct {
+	Rate  rate.Limit
+	Burst int
+}
+
+// Policies - политики операций. Операции без собственной политики ограничиваются Default.
+type Policies struct {
+	// PerIP - общая для всех операций политика IP-адреса. Нулевая политика не ограничивает запросы.
+	PerIP      Policy
+	Default    Policy
+	Operations map[string]Policy
+}
+
+// For возвращает политику операции.
+func (p Policies) For(operation string) Policy {
+	if policy, ok := p.Operations[operation]; ok {
+		return policy
+	}
+	return p.Default
+}
+
+// Store хранит корзины токенов. Реализация поверх общего хранилища (Redis, Postgres)
+// позволяет разделять лимиты между репликами сервиса.
+type Store interface {
+	// Take забирает токен из корзины key с политикой p. Если токенов нет,
+	// возвращает false и время, через которое токен появится.
+	Take(ctx context.Context, key string, p Policy) (ok bool, retryAfter time.Duration, err error)
+}
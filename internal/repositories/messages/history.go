@@ -0,0 +1,144 @@
+package messagesrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/attachment"
+	"github.com/FischukSergey/chat-service/internal/store/message"
+	"github.com/FischukSergey/chat-service/internal/store/predicate"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+type HistoryParams struct {
+	ChatID     types.ChatID
+	Visibility Visibility
+	PageSize   int
+	Cursor     string
+}
+
+type Message struct {
+	ID            types.MessageID
+	ChatID        types.ChatID
+	AuthorID      types.UserID
+	Body          string
+	IsRedacted    bool
+	AttachmentIDs []types.AttachmentID
+	CreatedAt     time.Time
+}
+
+type HistoryPage struct {
+	Messages []Message
+	// NextCursor пустой, если следующей страницы нет.
+	NextCursor string
+}
+
+// History возвращает сообщения чата от новых к старым. В отличие от поиска история читается через ent,
+// поэтому доступна и при включенном шифровании: сообщения расшифровывает перехватчик запросов.
+func (r *Repo) History(ctx context.Context, params HistoryParams) (HistoryPage, error) {
+	pageSize := params.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	q := r.db.Message.Query().Where(message.ChatID(params.ChatID))
+	switch params.Visibility {
+	case VisibleForClient:
+		q.Where(message.IsVisibleForClient(true))
+	case VisibleForManager:
+		q.Where(message.IsVisibleForManager(true))
+	default:
+		return HistoryPage{}, fmt.Errorf("unknown visibility %d", params.Visibility)
+	}
+	if params.Cursor != "" {
+		after, err := decodeCursor(params.Cursor)
+		if err != nil {
+			return HistoryPage{}, err
+		}
+		q.Where(createdBefore(after))
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница.
+	messages, err := q.
+		WithAttachments(func(q *store.AttachmentQuery) {
+			q.Select(attachment.FieldID, attachment.FieldMessageID).Order(attachment.ByCreatedAt(), attachment.ByID())
+		}).
+		Order(newestFirst).
+		Limit(pageSize + 1).
+		All(ctx)
+	if err != nil {
+		return HistoryPage{}, fmt.Errorf("query messages: %v", err)
+	}
+
+	var page HistoryPage
+	if len(messages) > pageSize {
+		messages = messages[:pageSize]
+		last := messages[len(messages)-1]
+		page.NextCursor = encodeCursor(cursor{CreatedAt: last.CreatedAt.UTC(), ID: last.ID})
+	}
+
+	page.Messages = make([]Message, 0, len(messages))
+	for _, m := range messages {
+		page.Messages = append(page.Messages, NewMessage(m))
+	}
+	return page, nil
+}
+
+// NewMessage переводит сообщение из хранилища в сообщение истории.
+// Вложения берутся из загруженных ребер сообщения.
+func NewMessage(m *store.Message) Message {
+	msg := Message{
+		ID:         m.ID,
+		ChatID:     m.ChatID,
+		AuthorID:   m.AuthorID,
+		Body:       m.Body,
+		IsRedacted: m.IsRedacted,
+		CreatedAt:  m.CreatedAt,
+	}
+	for _, a := range m.Edges.Attachments {
+		msg.AttachmentIDs = append(msg.AttachmentIDs, a.ID)
+	}
+	return msg
+}
+
+// newestFirst упорядочивает сообщения так же, как поиск: по времени создания и идентификатору.
+func newestFirst(s *sql.Selector) {
+	s.OrderExpr(sql.Expr(createdAtColumn(s) + " DESC, " + s.C(message.FieldID) + " DESC"))
+}
+
+// createdBefore отбирает сообщения, следующие в порядке newestFirst за сообщением курсора.
+func createdBefore(c cursor) predicate.Message {
+	return func(s *sql.Selector) {
+		createdAt, id := createdAtColumn(s), s.C(message.FieldID)
+		s.Where(sql.P(func(b *sql.Builder) {
+			arg := func() {
+				if b.Dialect() == dialect.SQLite {
+					sec, frac := sqliteTimeArgs(c.CreatedAt)
+					b.WriteString("(").Arg(sec).WriteString(" + CAST(").Arg(frac).WriteString(" AS REAL))")
+					return
+				}
+				b.Arg(c.CreatedAt.UTC())
+			}
+
+			b.WriteString("(" + createdAt + " < ")
+			arg()
+			b.WriteString(" OR (" + createdAt + " = ")
+			arg()
+			b.WriteString(" AND " + id + " < ").Arg(c.ID).WriteString("))")
+		}))
+	}
+}
+
+// createdAtColumn возвращает выражение времени создания сообщения, в SQLite - через sqliteTime.
+func createdAtColumn(s *sql.Selector) string {
+	if s.Dialect() == dialect.SQLite {
+		return sqliteTime(s.C(message.FieldCreatedAt))
+	}
+	return s.C(message.FieldCreatedAt)
+}
@@ -0,0 +1,83 @@
+package messagesrepo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	messagesrepo "github.com/FischukSergey/chat-service/internal/repositories/messages"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestRepo_History(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	// Время сообщений записывается в разных часовых поясах, порядок от этого не зависит.
+	zones := []*time.Location{time.FixedZone("MSK", 3*60*60), time.UTC, time.FixedZone("EST", -5*60*60)}
+	now := time.Now()
+	var expected []types.MessageID
+	for i := range 5 {
+		m := f.addMessage(t, f.chatA, f.clientA, "сообщение", now.Add(time.Duration(i)*time.Minute).In(zones[i%len(zones)]))
+		expected = append([]types.MessageID{m.ID}, expected...) // новые первыми
+	}
+	f.addMessage(t, f.chatB, f.clientB, "чужой чат", now)
+
+	hidden := f.addMessage(t, f.chatA, f.clientA, "служебное", now.Add(time.Hour))
+	f.client.Message.UpdateOne(hidden).SetIsVisibleForClient(false).ExecX(ctx)
+
+	t.Run("pagination", func(t *testing.T) {
+		var (
+			got    []types.MessageID
+			cursor string
+			pages  int
+		)
+		for {
+			page, err := f.repo.History(ctx, messagesrepo.HistoryParams{
+				ChatID:     f.chatA,
+				Visibility: messagesrepo.VisibleForClient,
+				PageSize:   2,
+				Cursor:     cursor,
+			})
+			require.NoError(t, err)
+			pages++
+
+			for _, m := range page.Messages {
+				assert.Equal(t, f.chatA, m.ChatID)
+				assert.Equal(t, "сообщение", m.Body)
+				got = append(got, m.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+			require.Less(t, pages, 10, "pagination does not end")
+		}
+
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, expected, got)
+	})
+
+	t.Run("manager sees hidden messages", func(t *testing.T) {
+		page, err := f.repo.History(ctx, messagesrepo.HistoryParams{
+			ChatID:     f.chatA,
+			Visibility: messagesrepo.VisibleForManager,
+			PageSize:   1,
+		})
+		require.NoError(t, err)
+		require.Len(t, page.Messages, 1)
+		assert.Equal(t, hidden.ID, page.Messages[0].ID)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := f.repo.History(ctx, messagesrepo.HistoryParams{
+			ChatID:     f.chatA,
+			Visibility: messagesrepo.VisibleForClient,
+			Cursor:     "invalid",
+		})
+		require.ErrorIs(t, err, messagesrepo.ErrInvalidCursor)
+	})
+}
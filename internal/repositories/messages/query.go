@@ -0,0 +1,128 @@
+package messagesrepo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// Маркеры начала и конца совпадения в сниппете. Заменяются на <mark> после экранирования текста.
+const (
+	markStart = "\x02"
+	markStop  = "\x03"
+)
+
+// headlineOptions - параметры ts_headline в PostgreSQL.
+const headlineOptions = "StartSel=" + markStart + ", StopSel=" + markStop +
+	", MaxWords=20, MinWords=8, MaxFragments=2, FragmentDelimiter=\" … \""
+
+// queryBuilder собирает запрос поиска сообщений с учетом диалекта.
+type queryBuilder struct {
+	dialect     string
+	from        string
+	snippet     string
+	conds       []string
+	args        []any
+	snippetInGo bool
+}
+
+func newQueryBuilder(d string) *queryBuilder {
+	return &queryBuilder{
+		dialect: d,
+		from:    "messages m JOIN chats c ON c.id = m.chat_id",
+	}
+}
+
+// arg добавляет аргумент запроса и возвращает его плейсхолдер.
+func (q *queryBuilder) arg(v any) string {
+	q.args = append(q.args, v)
+	if q.dialect == dialect.Postgres {
+		return "$" + strconv.Itoa(len(q.args))
+	}
+	return "?"
+}
+
+// createdAt возвращает выражение времени создания сообщения для сортировки и сравнения с курсором.
+func (q *queryBuilder) createdAt() string {
+	if q.dialect == dialect.SQLite {
+		return sqliteTime("m.created_at")
+	}
+	return "m.created_at"
+}
+
+// createdAtArg добавляет аргумент-время в UTC и возвращает выражение, сравнимое с createdAt.
+func (q *queryBuilder) createdAtArg(t time.Time) string {
+	if q.dialect == dialect.SQLite {
+		sec, frac := sqliteTimeArgs(t)
+		return "(" + q.arg(sec) + " + CAST(" + q.arg(frac) + " AS REAL))"
+	}
+	return q.arg(t.UTC())
+}
+
+// sqliteTime возвращает момент времени из колонки SQLite в секундах Unix с долями секунды.
+// SQLite хранит время строкой в часовом поясе записи, поэтому строки нельзя сравнивать напрямую,
+// а julianday учитывает только миллисекунды. Драйвер пишет время в формате
+// "2006-01-02 15:04:05.999999999-07:00", поэтому доли секунды берутся из строки после точки.
+func sqliteTime(column string) string {
+	return "(CAST(strftime('%s', " + column + ") AS INTEGER) + CAST('0.' || substr(" + column + ", 21, 9) AS REAL))"
+}
+
+// sqliteTimeArgs возвращает секунды и доли секунды момента t для сравнения с sqliteTime.
+// Доли передаются строкой, чтобы SQLite получил из них то же число, что и из колонки.
+func sqliteTimeArgs(t time.Time) (int64, string) {
+	return t.Unix(), fmt.Sprintf("0.%09d", t.Nanosecond())
+}
+
+func (q *queryBuilder) where(cond string) {
+	q.conds = append(q.conds, cond)
+}
+
+func (q *queryBuilder) postgresMatch(query string) {
+	tsQuery := "websearch_to_tsquery('" + tsConfig + "', " + q.arg(query) + ")"
+	q.where("to_tsvector('" + tsConfig + "', m.body) @@ " + tsQuery)
+	q.snippet = "ts_headline('" + tsConfig + "', m.body, " + tsQuery + ", " + q.arg(headlineOptions) + ")"
+}
+
+func (q *queryBuilder) fts5Match(terms []string) {
+	q.from = "messages_fts JOIN messages m ON m.rowid = messages_fts.rowid JOIN chats c ON c.id = m.chat_id"
+	q.where("messages_fts MATCH " + q.arg(fts5Query(terms)))
+	q.snippet = "snippet(messages_fts, 0, char(2), char(3), '…', 16)"
+}
+
+func (q *queryBuilder) likeMatch(terms []string) {
+	for _, t := range terms {
+		q.where("m.body LIKE " + q.arg("%"+escapeLike(t)+"%") + ` ESCAPE '\'`)
+	}
+	q.snippet = "m.body"
+	q.snippetInGo = true
+}
+
+func (q *queryBuilder) build(limit int) string {
+	var b strings.Builder
+	b.WriteString("SELECT m.id, m.chat_id, m.author_id, m.created_at, ")
+	b.WriteString(q.snippet)
+	b.WriteString(" FROM ")
+	b.WriteString(q.from)
+	b.WriteString(" WHERE ")
+	b.WriteString(strings.Join(q.conds, " AND "))
+	b.WriteString(" ORDER BY " + q.createdAt() + " DESC, m.id DESC LIMIT ")
+	b.WriteString(q.arg(limit))
+	return b.String()
+}
+
+// fts5Query превращает слова запроса в строки FTS5, чтобы спецсимволы пользователя
+// не интерпретировались как синтаксис запроса. Слова объединяются через AND.
+func fts5Query(terms []string) string {
+	quoted := make([]string, 0, len(terms))
+	for _, t := range terms {
+		quoted = append(quoted, `"`+strings.ReplaceAll(t, `"`, `""`)+`"`)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,62 @@
+package messagesrepo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+)
+
+//go:generate options-gen -out-filename=repo_options.gen.go -from-struct=Options
+type Options struct {
+	db      *store.Client `option:"mandatory" validate:"required"`
+	dialect string        `option:"mandatory" validate:"oneof=postgres sqlite3"`
+}
+
+type Repo struct {
+	Options
+	// fts5 - доступен ли в SQLite модуль FTS5 (go-sqlite3 собран с тегом sqlite_fts5).
+	fts5 bool
+}
+
+func New(opts Options) (*Repo, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &Repo{Options: opts}, nil
+}
+
+// Migrate создает структуры для полнотекстового поиска, которые не описываются ent-схемой:
+// GIN-индекс по tsvector в PostgreSQL или FTS5-таблицу с триггерами в SQLite.
+// Вызывается после Schema.Create.
+func (r *Repo) Migrate(ctx context.Context) error {
+	switch r.dialect {
+	case dialect.Postgres:
+		if _, err := r.db.ExecContext(ctx, postgresMigration); err != nil {
+			return fmt.Errorf("create fts index: %v", err)
+		}
+
+	case dialect.SQLite:
+		if _, err := r.db.ExecContext(ctx, sqliteFTS5Table); err != nil {
+			if !isNoFTS5Module(err) {
+				return fmt.Errorf("create fts table: %v", err)
+			}
+			// Без FTS5 поиск работает через LIKE.
+			return nil
+		}
+		for _, q := range sqliteFTS5Sync {
+			if _, err := r.db.ExecContext(ctx, q); err != nil {
+				return fmt.Errorf("create fts triggers: %v", err)
+			}
+		}
+		r.fts5 = true
+	}
+	return nil
+}
+
+func isNoFTS5Module(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "no such module: fts5")
+}
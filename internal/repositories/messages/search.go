@@ -0,0 +1,205 @@
+package messagesrepo
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const (
+	// tsConfig - конфигурация текстового поиска PostgreSQL. Должна совпадать с конфигурацией индекса.
+	tsConfig = "russian"
+
+	postgresMigration = `CREATE INDEX IF NOT EXISTS messages_body_fts_idx
+		ON messages USING GIN (to_tsvector('` + tsConfig + `', body))`
+
+	sqliteFTS5Table = `CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts
+		USING fts5(body, content='messages', content_rowid='rowid', tokenize='unicode61')`
+)
+
+// sqliteFTS5Sync поддерживает FTS5-таблицу в актуальном состоянии и индексирует уже имеющиеся сообщения.
+var sqliteFTS5Sync = []string{
+	`CREATE TRIGGER IF NOT EXISTS messages_fts_ai AFTER INSERT ON messages BEGIN
+		INSERT INTO messages_fts(rowid, body) VALUES (new.rowid, new.body);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS messages_fts_ad AFTER DELETE ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, body) VALUES ('delete', old.rowid, old.body);
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS messages_fts_au AFTER UPDATE OF body ON messages BEGIN
+		INSERT INTO messages_fts(messages_fts, rowid, body) VALUES ('delete', old.rowid, old.body);
+		INSERT INTO messages_fts(rowid, body) VALUES (new.rowid, new.body);
+	END`,
+	`INSERT INTO messages_fts(messages_fts) VALUES ('rebuild')`,
+}
+
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+var (
+	ErrEmptyQuery    = errors.New("empty search query")
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	errUnsupportedDialect = errors.New("unsupported dialect")
+)
+
+// Visibility определяет, для кого ищутся сообщения.
+type Visibility int
+
+const (
+	VisibleForClient Visibility = iota + 1
+	VisibleForManager
+)
+
+type SearchParams struct {
+	Query      string
+	Visibility Visibility
+	// ClientID ограничивает поиск чатом клиента. Пустой - поиск по всем чатам.
+	ClientID types.UserID
+	// ManagerID ограничивает поиск чатами, в которых менеджеру назначалась проблема, в том числе решенная.
+	ManagerID types.UserID
+	PageSize int
+	Cursor   string
+}
+
+type FoundMessage struct {
+	ID        types.MessageID
+	ChatID    types.ChatID
+	AuthorID  types.UserID
+	CreatedAt time.Time
+	// Snippet - фрагмент сообщения в HTML: текст экранирован, совпадения обернуты в <mark>.
+	Snippet string
+}
+
+type SearchResult struct {
+	Messages []FoundMessage
+	// NextCursor пустой, если следующей страницы нет.
+	NextCursor string
+}
+
+type cursor struct {
+	CreatedAt time.Time       `json:"created_at"`
+	ID        types.MessageID `json:"id"`
+}
+
+// SearchMessages ищет сообщения по тексту. Результаты упорядочены от новых к старым.
+func (r *Repo) SearchMessages(ctx context.Context, params SearchParams) (SearchResult, error) {
+	terms := strings.Fields(params.Query)
+	if len(terms) == 0 {
+		return SearchResult{}, ErrEmptyQuery
+	}
+
+	pageSize := params.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	var after *cursor
+	if params.Cursor != "" {
+		c, err := decodeCursor(params.Cursor)
+		if err != nil {
+			return SearchResult{}, err
+		}
+		after = &c
+	}
+
+	q := newQueryBuilder(r.dialect)
+	switch {
+	case r.dialect == dialect.Postgres:
+		q.postgresMatch(params.Query)
+	case r.dialect == dialect.SQLite && r.fts5:
+		q.fts5Match(terms)
+	case r.dialect == dialect.SQLite:
+		q.likeMatch(terms)
+	default:
+		return SearchResult{}, errUnsupportedDialect
+	}
+
+	switch params.Visibility {
+	case VisibleForClient:
+		q.where("m.is_visible_for_client")
+	case VisibleForManager:
+		q.where("m.is_visible_for_manager")
+	default:
+		return SearchResult{}, fmt.Errorf("unknown visibility %d", params.Visibility)
+	}
+	if !params.ClientID.IsZero() {
+		q.where("c.client_id = " + q.arg(params.ClientID))
+	}
+	if !params.ManagerID.IsZero() {
+		q.where("EXISTS (SELECT 1 FROM problems p WHERE p.chat_id = m.chat_id AND p.manager_id = " + q.arg(params.ManagerID) + ")")
+	}
+	if after != nil {
+		createdAt := q.createdAt()
+		q.where(fmt.Sprintf("(%s < %s OR (%s = %s AND m.id < %s))",
+			createdAt, q.createdAtArg(after.CreatedAt), createdAt, q.createdAtArg(after.CreatedAt), q.arg(after.ID)))
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница.
+	rows, err := r.db.QueryContext(ctx, q.build(pageSize+1), q.args...)
+	if err != nil {
+		return SearchResult{}, fmt.Errorf("query messages: %v", err)
+	}
+	defer rows.Close()
+
+	var found []FoundMessage
+	for rows.Next() {
+		var (
+			m       FoundMessage
+			snippet string
+		)
+		if err := rows.Scan(&m.ID, &m.ChatID, &m.AuthorID, &m.CreatedAt, &snippet); err != nil {
+			return SearchResult{}, fmt.Errorf("scan message: %v", err)
+		}
+		if q.snippetInGo {
+			snippet = likeSnippet(snippet, terms)
+		}
+		m.Snippet = formatSnippet(snippet)
+		found = append(found, m)
+	}
+	if err := rows.Err(); err != nil {
+		return SearchResult{}, fmt.Errorf("iterate messages: %v", err)
+	}
+
+	var res SearchResult
+	if len(found) > pageSize {
+		found = found[:pageSize]
+		last := found[len(found)-1]
+		res.NextCursor = encodeCursor(cursor{CreatedAt: last.CreatedAt.UTC(), ID: last.ID})
+	}
+	res.Messages = found
+
+	logger.FromContext(ctx).Debug("messages search",
+		zap.Int("terms", len(terms)), zap.Int("found", len(found)), zap.Bool("has_next", res.NextCursor != ""))
+	return res, nil
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID.IsZero() || c.CreatedAt.IsZero() {
+		return cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
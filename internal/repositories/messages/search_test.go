@@ -0,0 +1,242 @@
+package messagesrepo_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	messagesrepo "github.com/FischukSergey/chat-service/internal/repositories/messages"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+type fixture struct {
+	repo    *messagesrepo.Repo
+	client  *store.Client
+	clientA types.UserID
+	clientB types.UserID
+	chatA   types.ChatID
+	chatB   types.ChatID
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+	ctx := context.Background()
+
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	repo, err := messagesrepo.New(messagesrepo.NewOptions(client, dialect.SQLite))
+	require.NoError(t, err)
+	require.NoError(t, repo.Migrate(ctx))
+
+	f := fixture{
+		repo:    repo,
+		client:  client,
+		clientA: types.NewUserID(),
+		clientB: types.NewUserID(),
+	}
+	f.chatA = client.Chat.Create().SetClientID(f.clientA).SaveX(ctx).ID
+	f.chatB = client.Chat.Create().SetClientID(f.clientB).SaveX(ctx).ID
+	return f
+}
+
+func (f fixture) addMessage(t *testing.T, chatID types.ChatID, authorID types.UserID, body string, createdAt time.Time) *store.Message {
+	t.Helper()
+
+	return f.client.Message.Create().
+		SetChatID(chatID).
+		SetAuthorID(authorID).
+		SetBody(body).
+		SetCreatedAt(createdAt).
+		SaveX(context.Background())
+}
+
+func TestRepo_SearchMessages(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	now := time.Now()
+	m1 := f.addMessage(t, f.chatA, f.clientA, "Хочу оформить chargeback по операции", now.Add(-3*time.Minute))
+	m2 := f.addMessage(t, f.chatA, f.clientA, "Снова про chargeback: <b>ответа</b> нет", now.Add(-2*time.Minute))
+	f.addMessage(t, f.chatA, f.clientA, "Спасибо, вопрос решен", now.Add(-time.Minute))
+	f.addMessage(t, f.chatB, f.clientB, "У меня тоже chargeback", now)
+
+	hidden := f.addMessage(t, f.chatA, f.clientA, "Скрытый chargeback", now.Add(-4*time.Minute))
+	f.client.Message.UpdateOne(hidden).SetIsVisibleForClient(false).ExecX(ctx)
+
+	t.Run("client scope and visibility", func(t *testing.T) {
+		res, err := f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      "chargeback",
+			Visibility: messagesrepo.VisibleForClient,
+			ClientID:   f.clientA,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Messages, 2)
+		assert.Empty(t, res.NextCursor)
+
+		assert.Equal(t, m2.ID, res.Messages[0].ID)
+		assert.Equal(t, f.chatA, res.Messages[0].ChatID)
+		assert.Equal(t, f.clientA, res.Messages[0].AuthorID)
+		assert.Contains(t, res.Messages[0].Snippet, "<mark>chargeback</mark>")
+		assert.Contains(t, res.Messages[0].Snippet, "&lt;b&gt;")
+		assert.Equal(t, m1.ID, res.Messages[1].ID)
+	})
+
+	t.Run("manager sees messages hidden from client", func(t *testing.T) {
+		res, err := f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      "chargeback",
+			Visibility: messagesrepo.VisibleForManager,
+		})
+		require.NoError(t, err)
+		assert.Len(t, res.Messages, 4)
+	})
+
+	t.Run("manager scope", func(t *testing.T) {
+		managerA, managerB := types.NewUserID(), types.NewUserID()
+		// Решенная проблема тоже открывает менеджеру поиск по чату.
+		f.client.Problem.Create().SetChatID(f.chatA).SetManagerID(managerA).SetStatus(problem.StatusResolved).SaveX(ctx)
+		f.client.Problem.Create().SetChatID(f.chatB).SetManagerID(managerB).SetStatus(problem.StatusInProgress).SaveX(ctx)
+
+		res, err := f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      "chargeback",
+			Visibility: messagesrepo.VisibleForManager,
+			ManagerID:  managerA,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Messages, 3)
+		for _, m := range res.Messages {
+			assert.Equal(t, f.chatA, m.ChatID)
+		}
+
+		res, err = f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      "chargeback",
+			Visibility: messagesrepo.VisibleForManager,
+			ManagerID:  types.NewUserID(),
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res.Messages)
+	})
+
+	t.Run("all terms must match", func(t *testing.T) {
+		res, err := f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      "chargeback операции",
+			Visibility: messagesrepo.VisibleForClient,
+			ClientID:   f.clientA,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Messages, 1)
+		assert.Equal(t, m1.ID, res.Messages[0].ID)
+	})
+
+	t.Run("query syntax is not interpreted", func(t *testing.T) {
+		res, err := f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      `"chargeback OR*`,
+			Visibility: messagesrepo.VisibleForClient,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, res.Messages)
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		_, err := f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      "  ",
+			Visibility: messagesrepo.VisibleForClient,
+		})
+		require.ErrorIs(t, err, messagesrepo.ErrEmptyQuery)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      "chargeback",
+			Visibility: messagesrepo.VisibleForClient,
+			Cursor:     "not a cursor",
+		})
+		require.ErrorIs(t, err, messagesrepo.ErrInvalidCursor)
+	})
+}
+
+func TestRepo_SearchMessages_Pagination(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	now := time.Now()
+	var expected []types.MessageID
+	for i := range 5 {
+		m := f.addMessage(t, f.chatA, f.clientA, "перевод не дошел", now.Add(-time.Duration(i)*time.Minute))
+		expected = append(expected, m.ID)
+	}
+
+	var (
+		got    []types.MessageID
+		cursor string
+	)
+	for range 3 {
+		res, err := f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      "перевод",
+			Visibility: messagesrepo.VisibleForClient,
+			ClientID:   f.clientA,
+			PageSize:   2,
+			Cursor:     cursor,
+		})
+		require.NoError(t, err)
+		for _, m := range res.Messages {
+			got = append(got, m.ID)
+		}
+
+		cursor = res.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	assert.Equal(t, expected, got)
+	assert.Empty(t, cursor)
+}
+
+func TestRepo_SearchMessages_PaginationAcrossTimeZones(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	// Время сообщений записано в разных часовых поясах, курсор сравнивается по моменту времени.
+	zones := []*time.Location{time.FixedZone("MSK", 3*60*60), time.UTC, time.FixedZone("EST", -5*60*60)}
+	now := time.Now()
+	var expected []types.MessageID
+	for i := range 6 {
+		createdAt := now.Add(-time.Duration(i) * time.Minute).In(zones[i%len(zones)])
+		m := f.addMessage(t, f.chatA, f.clientA, "перевод не дошел", createdAt)
+		expected = append(expected, m.ID)
+	}
+
+	var (
+		got    []types.MessageID
+		cursor string
+	)
+	for range 4 {
+		res, err := f.repo.SearchMessages(ctx, messagesrepo.SearchParams{
+			Query:      "перевод",
+			Visibility: messagesrepo.VisibleForManager,
+			PageSize:   2,
+			Cursor:     cursor,
+		})
+		require.NoError(t, err)
+		for _, m := range res.Messages {
+			got = append(got, m.ID)
+		}
+
+		cursor = res.NextCursor
+		if cursor == "" {
+			break
+		}
+	}
+
+	assert.Equal(t, expected, got)
+	assert.Empty(t, cursor)
+}
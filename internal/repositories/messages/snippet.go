@@ -0,0 +1,70 @@
+package messagesrepo
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// snippetContext - сколько символов вокруг первого совпадения попадает в сниппет при поиске через LIKE.
+const snippetContext = 60
+
+// formatSnippet экранирует текст сниппета и заменяет маркеры совпадений на <mark>.
+func formatSnippet(s string) string {
+	s = html.EscapeString(s)
+	return strings.NewReplacer(markStart, "<mark>", markStop, "</mark>").Replace(s)
+}
+
+// likeSnippet вырезает из текста фрагмент вокруг первого совпадения и размечает в нем все совпадения.
+func likeSnippet(body string, terms []string) string {
+	text := []rune(body)
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	// marks[i] - длина совпадения, начинающегося с i-го символа.
+	marks := make(map[int]int)
+	first := -1
+	for _, t := range terms {
+		term := []rune(strings.ToLower(t))
+		for i := 0; i+len(term) <= len(lower); i++ {
+			if string(lower[i:i+len(term)]) != string(term) {
+				continue
+			}
+			if len(term) > marks[i] {
+				marks[i] = len(term)
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+	if first == -1 {
+		first = 0
+	}
+
+	from := max(0, first-snippetContext)
+	to := min(len(text), first+snippetContext)
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("…")
+	}
+	for i := from; i < to; {
+		if n, ok := marks[i]; ok {
+			end := min(i+n, len(text))
+			b.WriteString(markStart)
+			b.WriteString(string(text[i:end]))
+			b.WriteString(markStop)
+			i = end
+			continue
+		}
+		b.WriteRune(text[i])
+		i++
+	}
+	if to < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
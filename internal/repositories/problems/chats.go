@@ -0,0 +1,53 @@
+package problemsrepo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// ManagerChat - чат, в котором менеджеру назначена нерешенная проблема.
+type ManagerChat struct {
+	ChatID    types.ChatID
+	ClientID  types.UserID
+	ProblemID types.ProblemID
+	Status    problem.Status
+	// CreatedAt - время создания проблемы.
+	CreatedAt time.Time
+}
+
+// ManagerChats возвращает чаты с открытыми или взятыми в работу проблемами менеджера,
+// начиная с самой старой проблемы.
+func (r *Repo) ManagerChats(ctx context.Context, managerID types.UserID) ([]ManagerChat, error) {
+	problems, err := r.db.Problem.Query().
+		Where(
+			problem.ManagerID(managerID),
+			problem.StatusIn(problem.StatusOpen, problem.StatusInProgress),
+		).
+		WithChat().
+		Order(problem.ByCreatedAt(), problem.ByID()).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query problems: %v", err)
+	}
+
+	res := make([]ManagerChat, 0, len(problems))
+	for _, p := range problems {
+		res = append(res, managerChat(p))
+	}
+	return res, nil
+}
+
+func managerChat(p *store.Problem) ManagerChat {
+	return ManagerChat{
+		ChatID:    p.ChatID,
+		ClientID:  p.Edges.Chat.ClientID,
+		ProblemID: p.ID,
+		Status:    p.Status,
+		CreatedAt: p.CreatedAt,
+	}
+}
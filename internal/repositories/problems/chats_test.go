@@ -0,0 +1,49 @@
+package problemsrepo_test
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	problemsrepo "github.com/FischukSergey/chat-service/internal/repositories/problems"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestRepo_ManagerChats(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	repo, err := problemsrepo.New(problemsrepo.NewOptions(client))
+	require.NoError(t, err)
+
+	managerA, managerB := types.NewUserID(), types.NewUserID()
+	p1 := addProblem(ctx, t, client, managerA, problem.StatusInProgress)
+	p2 := addProblem(ctx, t, client, managerA, problem.StatusOpen)
+	addProblem(ctx, t, client, managerA, problem.StatusResolved)
+	addProblem(ctx, t, client, managerB, problem.StatusOpen)
+
+	chats, err := repo.ManagerChats(ctx, managerA)
+	require.NoError(t, err)
+	require.Len(t, chats, 2)
+
+	for i, p := range []*store.Problem{p1, p2} {
+		chat := client.Chat.GetX(ctx, p.ChatID)
+		assert.Equal(t, p.ChatID, chats[i].ChatID)
+		assert.Equal(t, chat.ClientID, chats[i].ClientID)
+		assert.Equal(t, p.ID, chats[i].ProblemID)
+		assert.Equal(t, p.Status, chats[i].Status)
+	}
+
+	chats, err = repo.ManagerChats(ctx, types.NewUserID())
+	require.NoError(t, err)
+	assert.Empty(t, chats)
+}
@@ -0,0 +1,69 @@
+package problemsrepo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// ManagerWorkload - количество нерешенных проблем, назначенных менеджеру.
+type ManagerWorkload struct {
+	ManagerID  types.UserID `json:"manager_id"`
+	Open       int          `json:"open"`
+	InProgress int          `json:"in_progress"`
+}
+
+// ManagersWorkload возвращает загрузку менеджеров, у которых есть открытые или взятые в работу проблемы.
+// Менеджеры отсортированы по убыванию общего числа проблем.
+func (r *Repo) ManagersWorkload(ctx context.Context) ([]ManagerWorkload, error) {
+	var rows []struct {
+		ManagerID string `json:"manager_id"`
+		Status    string `json:"status"`
+		Count     int    `json:"count"`
+	}
+	err := r.db.Problem.Query().
+		Where(problem.StatusIn(problem.StatusOpen, problem.StatusInProgress)).
+		GroupBy(problem.FieldManagerID, problem.FieldStatus).
+		Aggregate(store.Count()).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("group problems: %v", err)
+	}
+
+	byManager := make(map[types.UserID]*ManagerWorkload)
+	for _, row := range rows {
+		managerID, err := types.Parse[types.UserID](row.ManagerID)
+		if err != nil {
+			return nil, fmt.Errorf("parse manager id: %v", err)
+		}
+
+		w, ok := byManager[managerID]
+		if !ok {
+			w = &ManagerWorkload{ManagerID: managerID}
+			byManager[managerID] = w
+		}
+		switch problem.Status(row.Status) { //nolint:exhaustive // only unresolved problems are selected
+		case problem.StatusOpen:
+			w.Open = row.Count
+		case problem.StatusInProgress:
+			w.InProgress = row.Count
+		}
+	}
+
+	res := make([]ManagerWorkload, 0, len(byManager))
+	for _, w := range byManager {
+		res = append(res, *w)
+	}
+	slices.SortFunc(res, func(a, b ManagerWorkload) int {
+		if d := (b.Open + b.InProgress) - (a.Open + a.InProgress); d != 0 {
+			return d
+		}
+		return strings.Compare(a.ManagerID.String(), b.ManagerID.String())
+	})
+	return res, nil
+}
@@ -0,0 +1,59 @@
+package problemsrepo_test
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	problemsrepo "github.com/FischukSergey/chat-service/internal/repositories/problems"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestRepo_ManagersWorkload(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	repo, err := problemsrepo.New(problemsrepo.NewOptions(client))
+	require.NoError(t, err)
+
+	managerA, managerB, managerC := types.NewUserID(), types.NewUserID(), types.NewUserID()
+	addProblem(ctx, t, client, managerA, problem.StatusOpen)
+	addProblem(ctx, t, client, managerA, problem.StatusInProgress)
+	addProblem(ctx, t, client, managerA, problem.StatusInProgress)
+	addProblem(ctx, t, client, managerB, problem.StatusOpen)
+	addProblem(ctx, t, client, managerB, problem.StatusResolved)
+	addProblem(ctx, t, client, managerC, problem.StatusClosed)
+
+	workload, err := repo.ManagersWorkload(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []problemsrepo.ManagerWorkload{
+		{ManagerID: managerA, Open: 1, InProgress: 2},
+		{ManagerID: managerB, Open: 1, InProgress: 0},
+	}, workload)
+}
+
+func addProblem(
+	ctx context.Context,
+	t *testing.T,
+	client *store.Client,
+	managerID types.UserID,
+	status problem.Status,
+) *store.Problem {
+	t.Helper()
+
+	chat := client.Chat.Create().SetClientID(types.NewUserID()).SaveX(ctx)
+	return client.Problem.Create().
+		SetChatID(chat.ID).
+		SetManagerID(managerID).
+		SetStatus(status).
+		SaveX(ctx)
+}
@@ -2,31 +2,49 @@ package serverclient
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getsentry/sentry-go"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	oapimdlwr "github.com/oapi-codegen/echo-middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
-	"golang.org/x/sync/errgroup"
 
-	keycloakclient "github.com/FischukSergey/chat-service/internal/clients/keycloak"
+	"github.com/FischukSergey/chat-service/internal/blobstore"
 	"github.com/FischukSergey/chat-service/internal/middlewares"
+	"github.com/FischukSergey/chat-service/internal/ratelimit"
 	clientv1 "github.com/FischukSergey/chat-service/internal/server-client/v1"
+	"github.com/FischukSergey/chat-service/internal/servertls"
+	"github.com/FischukSergey/chat-service/internal/types"
+	websocketstream "github.com/FischukSergey/chat-service/internal/websocket-stream"
 )
 
 const (
 	readHeaderTimeout = time.Second
-	shutdownTimeout   = 3 * time.Second
-	bodyLimit         = "13KB" // Ограничение размера тела запроса до 13 килобайт
+	// multipartOverhead - запас на заголовки multipart-запроса сверх максимального размера вложения.
+	multipartOverhead = 16 << 10
+
+	tracerName = "server-client"
 
 	// Константы для Keycloak авторизации, надо будет поменять на то, что в конфиге.
 	keycloakResource = "chat-ui-client"
 	keycloakRole     = "support-chat-client"
+	// Журнал аудита и операции с префиксом /v1/manager/ доступны только менеджерам.
+	keycloakManagerResource = "chat-ui-manager"
+	keycloakManagerRole     = "support-chat-manager"
 )
 
 //go:generate options-gen -out-filename=server_options.gen.go -from-struct=Options
@@ -34,14 +52,114 @@ type Options struct {
 	logger               *zap.Logger              `option:"mandatory" validate:"required"`
 	addr                 string                   `option:"mandatory" validate:"required,hostname_port"`
 	allowOrigins         []string                 `option:"mandatory" validate:"min=1"`
+	cors                 CORSPolicy               `option:"mandatory"`
+	bodyLimit            int64                    `option:"mandatory" validate:"min=1"`
 	v1Swagger            *openapi3.T              `option:"mandatory" validate:"required"`
 	v1Handlers           clientv1.ServerInterface `option:"mandatory" validate:"required"`
-	keycloakIntrospector *keycloakclient.Client   `option:"optional"`
+	uploadBodyLimit      int64                    `option:"mandatory" validate:"min=1"`
+	keycloakIntrospector middlewares.Introspector `option:"optional"`
+	// metricsRegisterer - куда регистрировать метрики HTTP-запросов. Если не задан, метрики не собираются.
+	metricsRegisterer prometheus.Registerer `option:"optional"`
+	// tracerProvider создает серверные спаны. Если не задан, используется глобальный провайдер.
+	tracerProvider trace.TracerProvider `option:"optional"`
+	// presence отмечает активность пользователей, выполняющих запросы к API.
+	presence middlewares.PresenceTracker `option:"optional"`
+	// sentryClient - клиент Sentry для хабов запросов. Если не задан, контекст запросов в Sentry не передается.
+	sentryClient *sentry.Client `option:"optional"`
+	// auditRecorder записывает в журнал аудита отказы в аутентификации.
+	auditRecorder middlewares.AuditRecorder `option:"optional"`
+	// rateLimitStore хранит корзины токенов. Если не задан, частота запросов не ограничивается.
+	rateLimitStore middlewares.RateLimitStore `option:"optional"`
+	// rateLimitPolicies - политики ограничения частоты запросов по операциям (путь без префикса "/v1/").
+	rateLimitPolicies ratelimit.Policies
+	// trustedProxies - сети обратных прокси, от которых принимается адрес клиента из X-Forwarded-For.
+	// Если не заданы, адрес клиента берется из соединения.
+	trustedProxies []*net.IPNet
+	// realtime и managerRealtime обслуживают WebSocket-соединения клиентов (/ws) и менеджеров (/manager/ws).
+	// Если не заданы, маршруты не регистрируются.
+	realtime        RealtimeHandler `option:"optional"`
+	managerRealtime RealtimeHandler `option:"optional"`
+	// blobHandler отдает вложения по подписанным ссылкам, если хранилище не умеет делать это само.
+	blobHandler http.Handler `option:"optional"`
+	// tlsConfig включает TLS.
+	tlsConfig *tls.Config `option:"optional"`
+	// h2c включает HTTP/2 без TLS.
+	h2c bool
+	// secureHeaders - заголовки безопасности ответов.
+	secureHeaders SecureHeadersPolicy
+	// routes - переопределения настроек по операциям (путь без префикса "/v1/").
+	routes map[string]RoutePolicy
+}
+
+// RealtimeHandler обслуживает WebSocket-соединение аутентифицированного пользователя.
+type RealtimeHandler interface {
+	Serve(w http.ResponseWriter, r *http.Request, userID types.UserID) error
+}
+
+// CORSPolicy - настройки CORS. Разрешенные источники общие для всех маршрутов и задаются через SetAllowOrigins.
+type CORSPolicy struct {
+	AllowMethods     []string
+	AllowHeaders     []string
+	ExposeHeaders    []string
+	AllowCredentials bool
+	// MaxAge - сколько браузер может кешировать ответ на preflight-запрос.
+	MaxAge time.Duration
+}
+
+// SecureHeadersPolicy - заголовки безопасности ответов. Пустые значения не отправляются.
+type SecureHeadersPolicy struct {
+	// HSTSMaxAge включает Strict-Transport-Security. Заголовок отправляется только на запросы по HTTPS,
+	// в том числе пришедшие через прокси с X-Forwarded-Proto: https.
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+	HSTSPreload           bool
+	ContentSecurityPolicy string
+	ContentTypeNosniff    bool
+	FrameOptions          string
+	ReferrerPolicy        string
+}
+
+// RoutePolicy - переопределения настроек маршрута. Незаданные значения берутся из общих настроек.
+type RoutePolicy struct {
+	// BodyLimit - ограничение размера тела запроса в байтах.
+	BodyLimit int64
+	// CORS и SecureHeaders заменяют общие настройки целиком.
+	CORS          *CORSPolicy
+	SecureHeaders *SecureHeadersPolicy
 }
 
 type Server struct {
 	lg  *zap.Logger
 	srv *http.Server
+	e   *echo.Echo
+
+	cors          CORSPolicy
+	secureHeaders SecureHeadersPolicy
+	routes        map[string]RoutePolicy
+
+	// httpPolicies, allowOrigins и rateLimitPolicies меняются на лету при перечитывании конфига.
+	httpPolicies      atomic.Pointer[httpPolicies]
+	allowOrigins      atomic.Pointer[[]string]
+	rateLimitPolicies atomic.Pointer[ratelimit.Policies]
+	// operations - пути зарегистрированных маршрутов по именам операций.
+	operations map[string]string
+
+	// stopOnce, stopped и stopErr - остановка http.Server, начатая StopAccepting.
+	stopOnce sync.Once
+	stopped  chan struct{}
+	stopErr  error
+}
+
+// httpPolicy - CORS и заголовки безопасности маршрута.
+type httpPolicy struct {
+	cors          echo.MiddlewareFunc
+	secureHeaders echo.MiddlewareFunc
+}
+
+type httpPolicies struct {
+	def httpPolicy
+	// byPath - политики маршрутов с переопределениями по путям маршрутов.
+	byPath map[string]httpPolicy
 }
 
 func New(opts Options) (*Server, error) {
@@ -50,29 +168,65 @@ func New(opts Options) (*Server, error) {
 		return nil, fmt.Errorf("validate options: %v", err)
 	}
 
+	s := &Server{
+		lg:            opts.logger,
+		cors:          opts.cors,
+		secureHeaders: opts.secureHeaders,
+		routes:        opts.routes,
+		operations:    make(map[string]string),
+		stopped:       make(chan struct{}),
+	}
+	s.rateLimitPolicies.Store(&opts.rateLimitPolicies)
+
 	e := echo.New()
+	// Адрес клиента служит ключом лимитов по IP и попадает в журнал аудита, поэтому заголовкам с адресом
+	// доверяем, только если запрос пришел от доверенного прокси.
+	e.IPExtractor = ipExtractor(opts.trustedProxies)
+	if opts.metricsRegisterer != nil {
+		e.Use(middlewares.NewMetrics(opts.metricsRegisterer, "server_client"))
+	}
+	var tracingOptions []otelecho.Option
+	if opts.tracerProvider != nil {
+		tracingOptions = append(tracingOptions, otelecho.WithTracerProvider(opts.tracerProvider))
+	}
 	e.Use(
 		// Recovery middleware - восстанавливается после паники и логирует ошибку со стеком
 		middlewares.NewRecovery(opts.logger),
-		// Ограничение размера тела запроса
-		middleware.BodyLimit(bodyLimit),
+		// Трассировка - начинает серверный спан, продолжая трассу из заголовка traceparent
+		otelecho.Middleware(tracerName, tracingOptions...),
+		// Идентификатор запроса и логгер запроса в контексте
+		middlewares.NewRequestID(opts.logger),
+		// Хаб Sentry запроса: теги, пользователь, заголовки и хлебные крошки
+		middlewares.NewSentry(opts.sentryClient),
 		// Логирование запросов - логирует информацию о запросе, включая ID
 		middlewares.NewRequestLogger(opts.logger),
-		// CORS middleware
-		middleware.CORSWithConfig(middleware.CORSConfig{
-			AllowOrigins: opts.allowOrigins,
-			AllowMethods: []string{http.MethodPost, http.MethodOptions},
-			AllowHeaders: []string{"X-Request-ID", "Content-Type", "Authorization"},
-		}),
+		// CORS и заголовки безопасности выбираются по маршруту, в том числе для preflight-запросов.
+		// Список разрешенных источников меняется через SetAllowOrigins.
+		s.httpPolicyMiddleware,
 	)
 
-	// Добавляем middleware для авторизации Keycloak, если указан introspector
+	// Авторизация Keycloak навешивается на маршруты API, а не глобально:
+	// ссылки на скачивание вложений защищены подписью, а не токеном.
+	var auth, managerAuth []echo.MiddlewareFunc
+	if opts.auditRecorder != nil {
+		auth = append(auth, middlewares.NewAuthAudit(opts.auditRecorder))
+		managerAuth = append(managerAuth, middlewares.NewAuthAudit(opts.auditRecorder))
+	}
 	if opts.keycloakIntrospector != nil {
-		e.Use(middlewares.NewKeycloakTokenAuth(
+		auth = append(auth, middlewares.NewKeycloakTokenAuth(
 			opts.keycloakIntrospector,
 			keycloakResource, // надо будет поменять на то, что в конфиге
 			keycloakRole,     // надо будет поменять на то, что в конфиге
 		))
+		managerAuth = append(managerAuth, middlewares.NewKeycloakTokenAuth(
+			opts.keycloakIntrospector,
+			keycloakManagerResource,
+			keycloakManagerRole,
+		))
+	}
+	if opts.presence != nil {
+		auth = append(auth, middlewares.NewPresence(opts.presence))
+		managerAuth = append(managerAuth, middlewares.NewPresence(opts.presence))
 	}
 
 	// переделаный авторский вариант ??????????
@@ -84,44 +238,274 @@ func New(opts Options) (*Server, error) {
 			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
 		},
 	})
+	// Валидатор тела читает его в память целиком, поэтому у загрузки вложений проверяются только параметры:
+	// файл читается обработчиком потоком, а его размер ограничивает сервис вложений.
+	paramsValidator := oapimdlwr.OapiRequestValidatorWithOptions(opts.v1Swagger, &oapimdlwr.Options{
+		Options: openapi3filter.Options{
+			ExcludeRequestBody:  true,
+			ExcludeResponseBody: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+	})
 
 	// Регистрируем обработчики напрямую на маршрутах без группы v1
 	wrapper := &clientv1.ServerInterfaceWrapper{Handler: opts.v1Handlers}
-	e.POST("/v1/getHistory", wrapper.PostGetHistory, validator)
+	// Ограничение размера тела запроса задается для каждого маршрута:
+	// для загрузки вложений оно определяется максимальным размером файла.
+	// Частота запросов с IP-адреса ограничивается до аутентификации, чтобы поток запросов с неверными
+	// токенами не нагружал Keycloak и журнал аудита, а частота операций - после нее, по пользователям.
+	// validate проверяет запрос по спецификации, если задан.
+	route := func(
+		operation, path string,
+		limit int64,
+		routeAuth []echo.MiddlewareFunc,
+		validate echo.MiddlewareFunc,
+	) []echo.MiddlewareFunc {
+		s.operations[operation] = path
+		if p := opts.routes[operation]; p.BodyLimit > 0 {
+			limit = p.BodyLimit
+		}
+		var m []echo.MiddlewareFunc
+		if opts.rateLimitStore != nil {
+			m = append(m, middlewares.NewIPRateLimit(opts.rateLimitStore, func() ratelimit.Policy {
+				return s.rateLimitPolicies.Load().PerIP
+			}))
+		}
+		m = append(m, middleware.BodyLimit(strconv.FormatInt(limit, 10)))
+		m = append(m, routeAuth...)
+		if opts.rateLimitStore != nil {
+			m = append(m, middlewares.NewRateLimit(opts.rateLimitStore, operation, func() ratelimit.Policy {
+				return s.rateLimitPolicies.Load().For(operation)
+			}))
+		}
+		if validate != nil {
+			m = append(m, validate)
+		}
+		return m
+	}
+	uploadBodyLimit := opts.uploadBodyLimit + multipartOverhead
+	bodyLimit := opts.bodyLimit
+
+	e.POST("/v1/getHistory", wrapper.PostGetHistory,
+		route("getHistory", "/v1/getHistory", bodyLimit, auth, validator)...)
+	e.POST("/v1/sendMessage", wrapper.PostSendMessage,
+		route("sendMessage", "/v1/sendMessage", bodyLimit, auth, validator)...)
+	e.POST("/v1/searchMessages", wrapper.PostSearchMessages,
+		route("searchMessages", "/v1/searchMessages", bodyLimit, auth, validator)...)
+	e.POST("/v1/getAttachment", wrapper.PostGetAttachment,
+		route("getAttachment", "/v1/getAttachment", bodyLimit, auth, validator)...)
+	e.POST("/v1/uploadAttachment", wrapper.PostUploadAttachment,
+		route("uploadAttachment", "/v1/uploadAttachment", uploadBodyLimit, auth, paramsValidator)...)
+	e.POST("/v1/manager/searchMessages", wrapper.PostManagerSearchMessages,
+		route("manager/searchMessages", "/v1/manager/searchMessages", bodyLimit, managerAuth, validator)...)
+	e.POST("/v1/manager/sendMessage", wrapper.PostManagerSendMessage,
+		route("manager/sendMessage", "/v1/manager/sendMessage", bodyLimit, managerAuth, validator)...)
+	e.POST("/v1/manager/getChatHistory", wrapper.PostManagerGetChatHistory,
+		route("manager/getChatHistory", "/v1/manager/getChatHistory", bodyLimit, managerAuth, validator)...)
+	e.POST("/v1/manager/getAttachment", wrapper.PostManagerGetAttachment,
+		route("manager/getAttachment", "/v1/manager/getAttachment", bodyLimit, managerAuth, validator)...)
+	e.POST("/v1/manager/getChats", wrapper.PostManagerGetChats,
+		route("manager/getChats", "/v1/manager/getChats", bodyLimit, managerAuth, validator)...)
+	e.POST("/v1/getAuditEvents", wrapper.PostGetAuditEvents,
+		route("getAuditEvents", "/v1/getAuditEvents", bodyLimit, managerAuth, validator)...)
+
+	// WebSocket-соединения открываются GET-запросом без тела, которого нет в спецификации.
+	// Источник проверяется до аутентификации: CORS не защищает от чужих страниц,
+	// открывающих WebSocket с токеном пользователя.
+	realtimeRoute := func(operation, path string, routeAuth []echo.MiddlewareFunc) []echo.MiddlewareFunc {
+		m := []echo.MiddlewareFunc{s.checkOrigin, middlewares.NewWebSocketToken(websocketstream.Protocol)}
+		return append(m, route(operation, path, bodyLimit, routeAuth, nil)...)
+	}
+	if opts.realtime != nil {
+		e.GET("/ws", realtimeHandler(opts.realtime), realtimeRoute("ws", "/ws", auth)...)
+	}
+	if opts.managerRealtime != nil {
+		e.GET("/manager/ws", realtimeHandler(opts.managerRealtime), realtimeRoute("manager/ws", "/manager/ws", managerAuth)...)
+	}
+
+	// Ссылки на скачивание не требуют токена, поэтому скачивания считаются по IP-адресам.
+	downloadRoute := route("downloadAttachment", blobstore.DownloadPath+"*", bodyLimit, nil, nil)
+	if opts.blobHandler != nil {
+		e.GET(blobstore.DownloadPath+"*", echo.WrapHandler(opts.blobHandler), downloadRoute...)
+	}
+
+	for operation := range opts.routes {
+		if _, ok := s.operations[operation]; !ok {
+			return nil, fmt.Errorf("unknown route operation %q", operation)
+		}
+	}
+	s.SetAllowOrigins(opts.allowOrigins)
 
-	srv := &http.Server{
+	if err := s.checkRateLimitOperations(opts.rateLimitPolicies); err != nil {
+		return nil, err
+	}
+
+	s.e = e
+	s.srv = &http.Server{
 		Addr:              opts.addr,
-		Handler:           e,
+		Handler:           servertls.Handler(e, opts.h2c),
 		ReadHeaderTimeout: readHeaderTimeout,
+		TLSConfig:         opts.tlsConfig,
 	}
+	return s, nil
+}
 
-	return &Server{
-		lg:  opts.logger,
-		srv: srv,
-	}, nil
+// SetAllowOrigins заменяет список источников, которым CORS разрешает запросы.
+func (s *Server) SetAllowOrigins(origins []string) {
+	p := &httpPolicies{
+		def:    httpPolicy{cors: corsMiddleware(origins, s.cors), secureHeaders: secureHeadersMiddleware(s.secureHeaders)},
+		byPath: make(map[string]httpPolicy, len(s.routes)),
+	}
+	s.allowOrigins.Store(&origins)
+	for operation, r := range s.routes {
+		if r.CORS == nil && r.SecureHeaders == nil {
+			continue
+		}
+		routePolicy := p.def
+		if r.CORS != nil {
+			routePolicy.cors = corsMiddleware(origins, *r.CORS)
+		}
+		if r.SecureHeaders != nil {
+			routePolicy.secureHeaders = secureHeadersMiddleware(*r.SecureHeaders)
+		}
+		p.byPath[s.operations[operation]] = routePolicy
+	}
+	s.httpPolicies.Store(p)
+}
+
+func corsMiddleware(origins []string, p CORSPolicy) echo.MiddlewareFunc {
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins:     origins,
+		AllowMethods:     p.AllowMethods,
+		AllowHeaders:     p.AllowHeaders,
+		ExposeHeaders:    p.ExposeHeaders,
+		AllowCredentials: p.AllowCredentials,
+		MaxAge:           int(p.MaxAge / time.Second),
+	})
 }
 
-func (s *Server) Run(ctx context.Context) error {
-	eg, ctx := errgroup.WithContext(ctx)
+func secureHeadersMiddleware(p SecureHeadersPolicy) echo.MiddlewareFunc {
+	cfg := middleware.SecureConfig{
+		XFrameOptions:         p.FrameOptions,
+		HSTSMaxAge:            int(p.HSTSMaxAge / time.Second),
+		HSTSExcludeSubdomains: !p.HSTSIncludeSubdomains,
+		HSTSPreloadEnabled:    p.HSTSPreload,
+		ContentSecurityPolicy: p.ContentSecurityPolicy,
+		ReferrerPolicy:        p.ReferrerPolicy,
+	}
+	if p.ContentTypeNosniff {
+		cfg.ContentTypeNosniff = "nosniff"
+	}
+	return middleware.SecureWithConfig(cfg)
+}
 
-	eg.Go(func() error {
-		// Ожидаем завершения контекста и выполняем graceful shutdown
-		<-ctx.Done()
+// ipExtractor берет адрес клиента из соединения, а за доверенными прокси - из X-Forwarded-For.
+func ipExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
 
-		shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
-		defer cancel()
+	trust := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, n := range trustedProxies {
+		trust = append(trust, echo.TrustIPRange(n))
+	}
+	return echo.ExtractIPFromXFFHeader(trust...)
+}
 
-		return s.srv.Shutdown(shutdownCtx)
-	})
+// checkOrigin отклоняет WebSocket-соединения со страниц, не входящих в список разрешенных источников.
+// Запросы без заголовка Origin приходят не из браузера и пропускаются.
+func (s *Server) checkOrigin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		origin := c.Request().Header.Get(echo.HeaderOrigin)
+		if origin == "" {
+			return next(c)
+		}
+		origins := *s.allowOrigins.Load()
+		if !slices.Contains(origins, "*") && !slices.Contains(origins, origin) {
+			return echo.NewHTTPError(http.StatusForbidden, "origin is not allowed")
+		}
+		return next(c)
+	}
+}
 
-	eg.Go(func() error {
-		s.lg.Info("listen and serve", zap.String("addr", s.srv.Addr))
+func realtimeHandler(h RealtimeHandler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return h.Serve(c.Response(), c.Request(), middlewares.MustUserID(c))
+	}
+}
+
+// SetRateLimitPolicies заменяет политики ограничения частоты запросов.
+// Корзины токенов операций с изменившейся политикой начинаются заново.
+func (s *Server) SetRateLimitPolicies(p ratelimit.Policies) error {
+	if err := s.checkRateLimitOperations(p); err != nil {
+		return err
+	}
+	s.rateLimitPolicies.Store(&p)
+	return nil
+}
 
-		if err := s.srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			return err
+func (s *Server) checkRateLimitOperations(p ratelimit.Policies) error {
+	for operation := range p.Operations {
+		if _, ok := s.operations[operation]; !ok {
+			return fmt.Errorf("unknown rate limit operation %q", operation)
 		}
-		return nil
+	}
+	return nil
+}
+
+// httpPolicyMiddleware применяет CORS и заголовки безопасности маршрута, к которому относится запрос.
+// Echo определяет маршрут и для preflight-запросов, поэтому они получают настройки CORS своего маршрута.
+func (s *Server) httpPolicyMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		policies := s.httpPolicies.Load()
+		p, ok := policies.byPath[c.Path()]
+		if !ok {
+			p = policies.def
+		}
+		return p.secureHeaders(p.cors(next))(c)
+	}
+}
+
+// Routes возвращает зарегистрированные маршруты сервера.
+func (s *Server) Routes() []*echo.Route {
+	return s.e.Routes()
+}
+
+// Run обслуживает запросы, пока сервер не будет остановлен через Shutdown.
+func (s *Server) Run() error {
+	s.lg.Info("listen and serve", zap.String("addr", s.srv.Addr), zap.Bool("tls", s.srv.TLSConfig != nil))
+
+	if err := servertls.ListenAndServe(s.srv); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// StopAccepting закрывает слушающие сокеты и возвращается, не дожидаясь завершения активных запросов.
+// Так realtime-клиенты могут получить кадр закрытия, пока сервер ждет запросы. WebSocket-соединения
+// http.Server не отслеживает: они завершаются при закрытии подписок на события.
+func (s *Server) StopAccepting() {
+	s.stopOnce.Do(func() {
+		go func() {
+			// Ожидание ограничивает Shutdown: по его таймауту активные соединения закрываются принудительно.
+			s.stopErr = s.srv.Shutdown(context.Background())
+			close(s.stopped)
+		}()
 	})
+}
+
+// Shutdown перестает принимать соединения, если это еще не сделал StopAccepting, и ждет завершения
+// активных запросов, пока не завершится ctx. По завершении ctx оставшиеся соединения закрываются.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.StopAccepting()
 
-	return eg.Wait()
+	select {
+	case <-s.stopped:
+		return s.stopErr
+	case <-ctx.Done():
+		if err := s.srv.Close(); err != nil {
+			s.lg.Warn("close connections", zap.Error(err))
+		}
+		return fmt.Errorf("wait active requests: %w", ctx.Err())
+	}
 }
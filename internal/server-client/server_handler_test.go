@@ -0,0 +1,7 @@
+package serverclient //nolint:testpackage // special hack
+
+import "net/http"
+
+func (s *Server) Handler() http.Handler {
+	return s.srv.Handler
+}
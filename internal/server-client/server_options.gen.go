@@ -2,13 +2,20 @@
 package serverclient
 
 import (
+	"crypto/tls"
 	fmt461e464ebed9 "fmt"
+	"net"
+	"net/http"
 
-	keycloakclient "github.com/FischukSergey/chat-service/internal/clients/keycloak"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	"github.com/FischukSergey/chat-service/internal/ratelimit"
 	clientv1 "github.com/FischukSergey/chat-service/internal/server-client/v1"
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getsentry/sentry-go"
 	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
 	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -18,8 +25,11 @@ func NewOptions(
 	logger *zap.Logger,
 	addr string,
 	allowOrigins []string,
+	cors CORSPolicy,
+	bodyLimit int64,
 	v1Swagger *openapi3.T,
 	v1Handlers clientv1.ServerInterface,
+	uploadBodyLimit int64,
 	options ...OptOptionsSetter,
 ) Options {
 	o := Options{}
@@ -32,30 +42,159 @@ func NewOptions(
 
 	o.allowOrigins = allowOrigins
 
+	o.cors = cors
+
+	o.bodyLimit = bodyLimit
+
 	o.v1Swagger = v1Swagger
 
 	o.v1Handlers = v1Handlers
 
+	o.uploadBodyLimit = uploadBodyLimit
+
 	for _, opt := range options {
 		opt(&o)
 	}
 	return o
 }
 
-func WithKeycloakIntrospector(opt *keycloakclient.Client) OptOptionsSetter {
+func WithKeycloakIntrospector(opt middlewares.Introspector) OptOptionsSetter {
 	return func(o *Options) {
 		o.keycloakIntrospector = opt
 
 	}
 }
 
+// metricsRegisterer - куда регистрировать метрики HTTP-запросов. Если не задан, метрики не собираются.
+func WithMetricsRegisterer(opt prometheus.Registerer) OptOptionsSetter {
+	return func(o *Options) {
+		o.metricsRegisterer = opt
+
+	}
+}
+
+// tracerProvider создает серверные спаны. Если не задан, используется глобальный провайдер.
+func WithTracerProvider(opt trace.TracerProvider) OptOptionsSetter {
+	return func(o *Options) {
+		o.tracerProvider = opt
+
+	}
+}
+
+// presence отмечает активность пользователей, выполняющих запросы к API.
+func WithPresence(opt middlewares.PresenceTracker) OptOptionsSetter {
+	return func(o *Options) {
+		o.presence = opt
+
+	}
+}
+
+// sentryClient - клиент Sentry для хабов запросов. Если не задан, контекст запросов в Sentry не передается.
+func WithSentryClient(opt *sentry.Client) OptOptionsSetter {
+	return func(o *Options) {
+		o.sentryClient = opt
+
+	}
+}
+
+// auditRecorder записывает в журнал аудита отказы в аутентификации.
+func WithAuditRecorder(opt middlewares.AuditRecorder) OptOptionsSetter {
+	return func(o *Options) {
+		o.auditRecorder = opt
+
+	}
+}
+
+// rateLimitStore хранит корзины токенов. Если не задан, частота запросов не ограничивается.
+func WithRateLimitStore(opt middlewares.RateLimitStore) OptOptionsSetter {
+	return func(o *Options) {
+		o.rateLimitStore = opt
+
+	}
+}
+
+// rateLimitPolicies - политики ограничения частоты запросов по операциям (путь без префикса "/v1/").
+func WithRateLimitPolicies(opt ratelimit.Policies) OptOptionsSetter {
+	return func(o *Options) {
+		o.rateLimitPolicies = opt
+
+	}
+}
+
+// trustedProxies - сети обратных прокси, от которых принимается адрес клиента из X-Forwarded-For.
+// Если не заданы, адрес клиента берется из соединения.
+func WithTrustedProxies(opt []*net.IPNet) OptOptionsSetter {
+	return func(o *Options) {
+		o.trustedProxies = opt
+
+	}
+}
+
+// realtime и managerRealtime обслуживают WebSocket-соединения клиентов (/ws) и менеджеров (/manager/ws).
+// Если не заданы, маршруты не регистрируются.
+func WithRealtime(opt RealtimeHandler) OptOptionsSetter {
+	return func(o *Options) {
+		o.realtime = opt
+
+	}
+}
+
+func WithManagerRealtime(opt RealtimeHandler) OptOptionsSetter {
+	return func(o *Options) {
+		o.managerRealtime = opt
+
+	}
+}
+
+// blobHandler отдает вложения по подписанным ссылкам, если хранилище не умеет делать это само.
+func WithBlobHandler(opt http.Handler) OptOptionsSetter {
+	return func(o *Options) {
+		o.blobHandler = opt
+
+	}
+}
+
+// tlsConfig включает TLS.
+func WithTlsConfig(opt *tls.Config) OptOptionsSetter {
+	return func(o *Options) {
+		o.tlsConfig = opt
+
+	}
+}
+
+// h2c включает HTTP/2 без TLS.
+func WithH2c(opt bool) OptOptionsSetter {
+	return func(o *Options) {
+		o.h2c = opt
+
+	}
+}
+
+// secureHeaders - заголовки безопасности ответов.
+func WithSecureHeaders(opt SecureHeadersPolicy) OptOptionsSetter {
+	return func(o *Options) {
+		o.secureHeaders = opt
+
+	}
+}
+
+// routes - переопределения настроек по операциям (путь без префикса "/v1/").
+func WithRoutes(opt map[string]RoutePolicy) OptOptionsSetter {
+	return func(o *Options) {
+		o.routes = opt
+
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("logger", _validate_Options_logger(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("addr", _validate_Options_addr(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("allowOrigins", _validate_Options_allowOrigins(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("bodyLimit", _validate_Options_bodyLimit(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("v1Swagger", _validate_Options_v1Swagger(o)))
 	errs.Add(errors461e464ebed9.NewValidationError("v1Handlers", _validate_Options_v1Handlers(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("uploadBodyLimit", _validate_Options_uploadBodyLimit(o)))
 	return errs.AsError()
 }
 
@@ -80,6 +219,13 @@ func _validate_Options_allowOrigins(o *Options) error {
 	return nil
 }
 
+func _validate_Options_bodyLimit(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.bodyLimit, "min=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `bodyLimit` did not pass the test: %w", err)
+	}
+	return nil
+}
+
 func _validate_Options_v1Swagger(o *Options) error {
 	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.v1Swagger, "required"); err != nil {
 		return fmt461e464ebed9.Errorf("field `v1Swagger` did not pass the test: %w", err)
@@ -93,3 +239,10 @@ func _validate_Options_v1Handlers(o *Options) error {
 	}
 	return nil
 }
+
+func _validate_Options_uploadBodyLimit(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.uploadBodyLimit, "min=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `uploadBodyLimit` did not pass the test: %w", err)
+	}
+	return nil
+}
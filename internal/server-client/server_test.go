@@ -0,0 +1,358 @@
+package serverclient_test
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+
+	"github.com/FischukSergey/chat-service/internal/blobstore"
+	keycloakclient "github.com/FischukSergey/chat-service/internal/clients/keycloak"
+	middlewaresmocks "github.com/FischukSergey/chat-service/internal/middlewares/mocks"
+	"github.com/FischukSergey/chat-service/internal/ratelimit"
+	serverclient "github.com/FischukSergey/chat-service/internal/server-client"
+	clientv1 "github.com/FischukSergey/chat-service/internal/server-client/v1"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const origin = "http://localhost:3000"
+
+// handlersStub не вызывается: запросы в тестах не доходят до обработчиков API.
+type handlersStub struct {
+	clientv1.ServerInterface
+}
+
+func newServer(t *testing.T, opts ...serverclient.OptOptionsSetter) *serverclient.Server {
+	t.Helper()
+
+	swagger, err := clientv1.GetSwagger()
+	require.NoError(t, err)
+	swagger.Servers = nil
+
+	cors := serverclient.CORSPolicy{
+		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodOptions},
+		AllowHeaders: []string{"Content-Type", "Authorization"},
+		MaxAge:       10 * time.Minute,
+	}
+	opts = append([]serverclient.OptOptionsSetter{
+		serverclient.WithBlobHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, "file")
+		})),
+		serverclient.WithSecureHeaders(serverclient.SecureHeadersPolicy{
+			HSTSMaxAge:            time.Hour,
+			HSTSIncludeSubdomains: true,
+			ContentSecurityPolicy: "default-src 'none'",
+			ContentTypeNosniff:    true,
+			FrameOptions:          "DENY",
+		}),
+		serverclient.WithRoutes(map[string]serverclient.RoutePolicy{
+			"uploadAttachment": {
+				BodyLimit: 100,
+				CORS: &serverclient.CORSPolicy{
+					AllowMethods:     []string{http.MethodPost},
+					AllowHeaders:     []string{"Content-Type"},
+					AllowCredentials: true,
+					MaxAge:           time.Minute,
+				},
+			},
+			"downloadAttachment": {
+				SecureHeaders: &serverclient.SecureHeadersPolicy{ContentSecurityPolicy: "sandbox"},
+			},
+		}),
+	}, opts...)
+
+	srv, err := serverclient.New(serverclient.NewOptions(
+		zap.NewNop(), ":8080", []string{origin}, cors, 1<<10, swagger, handlersStub{}, 1<<20, opts...))
+	require.NoError(t, err)
+	return srv
+}
+
+func TestServer_CORS(t *testing.T) {
+	srv := newServer(t)
+
+	cases := []struct {
+		name            string
+		path            string
+		expMethods      string
+		expMaxAge       string
+		expCredentials  string
+		expAllowHeaders string
+	}{
+		{
+			name:            "default policy",
+			path:            "/v1/getHistory",
+			expMethods:      "GET,POST,OPTIONS",
+			expMaxAge:       "600",
+			expAllowHeaders: "Content-Type,Authorization",
+		},
+		{
+			name:            "route override",
+			path:            "/v1/uploadAttachment",
+			expMethods:      "POST",
+			expMaxAge:       "60",
+			expCredentials:  "true",
+			expAllowHeaders: "Content-Type",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, tt.path, nil)
+			req.Header.Set("Origin", origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			rec := httptest.NewRecorder()
+
+			srv.Handler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code)
+			assert.Equal(t, origin, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tt.expMethods, rec.Header().Get("Access-Control-Allow-Methods"))
+			assert.Equal(t, tt.expMaxAge, rec.Header().Get("Access-Control-Max-Age"))
+			assert.Equal(t, tt.expCredentials, rec.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, tt.expAllowHeaders, rec.Header().Get("Access-Control-Allow-Headers"))
+		})
+	}
+
+	t.Run("allow origins are replaced", func(t *testing.T) {
+		srv.SetAllowOrigins([]string{"https://chat.example.com"})
+
+		req := httptest.NewRequest(http.MethodOptions, "/v1/uploadAttachment", nil)
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		rec := httptest.NewRecorder()
+
+		srv.Handler().ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	})
+}
+
+func TestServer_SecureHeaders(t *testing.T) {
+	srv := newServer(t)
+
+	t.Run("default policy", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/getHistory", strings.NewReader("{}"))
+		req.Header.Set("X-Forwarded-Proto", "https")
+		rec := httptest.NewRecorder()
+
+		srv.Handler().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "nosniff", rec.Header().Get("X-Content-Type-Options"))
+		assert.Equal(t, "DENY", rec.Header().Get("X-Frame-Options"))
+		assert.Equal(t, "default-src 'none'", rec.Header().Get("Content-Security-Policy"))
+		assert.Equal(t, "max-age=3600; includeSubdomains", rec.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("no hsts over http", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/v1/getHistory", strings.NewReader("{}"))
+		rec := httptest.NewRecorder()
+
+		srv.Handler().ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get("Strict-Transport-Security"))
+	})
+
+	t.Run("route override", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, blobstore.DownloadPath+"file", nil)
+		rec := httptest.NewRecorder()
+
+		srv.Handler().ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "sandbox", rec.Header().Get("Content-Security-Policy"))
+		assert.Empty(t, rec.Header().Get("X-Frame-Options"))
+	})
+}
+
+func TestServer_BodyLimit(t *testing.T) {
+	srv := newServer(t)
+
+	cases := []struct {
+		name string
+		path string
+		size int
+	}{
+		{name: "default limit", path: "/v1/getHistory", size: 2 << 10},
+		{name: "route override", path: "/v1/uploadAttachment", size: 200},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(strings.Repeat("a", tt.size)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			srv.Handler().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		})
+	}
+}
+
+func TestServer_UnknownRouteOperation(t *testing.T) {
+	swagger, err := clientv1.GetSwagger()
+	require.NoError(t, err)
+
+	_, err = serverclient.New(serverclient.NewOptions(
+		zap.NewNop(), ":8080", []string{origin}, serverclient.CORSPolicy{}, 1<<10, swagger, handlersStub{}, 1<<20,
+		serverclient.WithRoutes(map[string]serverclient.RoutePolicy{"unknown": {BodyLimit: 1}}),
+	))
+	require.Error(t, err)
+}
+
+func TestServer_IPRateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	introspector := middlewaresmocks.NewMockIntrospector(ctrl)
+	introspector.EXPECT().IntrospectToken(gomock.Any(), "token").
+		Return(&keycloakclient.IntrospectTokenResult{Active: false}, nil).AnyTimes()
+
+	newLimitedServer := func(t *testing.T, opts ...serverclient.OptOptionsSetter) *serverclient.Server {
+		t.Helper()
+
+		store, err := ratelimit.NewMemoryStore(ratelimit.NewMemoryStoreOptions())
+		require.NoError(t, err)
+		return newServer(t, append([]serverclient.OptOptionsSetter{
+			serverclient.WithKeycloakIntrospector(introspector),
+			serverclient.WithRateLimitStore(store),
+			serverclient.WithRateLimitPolicies(ratelimit.Policies{
+				PerIP:   ratelimit.Policy{Rate: rate.Limit(0.01), Burst: 1},
+				Default: ratelimit.Policy{Rate: 100, Burst: 100},
+			}),
+		}, opts...)...)
+	}
+
+	// Токен неактивен: запрос, прошедший лимит по IP, получает 401.
+	request := func(srv *serverclient.Server, remoteAddr, forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodPost, "/v1/getHistory", strings.NewReader("{}"))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer token")
+		req.RemoteAddr = remoteAddr
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+			req.Header.Set("X-Real-IP", forwardedFor)
+		}
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("spoofed headers do not reset the bucket", func(t *testing.T) {
+		srv := newLimitedServer(t)
+
+		assert.Equal(t, http.StatusUnauthorized, request(srv, "203.0.113.1:1234", ""))
+		assert.Equal(t, http.StatusTooManyRequests, request(srv, "203.0.113.1:1234", "198.51.100.1"))
+		assert.Equal(t, http.StatusTooManyRequests, request(srv, "203.0.113.1:1234", "198.51.100.2"))
+
+		// У другого адреса своя корзина.
+		assert.Equal(t, http.StatusUnauthorized, request(srv, "203.0.113.2:1234", ""))
+	})
+
+	t.Run("trusted proxy forwards client address", func(t *testing.T) {
+		_, proxies, err := net.ParseCIDR("10.0.0.0/8")
+		require.NoError(t, err)
+		srv := newLimitedServer(t, serverclient.WithTrustedProxies([]*net.IPNet{proxies}))
+
+		assert.Equal(t, http.StatusUnauthorized, request(srv, "10.0.0.1:1234", "198.51.100.1"))
+		assert.Equal(t, http.StatusTooManyRequests, request(srv, "10.0.0.1:1234", "198.51.100.1"))
+		assert.Equal(t, http.StatusUnauthorized, request(srv, "10.0.0.1:1234", "198.51.100.2"))
+
+		// Заголовок от адреса не из списка прокси игнорируется.
+		assert.Equal(t, http.StatusUnauthorized, request(srv, "203.0.113.1:1234", "198.51.100.3"))
+		assert.Equal(t, http.StatusTooManyRequests, request(srv, "203.0.113.1:1234", "198.51.100.4"))
+	})
+}
+
+// realtimeStub не вызывается: запросы в тестах не проходят аутентификацию.
+type realtimeStub struct{}
+
+func (realtimeStub) Serve(http.ResponseWriter, *http.Request, types.UserID) error {
+	panic("unexpected call")
+}
+
+func TestServer_Realtime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	introspector := middlewaresmocks.NewMockIntrospector(ctrl)
+	introspector.EXPECT().IntrospectToken(gomock.Any(), "token").
+		Return(&keycloakclient.IntrospectTokenResult{Active: false}, nil).AnyTimes()
+
+	srv := newServer(t,
+		serverclient.WithKeycloakIntrospector(introspector),
+		serverclient.WithRealtime(realtimeStub{}),
+		serverclient.WithManagerRealtime(realtimeStub{}),
+	)
+
+	request := func(path, reqOrigin string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Protocol", "chat-service-protocol, token")
+		if reqOrigin != "" {
+			req.Header.Set("Origin", reqOrigin)
+		}
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, path := range []string{"/ws", "/manager/ws"} {
+		t.Run(path, func(t *testing.T) {
+			// Токен из подпротокола доходит до Keycloak, неактивный токен отклоняется.
+			assert.Equal(t, http.StatusUnauthorized, request(path, origin).Code)
+			assert.Equal(t, http.StatusUnauthorized, request(path, "").Code)
+			// Чужая страница не может открыть соединение.
+			assert.Equal(t, http.StatusForbidden, request(path, "http://evil.example").Code)
+		})
+	}
+
+	t.Run("allow origins are reloaded", func(t *testing.T) {
+		srv.SetAllowOrigins([]string{"http://evil.example"})
+		defer srv.SetAllowOrigins([]string{origin})
+
+		assert.Equal(t, http.StatusUnauthorized, request("/ws", "http://evil.example").Code)
+		assert.Equal(t, http.StatusForbidden, request("/ws", origin).Code)
+	})
+
+	t.Run("route override", func(t *testing.T) {
+		srv = newServer(t,
+			serverclient.WithKeycloakIntrospector(introspector),
+			serverclient.WithRealtime(realtimeStub{}),
+			serverclient.WithManagerRealtime(realtimeStub{}),
+			serverclient.WithRoutes(map[string]serverclient.RoutePolicy{
+				"ws": {SecureHeaders: &serverclient.SecureHeadersPolicy{ContentSecurityPolicy: "sandbox"}},
+			}),
+		)
+
+		assert.Equal(t, "sandbox", request("/ws", origin).Header().Get("Content-Security-Policy"))
+		assert.Equal(t, "default-src 'none'", request("/manager/ws", origin).Header().Get("Content-Security-Policy"))
+	})
+}
+
+func TestServer_Tracing(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	srv := newServer(t, serverclient.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/getHistory", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	srv.Handler().ServeHTTP(rec, req)
+
+	require.Len(t, spans.Ended(), 1)
+	span := spans.Ended()[0]
+	assert.Equal(t, "POST /v1/getHistory", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.True(t, span.SpanContext().IsSampled())
+}
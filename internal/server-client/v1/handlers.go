@@ -1,14 +1,70 @@
 package clientv1
 
 import (
+	"context"
 	"fmt"
+	"io"
 
 	"go.uber.org/zap"
+
+	messagesrepo "github.com/FischukSergey/chat-service/internal/repositories/messages"
+	problemsrepo "github.com/FischukSergey/chat-service/internal/repositories/problems"
+	"github.com/FischukSergey/chat-service/internal/services/attachments"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+	"github.com/FischukSergey/chat-service/internal/services/presence"
+	"github.com/FischukSergey/chat-service/internal/types"
 )
 
+type attachmentsService interface {
+	Upload(ctx context.Context, uploaderID types.UserID, fileName string, r io.Reader) (*attachments.Attachment, error)
+	Get(ctx context.Context, uploaderID types.UserID, id types.AttachmentID) (*attachments.Attachment, error)
+}
+
+type messagesSearcher interface {
+	SearchMessages(ctx context.Context, params messagesrepo.SearchParams) (messagesrepo.SearchResult, error)
+}
+
+type auditQuerier interface {
+	Query(ctx context.Context, f audit.Filter) (audit.Page, error)
+}
+
+type managerChatsLister interface {
+	ManagerChats(ctx context.Context, managerID types.UserID) ([]problemsrepo.ManagerChat, error)
+}
+
+type presenceChecker interface {
+	Status(userID types.UserID) (presence.Status, bool)
+}
+
+type messengerService interface {
+	SendClientMessage(
+		ctx context.Context, clientID types.UserID, body string, attachmentIDs []types.AttachmentID,
+	) (messagesrepo.Message, error)
+	SendManagerMessage(
+		ctx context.Context, managerID types.UserID, chatID types.ChatID, body string, attachmentIDs []types.AttachmentID,
+	) (messagesrepo.Message, error)
+	ClientHistory(ctx context.Context, clientID types.UserID, pageSize int, cursor string) (messagesrepo.HistoryPage, error)
+	ManagerHistory(
+		ctx context.Context, managerID types.UserID, chatID types.ChatID, pageSize int, cursor string,
+	) (messagesrepo.HistoryPage, error)
+}
+
+type typingService interface {
+	ClientTyping(ctx context.Context, clientID types.UserID) error
+}
+
 //go:generate options-gen -out-filename=handlers_options.gen.go -from-struct=Options
 type Options struct {
-	logger *zap.Logger `option:"mandatory" validate:"required"`
+	logger      *zap.Logger        `option:"mandatory" validate:"required"`
+	attachments attachmentsService `option:"mandatory" validate:"required"`
+	typing      typingService      `option:"mandatory" validate:"required"`
+	chats       managerChatsLister `option:"mandatory" validate:"required"`
+	presence    presenceChecker    `option:"mandatory" validate:"required"`
+	messenger   messengerService   `option:"mandatory" validate:"required"`
+	// search не задан, если поиск недоступен (например, при включенном шифровании сообщений).
+	search messagesSearcher `option:"optional"`
+	// audit не задан, если журнал аудита не ведется.
+	audit auditQuerier `option:"optional"`
 	// Ждут своего часа.
 }
 
@@ -0,0 +1,98 @@
+package clientv1
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	"github.com/FischukSergey/chat-service/internal/services/attachments"
+)
+
+const attachmentFormField = "file"
+
+// PostUploadAttachment читает файл из тела запроса потоком и передает его сервису вложений,
+// который ограничивает размер при чтении. Поля формы до файла пропускаются.
+func (h Handlers) PostUploadAttachment(eCtx echo.Context, _ PostUploadAttachmentParams) error {
+	userID := middlewares.MustUserID(eCtx)
+
+	mr, err := eCtx.Request().MultipartReader()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "multipart form is required")
+	}
+
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return echo.NewHTTPError(http.StatusBadRequest, "file is required")
+		}
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid multipart form")
+		}
+		if part.FormName() != attachmentFormField || part.FileName() == "" {
+			continue
+		}
+
+		a, err := h.attachments.Upload(eCtx.Request().Context(), userID, part.FileName(), part)
+		if err != nil {
+			return attachmentError(eCtx, err)
+		}
+		return eCtx.JSON(http.StatusOK, AttachmentResponse{Data: toAttachment(a)})
+	}
+}
+
+func (h Handlers) PostGetAttachment(eCtx echo.Context, _ PostGetAttachmentParams) error {
+	return h.getAttachment(eCtx)
+}
+
+// PostManagerGetAttachment отдает менеджеру вложения из сообщений чатов, где ему назначена проблема.
+func (h Handlers) PostManagerGetAttachment(eCtx echo.Context, _ PostManagerGetAttachmentParams) error {
+	return h.getAttachment(eCtx)
+}
+
+func (h Handlers) getAttachment(eCtx echo.Context) error {
+	userID := middlewares.MustUserID(eCtx)
+
+	var req GetAttachmentRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+
+	a, err := h.attachments.Get(eCtx.Request().Context(), userID, req.Id)
+	if err != nil {
+		return attachmentError(eCtx, err)
+	}
+
+	return eCtx.JSON(http.StatusOK, AttachmentResponse{Data: toAttachment(a)})
+}
+
+func attachmentError(eCtx echo.Context, err error) error {
+	switch {
+	case errors.Is(err, attachments.ErrTooLarge):
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "file is too large")
+	case errors.Is(err, attachments.ErrMIMETypeNotAllowed):
+		return echo.NewHTTPError(http.StatusUnsupportedMediaType, "file type is not allowed")
+	case errors.Is(err, attachments.ErrEmpty):
+		return echo.NewHTTPError(http.StatusBadRequest, "file is empty")
+	case errors.Is(err, attachments.ErrNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, "attachment not found")
+	}
+
+	logger.FromContext(eCtx.Request().Context()).Error("attachment request failed", zap.Error(err))
+	return echo.NewHTTPError(http.StatusInternalServerError)
+}
+
+func toAttachment(a *attachments.Attachment) Attachment {
+	return Attachment{
+		Id:           a.ID,
+		FileName:     a.FileName,
+		ContentType:  a.ContentType,
+		Size:         a.Size,
+		Url:          a.URL,
+		UrlExpiresAt: a.URLExpiresAt,
+	}
+}
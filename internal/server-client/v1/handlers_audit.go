@@ -0,0 +1,89 @@
+package clientv1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+)
+
+func (h Handlers) PostGetAuditEvents(eCtx echo.Context, _ PostGetAuditEventsParams) error {
+	if h.audit == nil {
+		return echo.NewHTTPError(http.StatusNotImplemented, "audit log is unavailable")
+	}
+
+	var req GetAuditEventsRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+
+	var filter audit.Filter
+	if req.Actor != nil {
+		filter.Actor = *req.Actor
+	}
+	if req.Action != nil {
+		filter.Action = audit.Action(*req.Action)
+	}
+	if req.Target != nil {
+		filter.Target = *req.Target
+	}
+	if req.From != nil {
+		filter.From = *req.From
+	}
+	if req.To != nil {
+		filter.To = *req.To
+	}
+	if req.PageSize != nil {
+		filter.PageSize = *req.PageSize
+	}
+	if req.Cursor != nil {
+		filter.Cursor = *req.Cursor
+	}
+
+	res, err := h.audit.Query(eCtx.Request().Context(), filter)
+	if err != nil {
+		if errors.Is(err, audit.ErrInvalidCursor) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		logger.FromContext(eCtx.Request().Context()).Error("query audit events failed", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	page := AuditEventsPage{Events: make([]AuditEvent, 0, len(res.Events))}
+	for _, e := range res.Events {
+		page.Events = append(page.Events, adaptAuditEvent(e))
+	}
+	if res.NextCursor != "" {
+		page.NextCursor = &res.NextCursor
+	}
+
+	return eCtx.JSON(http.StatusOK, GetAuditEventsResponse{Data: page})
+}
+
+func adaptAuditEvent(e audit.Event) AuditEvent {
+	res := AuditEvent{
+		Id:        e.ID,
+		Action:    AuditAction(e.Action),
+		CreatedAt: e.CreatedAt,
+	}
+	if e.Actor != "" {
+		res.Actor = &e.Actor
+	}
+	if e.Target != "" {
+		res.Target = &e.Target
+	}
+	if len(e.Metadata) > 0 {
+		res.Metadata = &e.Metadata
+	}
+	if !e.RequestID.IsZero() {
+		res.RequestId = &e.RequestID
+	}
+	if e.IP != "" {
+		res.Ip = &e.IP
+	}
+	return res
+}
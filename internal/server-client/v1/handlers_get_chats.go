@@ -0,0 +1,40 @@
+package clientv1
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+)
+
+func (h Handlers) PostManagerGetChats(eCtx echo.Context, _ PostManagerGetChatsParams) error {
+	chats, err := h.chats.ManagerChats(eCtx.Request().Context(), middlewares.MustUserID(eCtx))
+	if err != nil {
+		logger.FromContext(eCtx.Request().Context()).Error("get manager chats failed", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	list := ChatsList{Chats: make([]ManagerChat, 0, len(chats))}
+	for _, c := range chats {
+		// Присутствие клиента берется из памяти сервиса: клиент онлайн, пока пользуется API или держит WebSocket.
+		var p Presence
+		if status, ok := h.presence.Status(c.ClientID); ok {
+			p.Online = status.Online
+			lastSeen := status.LastSeen.UTC()
+			p.LastSeenAt = &lastSeen
+		}
+
+		list.Chats = append(list.Chats, ManagerChat{
+			ChatId:         c.ChatID,
+			ClientId:       c.ClientID,
+			ClientPresence: p,
+			ProblemId:      c.ProblemID,
+			ProblemStatus:  ManagerChatProblemStatus(c.Status),
+		})
+	}
+
+	return eCtx.JSON(http.StatusOK, GetChatsResponse{Data: list})
+}
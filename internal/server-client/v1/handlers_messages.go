@@ -0,0 +1,121 @@
+package clientv1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	messagesrepo "github.com/FischukSergey/chat-service/internal/repositories/messages"
+	"github.com/FischukSergey/chat-service/internal/services/messenger"
+	"github.com/FischukSergey/chat-service/internal/services/normalizer"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func (h Handlers) PostSendMessage(eCtx echo.Context, _ PostSendMessageParams) error {
+	var req SendMessageRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+
+	msg, err := h.messenger.SendClientMessage(eCtx.Request().Context(), middlewares.MustUserID(eCtx),
+		req.Body, deref(req.AttachmentIds))
+	if err != nil {
+		return messengerError(eCtx, "send message failed", err)
+	}
+	return eCtx.JSON(http.StatusOK, SendMessageResponse{Data: apiMessage(msg)})
+}
+
+func (h Handlers) PostManagerSendMessage(eCtx echo.Context, _ PostManagerSendMessageParams) error {
+	var req ManagerSendMessageRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+
+	msg, err := h.messenger.SendManagerMessage(eCtx.Request().Context(), middlewares.MustUserID(eCtx),
+		req.ChatId, req.Body, deref(req.AttachmentIds))
+	if err != nil {
+		return messengerError(eCtx, "send manager message failed", err)
+	}
+	return eCtx.JSON(http.StatusOK, SendMessageResponse{Data: apiMessage(msg)})
+}
+
+func (h Handlers) PostGetHistory(eCtx echo.Context, _ PostGetHistoryParams) error {
+	var req GetHistoryRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+
+	page, err := h.messenger.ClientHistory(eCtx.Request().Context(), middlewares.MustUserID(eCtx),
+		deref(req.PageSize), deref(req.Cursor))
+	if err != nil {
+		return messengerError(eCtx, "get history failed", err)
+	}
+	return eCtx.JSON(http.StatusOK, GetHistoryResponse{Data: apiMessagesPage(page)})
+}
+
+func (h Handlers) PostManagerGetChatHistory(eCtx echo.Context, _ PostManagerGetChatHistoryParams) error {
+	var req ManagerGetChatHistoryRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+
+	page, err := h.messenger.ManagerHistory(eCtx.Request().Context(), middlewares.MustUserID(eCtx),
+		req.ChatId, deref(req.PageSize), deref(req.Cursor))
+	if err != nil {
+		return messengerError(eCtx, "get chat history failed", err)
+	}
+	return eCtx.JSON(http.StatusOK, GetHistoryResponse{Data: apiMessagesPage(page)})
+}
+
+// messengerError переводит ошибку отправки или чтения сообщений в ответ API.
+func messengerError(eCtx echo.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, normalizer.ErrEmptyBody),
+		errors.Is(err, normalizer.ErrTooLong),
+		errors.Is(err, normalizer.ErrMarkupOnly),
+		errors.Is(err, messagesrepo.ErrInvalidCursor),
+		errors.Is(err, messenger.ErrAttachmentNotFound):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, messenger.ErrNotAssigned):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case errors.Is(err, messenger.ErrChatNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	logger.FromContext(eCtx.Request().Context()).Error(msg, zap.Error(err))
+	return echo.NewHTTPError(http.StatusInternalServerError)
+}
+
+func apiMessage(m messagesrepo.Message) Message {
+	return Message{
+		Id:            m.ID,
+		AuthorId:      m.AuthorID,
+		Body:          m.Body,
+		IsRedacted:    m.IsRedacted,
+		AttachmentIds: append([]types.AttachmentID{}, m.AttachmentIDs...),
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+func apiMessagesPage(page messagesrepo.HistoryPage) MessagesPage {
+	res := MessagesPage{Messages: make([]Message, 0, len(page.Messages))}
+	for _, m := range page.Messages {
+		res.Messages = append(res.Messages, apiMessage(m))
+	}
+	if page.NextCursor != "" {
+		res.NextCursor = &page.NextCursor
+	}
+	return res
+}
+
+func deref[T any](v *T) T {
+	if v == nil {
+		var zero T
+		return zero
+	}
+	return *v
+}
@@ -13,6 +13,11 @@ type OptOptionsSetter func(o *Options)
 
 func NewOptions(
 	logger *zap.Logger,
+	attachments attachmentsService,
+	typing typingService,
+	chats managerChatsLister,
+	presence presenceChecker,
+	messenger messengerService,
 	options ...OptOptionsSetter,
 ) Options {
 	o := Options{}
@@ -21,15 +26,46 @@ func NewOptions(
 
 	o.logger = logger
 
+	o.attachments = attachments
+
+	o.typing = typing
+
+	o.chats = chats
+
+	o.presence = presence
+
+	o.messenger = messenger
+
 	for _, opt := range options {
 		opt(&o)
 	}
 	return o
 }
 
+// search не задан, если поиск недоступен (например, при включенном шифровании сообщений).
+func WithSearch(opt messagesSearcher) OptOptionsSetter {
+	return func(o *Options) {
+		o.search = opt
+
+	}
+}
+
+// audit не задан, если журнал аудита не ведется.
+func WithAudit(opt auditQuerier) OptOptionsSetter {
+	return func(o *Options) {
+		o.audit = opt
+
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("logger", _validate_Options_logger(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("attachments", _validate_Options_attachments(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("typing", _validate_Options_typing(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("chats", _validate_Options_chats(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("presence", _validate_Options_presence(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("messenger", _validate_Options_messenger(o)))
 	return errs.AsError()
 }
 
@@ -39,3 +75,38 @@ func _validate_Options_logger(o *Options) error {
 	}
 	return nil
 }
+
+func _validate_Options_attachments(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.attachments, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `attachments` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_typing(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.typing, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `typing` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_chats(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.chats, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `chats` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_presence(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.presence, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `presence` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_messenger(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.messenger, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `messenger` did not pass the test: %w", err)
+	}
+	return nil
+}
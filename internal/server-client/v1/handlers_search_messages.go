@@ -0,0 +1,75 @@
+package clientv1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/middlewares"
+	messagesrepo "github.com/FischukSergey/chat-service/internal/repositories/messages"
+)
+
+func (h Handlers) PostSearchMessages(eCtx echo.Context, _ PostSearchMessagesParams) error {
+	// Клиент ищет только по своему чату и только среди видимых ему сообщений.
+	return h.searchMessages(eCtx, messagesrepo.SearchParams{
+		Visibility: messagesrepo.VisibleForClient,
+		ClientID:   middlewares.MustUserID(eCtx),
+	})
+}
+
+func (h Handlers) PostManagerSearchMessages(eCtx echo.Context, _ PostManagerSearchMessagesParams) error {
+	// Менеджер ищет по чатам, где ему назначалась проблема, включая сообщения, скрытые от клиентов.
+	// В отличие от истории чата поиск охватывает и решенные проблемы: менеджер находит свои прошлые
+	// разговоры с клиентом, но не видит переписку других менеджеров.
+	return h.searchMessages(eCtx, messagesrepo.SearchParams{
+		Visibility: messagesrepo.VisibleForManager,
+		ManagerID:  middlewares.MustUserID(eCtx),
+	})
+}
+
+func (h Handlers) searchMessages(eCtx echo.Context, searchParams messagesrepo.SearchParams) error {
+	if h.search == nil {
+		return echo.NewHTTPError(http.StatusNotImplemented, "search is unavailable while messages encryption is enabled")
+	}
+
+	var req SearchMessagesRequest
+	if err := eCtx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
+	}
+
+	searchParams.Query = req.Query
+	if req.PageSize != nil {
+		searchParams.PageSize = *req.PageSize
+	}
+	if req.Cursor != nil {
+		searchParams.Cursor = *req.Cursor
+	}
+
+	res, err := h.search.SearchMessages(eCtx.Request().Context(), searchParams)
+	if err != nil {
+		if errors.Is(err, messagesrepo.ErrEmptyQuery) || errors.Is(err, messagesrepo.ErrInvalidCursor) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		logger.FromContext(eCtx.Request().Context()).Error("search messages failed", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	page := FoundMessagesPage{Messages: make([]FoundMessage, 0, len(res.Messages))}
+	for _, m := range res.Messages {
+		page.Messages = append(page.Messages, FoundMessage{
+			Id:        m.ID,
+			ChatId:    m.ChatID,
+			AuthorId:  m.AuthorID,
+			CreatedAt: m.CreatedAt,
+			Snippet:   m.Snippet,
+		})
+	}
+	if res.NextCursor != "" {
+		page.NextCursor = &res.NextCursor
+	}
+
+	return eCtx.JSON(http.StatusOK, SearchMessagesResponse{Data: page})
+}
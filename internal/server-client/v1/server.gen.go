@@ -25,6 +25,130 @@ const (
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for AuditAction.
+const (
+	AuthFailed      AuditAction = "auth_failed"
+	ConfigChanged   AuditAction = "config_changed"
+	MessageDeleted  AuditAction = "message_deleted"
+	MessageEdited   AuditAction = "message_edited"
+	ProblemAssigned AuditAction = "problem_assigned"
+	ProblemClosed   AuditAction = "problem_closed"
+)
+
+// Defines values for ManagerChatProblemStatus.
+const (
+	InProgress ManagerChatProblemStatus = "in_progress"
+	Open       ManagerChatProblemStatus = "open"
+)
+
+// Attachment defines model for Attachment.
+type Attachment struct {
+	ContentType string             `json:"contentType"`
+	FileName    string             `json:"fileName"`
+	Id          types.AttachmentID `json:"id"`
+	Size        int64              `json:"size"`
+
+	// Url Временная ссылка на скачивание файла.
+	Url          string    `json:"url"`
+	UrlExpiresAt time.Time `json:"urlExpiresAt"`
+}
+
+// AttachmentResponse defines model for AttachmentResponse.
+type AttachmentResponse struct {
+	Data Attachment `json:"data"`
+}
+
+// AuditAction defines model for AuditAction.
+type AuditAction string
+
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	Action    AuditAction             `json:"action"`
+	Actor     *string                 `json:"actor,omitempty"`
+	CreatedAt time.Time               `json:"createdAt"`
+	Id        types.AuditEventID      `json:"id"`
+	Ip        *string                 `json:"ip,omitempty"`
+	Metadata  *map[string]interface{} `json:"metadata,omitempty"`
+	RequestId *types.RequestID        `json:"requestId,omitempty"`
+	Target    *string                 `json:"target,omitempty"`
+}
+
+// AuditEventsPage defines model for AuditEventsPage.
+type AuditEventsPage struct {
+	Events []AuditEvent `json:"events"`
+
+	// NextCursor Курсор для следующей страницы. Если нет следующей страницы, то не возвращается.
+	NextCursor *string `json:"nextCursor"`
+}
+
+// ChatsList defines model for ChatsList.
+type ChatsList struct {
+	Chats []ManagerChat `json:"chats"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error Error `json:"error"`
+}
+
+// FoundMessage defines model for FoundMessage.
+type FoundMessage struct {
+	AuthorId  types.UserID    `json:"authorId"`
+	ChatId    types.ChatID    `json:"chatId"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Id        types.MessageID `json:"id"`
+
+	// Snippet Фрагмент сообщения в HTML: текст экранирован,
+	// найденные слова обернуты в <mark>.
+	Snippet string `json:"snippet"`
+}
+
+// FoundMessagesPage defines model for FoundMessagesPage.
+type FoundMessagesPage struct {
+	Messages []FoundMessage `json:"messages"`
+
+	// NextCursor Курсор для следующей страницы. Если нет следующей страницы, то не возвращается.
+	NextCursor *string `json:"nextCursor"`
+}
+
+// GetAttachmentRequest defines model for GetAttachmentRequest.
+type GetAttachmentRequest struct {
+	Id types.AttachmentID `json:"id"`
+}
+
+// GetAuditEventsRequest defines model for GetAuditEventsRequest.
+type GetAuditEventsRequest struct {
+	Action *AuditAction `json:"action,omitempty"`
+	Actor  *string      `json:"actor"`
+
+	// Cursor Курсор для пагинации
+	Cursor *string `json:"cursor"`
+
+	// From Начало периода включительно.
+	From     *time.Time `json:"from"`
+	PageSize *int       `json:"pageSize"`
+	Target   *string    `json:"target"`
+
+	// To Конец периода не включительно.
+	To *time.Time `json:"to"`
+}
+
+// GetAuditEventsResponse defines model for GetAuditEventsResponse.
+type GetAuditEventsResponse struct {
+	Data AuditEventsPage `json:"data"`
+}
+
+// GetChatsResponse defines model for GetChatsResponse.
+type GetChatsResponse struct {
+	Data ChatsList `json:"data"`
+}
+
 // GetHistoryRequest defines model for GetHistoryRequest.
 type GetHistoryRequest struct {
 	// Cursor Курсор для пагинации
@@ -37,14 +161,59 @@ type GetHistoryResponse struct {
 	Data MessagesPage `json:"data"`
 }
 
+// ManagerChat defines model for ManagerChat.
+type ManagerChat struct {
+	ChatId         types.ChatID             `json:"chatId"`
+	ClientId       types.UserID             `json:"clientId"`
+	ClientPresence Presence                 `json:"clientPresence"`
+	ProblemId      types.ProblemID          `json:"problemId"`
+	ProblemStatus  ManagerChatProblemStatus `json:"problemStatus"`
+}
+
+// ManagerChatProblemStatus defines model for ManagerChat.ProblemStatus.
+type ManagerChatProblemStatus string
+
+// ManagerGetChatHistoryRequest defines model for ManagerGetChatHistoryRequest.
+type ManagerGetChatHistoryRequest struct {
+	ChatId types.ChatID `json:"chatId"`
+
+	// Cursor Курсор для пагинации
+	Cursor   *string `json:"cursor"`
+	PageSize *int    `json:"pageSize"`
+}
+
+// ManagerSendMessageRequest defines model for ManagerSendMessageRequest.
+type ManagerSendMessageRequest struct {
+	// AttachmentIds Загруженные отправителем и еще не отправленные вложения.
+	AttachmentIds *[]types.AttachmentID `json:"attachmentIds,omitempty"`
+
+	// Body Текст сообщения. Сервер приводит его к форме NFC и удаляет управляющие символы
+	// и символы нулевой ширины. Максимальная длина задается конфигурацией сервиса.
+	Body   MessageBody  `json:"body"`
+	ChatId types.ChatID `json:"chatId"`
+}
+
 // Message defines model for Message.
 type Message struct {
-	AuthorId  types.UserID    `json:"authorId"`
-	Body      string          `json:"body"`
+	// AttachmentIds Вложения сообщения. Доступны через /getAttachment обоим участникам чата.
+	AttachmentIds []types.AttachmentID `json:"attachmentIds"`
+	AuthorId      types.UserID         `json:"authorId"`
+
+	// Body Текст сообщения. Сервер приводит его к форме NFC и удаляет управляющие символы
+	// и символы нулевой ширины. Максимальная длина задается конфигурацией сервиса.
+	Body      MessageBody     `json:"body"`
 	CreatedAt time.Time       `json:"createdAt"`
 	Id        types.MessageID `json:"id"`
+
+	// IsRedacted В тексте найдены и замаскированы чувствительные данные (номера карт, CVV, телефоны).
+	// Менеджер видит пометку "чувствительные данные удалены".
+	IsRedacted bool `json:"isRedacted"`
 }
 
+// MessageBody Текст сообщения. Сервер приводит его к форме NFC и удаляет управляющие символы
+// и символы нулевой ширины. Максимальная длина задается конфигурацией сервиса.
+type MessageBody = string
+
 // MessagesPage defines model for MessagesPage.
 type MessagesPage struct {
 	Messages []Message `json:"messages"`
@@ -55,23 +224,195 @@ type MessagesPage struct {
 	NextCursor *string `json:"nextCursor"`
 }
 
+// Presence defines model for Presence.
+type Presence struct {
+	// LastSeenAt Время последней активности. Не возвращается, если активность неизвестна.
+	LastSeenAt *time.Time `json:"lastSeenAt"`
+	Online     bool       `json:"online"`
+}
+
+// SearchMessagesRequest defines model for SearchMessagesRequest.
+type SearchMessagesRequest struct {
+	// Cursor Курсор для пагинации
+	Cursor   *string `json:"cursor"`
+	PageSize *int    `json:"pageSize"`
+	Query    string  `json:"query"`
+}
+
+// SearchMessagesResponse defines model for SearchMessagesResponse.
+type SearchMessagesResponse struct {
+	Data FoundMessagesPage `json:"data"`
+}
+
+// SendMessageRequest defines model for SendMessageRequest.
+type SendMessageRequest struct {
+	// AttachmentIds Загруженные отправителем и еще не отправленные вложения.
+	AttachmentIds *[]types.AttachmentID `json:"attachmentIds,omitempty"`
+
+	// Body Текст сообщения. Сервер приводит его к форме NFC и удаляет управляющие символы
+	// и символы нулевой ширины. Максимальная длина задается конфигурацией сервиса.
+	Body MessageBody `json:"body"`
+}
+
+// SendMessageResponse defines model for SendMessageResponse.
+type SendMessageResponse struct {
+	Data Message `json:"data"`
+}
+
+// UploadAttachmentRequest defines model for UploadAttachmentRequest.
+type UploadAttachmentRequest struct {
+	File openapi_types.File `json:"file"`
+}
+
 // XRequestIDHeader defines model for XRequestIDHeader.
 type XRequestIDHeader = openapi_types.UUID
 
+// ChatNotFound defines model for ChatNotFound.
+type ChatNotFound = ErrorResponse
+
+// InvalidMessage defines model for InvalidMessage.
+type InvalidMessage = ErrorResponse
+
+// NotAssigned defines model for NotAssigned.
+type NotAssigned = ErrorResponse
+
+// SearchUnavailable defines model for SearchUnavailable.
+type SearchUnavailable = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
+// PostGetAttachmentParams defines parameters for PostGetAttachment.
+type PostGetAttachmentParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
+// PostGetAuditEventsParams defines parameters for PostGetAuditEvents.
+type PostGetAuditEventsParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
 // PostGetHistoryParams defines parameters for PostGetHistory.
 type PostGetHistoryParams struct {
 	// XRequestID Unique request identifier
 	XRequestID XRequestIDHeader `json:"X-Request-ID"`
 }
 
+// PostManagerGetAttachmentParams defines parameters for PostManagerGetAttachment.
+type PostManagerGetAttachmentParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
+// PostManagerGetChatHistoryParams defines parameters for PostManagerGetChatHistory.
+type PostManagerGetChatHistoryParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
+// PostManagerGetChatsParams defines parameters for PostManagerGetChats.
+type PostManagerGetChatsParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
+// PostManagerSearchMessagesParams defines parameters for PostManagerSearchMessages.
+type PostManagerSearchMessagesParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
+// PostManagerSendMessageParams defines parameters for PostManagerSendMessage.
+type PostManagerSendMessageParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
+// PostSearchMessagesParams defines parameters for PostSearchMessages.
+type PostSearchMessagesParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
+// PostSendMessageParams defines parameters for PostSendMessage.
+type PostSendMessageParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
+// PostUploadAttachmentParams defines parameters for PostUploadAttachment.
+type PostUploadAttachmentParams struct {
+	// XRequestID Unique request identifier
+	XRequestID XRequestIDHeader `json:"X-Request-ID"`
+}
+
+// PostGetAttachmentJSONRequestBody defines body for PostGetAttachment for application/json ContentType.
+type PostGetAttachmentJSONRequestBody = GetAttachmentRequest
+
+// PostGetAuditEventsJSONRequestBody defines body for PostGetAuditEvents for application/json ContentType.
+type PostGetAuditEventsJSONRequestBody = GetAuditEventsRequest
+
 // PostGetHistoryJSONRequestBody defines body for PostGetHistory for application/json ContentType.
 type PostGetHistoryJSONRequestBody = GetHistoryRequest
 
+// PostManagerGetAttachmentJSONRequestBody defines body for PostManagerGetAttachment for application/json ContentType.
+type PostManagerGetAttachmentJSONRequestBody = GetAttachmentRequest
+
+// PostManagerGetChatHistoryJSONRequestBody defines body for PostManagerGetChatHistory for application/json ContentType.
+type PostManagerGetChatHistoryJSONRequestBody = ManagerGetChatHistoryRequest
+
+// PostManagerSearchMessagesJSONRequestBody defines body for PostManagerSearchMessages for application/json ContentType.
+type PostManagerSearchMessagesJSONRequestBody = SearchMessagesRequest
+
+// PostManagerSendMessageJSONRequestBody defines body for PostManagerSendMessage for application/json ContentType.
+type PostManagerSendMessageJSONRequestBody = ManagerSendMessageRequest
+
+// PostSearchMessagesJSONRequestBody defines body for PostSearchMessages for application/json ContentType.
+type PostSearchMessagesJSONRequestBody = SearchMessagesRequest
+
+// PostSendMessageJSONRequestBody defines body for PostSendMessage for application/json ContentType.
+type PostSendMessageJSONRequestBody = SendMessageRequest
+
+// PostUploadAttachmentMultipartRequestBody defines body for PostUploadAttachment for multipart/form-data ContentType.
+type PostUploadAttachmentMultipartRequestBody = UploadAttachmentRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
+	// (POST /v1/getAttachment)
+	PostGetAttachment(ctx echo.Context, params PostGetAttachmentParams) error
+
+	// (POST /v1/getAuditEvents)
+	PostGetAuditEvents(ctx echo.Context, params PostGetAuditEventsParams) error
+
 	// (POST /v1/getHistory)
 	PostGetHistory(ctx echo.Context, params PostGetHistoryParams) error
+
+	// (POST /v1/manager/getAttachment)
+	PostManagerGetAttachment(ctx echo.Context, params PostManagerGetAttachmentParams) error
+
+	// (POST /v1/manager/getChatHistory)
+	PostManagerGetChatHistory(ctx echo.Context, params PostManagerGetChatHistoryParams) error
+
+	// (POST /v1/manager/getChats)
+	PostManagerGetChats(ctx echo.Context, params PostManagerGetChatsParams) error
+
+	// (POST /v1/manager/searchMessages)
+	PostManagerSearchMessages(ctx echo.Context, params PostManagerSearchMessagesParams) error
+
+	// (POST /v1/manager/sendMessage)
+	PostManagerSendMessage(ctx echo.Context, params PostManagerSendMessageParams) error
+
+	// (POST /v1/searchMessages)
+	PostSearchMessages(ctx echo.Context, params PostSearchMessagesParams) error
+
+	// (POST /v1/sendMessage)
+	PostSendMessage(ctx echo.Context, params PostSendMessageParams) error
+
+	// (POST /v1/uploadAttachment)
+	PostUploadAttachment(ctx echo.Context, params PostUploadAttachmentParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -79,6 +420,72 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// PostGetAttachment converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGetAttachment(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostGetAttachmentParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostGetAttachment(ctx, params)
+	return err
+}
+
+// PostGetAuditEvents converts echo context to params.
+func (w *ServerInterfaceWrapper) PostGetAuditEvents(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostGetAuditEventsParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostGetAuditEvents(ctx, params)
+	return err
+}
+
 // PostGetHistory converts echo context to params.
 func (w *ServerInterfaceWrapper) PostGetHistory(ctx echo.Context) error {
 	var err error
@@ -112,6 +519,270 @@ func (w *ServerInterfaceWrapper) PostGetHistory(ctx echo.Context) error {
 	return err
 }
 
+// PostManagerGetAttachment converts echo context to params.
+func (w *ServerInterfaceWrapper) PostManagerGetAttachment(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostManagerGetAttachmentParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostManagerGetAttachment(ctx, params)
+	return err
+}
+
+// PostManagerGetChatHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) PostManagerGetChatHistory(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostManagerGetChatHistoryParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostManagerGetChatHistory(ctx, params)
+	return err
+}
+
+// PostManagerGetChats converts echo context to params.
+func (w *ServerInterfaceWrapper) PostManagerGetChats(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostManagerGetChatsParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostManagerGetChats(ctx, params)
+	return err
+}
+
+// PostManagerSearchMessages converts echo context to params.
+func (w *ServerInterfaceWrapper) PostManagerSearchMessages(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostManagerSearchMessagesParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostManagerSearchMessages(ctx, params)
+	return err
+}
+
+// PostManagerSendMessage converts echo context to params.
+func (w *ServerInterfaceWrapper) PostManagerSendMessage(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostManagerSendMessageParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostManagerSendMessage(ctx, params)
+	return err
+}
+
+// PostSearchMessages converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSearchMessages(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostSearchMessagesParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSearchMessages(ctx, params)
+	return err
+}
+
+// PostSendMessage converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSendMessage(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostSendMessageParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSendMessage(ctx, params)
+	return err
+}
+
+// PostUploadAttachment converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUploadAttachment(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUploadAttachmentParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Request-ID")]; found {
+		var XRequestID XRequestIDHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Request-ID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Request-ID", valueList[0], &XRequestID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Request-ID: %s", err))
+		}
+
+		params.XRequestID = XRequestID
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Request-ID is required, but not found"))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUploadAttachment(ctx, params)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -140,32 +811,85 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.POST(baseURL+"/v1/getAttachment", wrapper.PostGetAttachment)
+	router.POST(baseURL+"/v1/getAuditEvents", wrapper.PostGetAuditEvents)
 	router.POST(baseURL+"/v1/getHistory", wrapper.PostGetHistory)
+	router.POST(baseURL+"/v1/manager/getAttachment", wrapper.PostManagerGetAttachment)
+	router.POST(baseURL+"/v1/manager/getChatHistory", wrapper.PostManagerGetChatHistory)
+	router.POST(baseURL+"/v1/manager/getChats", wrapper.PostManagerGetChats)
+	router.POST(baseURL+"/v1/manager/searchMessages", wrapper.PostManagerSearchMessages)
+	router.POST(baseURL+"/v1/manager/sendMessage", wrapper.PostManagerSendMessage)
+	router.POST(baseURL+"/v1/searchMessages", wrapper.PostSearchMessages)
+	router.POST(baseURL+"/v1/sendMessage", wrapper.PostSendMessage)
+	router.POST(baseURL+"/v1/uploadAttachment", wrapper.PostUploadAttachment)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RWy24bNxd+FYL/vxzNKM0mmF0uSOKiRY04RgNYXtCjY4nJ3EJyDKuCAEsKEgRuHaBA",
-	"N930FVTFgpXYll/h8I2KwxlZsuVcWiAricPDc/m+j+ewy6MsybMUUqN52OW5UCIBA8qtnj2BlwVos/bg",
-	"MYgmKPrWBB0pmRuZpTzkm6l8WQBTpR2TTUiN3JWguMclGbTLgx5PRQI85M9qlc/a2gPucTooFTR5aFQB",
-	"HtdRGxJBcXYzlQjDQ14Ussk9bjo5nddGybTFe73e3Nhl+gjMY6lNpjqVe1eMynJQRoIziQqlsxtKwD/t",
-	"0B7YPs7sAcNjPLXvGF7gCN/jFM9xZF/jFKdUQRHHYieGea7XMvJ4LlqwIX+BMsauKGLDw1t1jydiXyZF",
-	"QgtaybRafdKnTA20QHGqsvqU7TyHyFCY5Vp1nqUaVottCuNQ/L+CXR7y/wULmoMKtuBH0Fq0QK+LFrhI",
-	"CzK2SgfbN0SvTq2GFIVpZ2qt+WXyPL5fa2W16iP9aH9Tg3KKuNyqySTPVEmkMG0e8pY07WLHj7IkeCh1",
-	"1C5ebIBqQSeI2sLUNKg9GUFA2KlUxIFz7ArbyZod8rPCWKRAGGjeNVeSbgoDNSMT4DeQLP9jgRVu36jG",
-	"a+y5nC4JqQBYLvczzJZ6WKE3qXbpvzSQ6K+UF19IWCglOrROYd/c/xfX0fbxFCd4bIf2yL7FCX5gtm8H",
-	"9gBHeI5T+9oe+o0U/3B2U4bnOLGDrznlMTvAmTvAcIwzPMEx7du3OCIftm/f3eAZPy5yxNHcySfOM7yw",
-	"QwqMI1eKy2CGH3HkN9Ivt5VrzF7SsMogdUSICiVNZ4M4KLnaAaFA3S1IXfPVw7l6v//5Ka/6KHkqdxdy",
-	"bhuTl+KS6W62ytXd9bUliib2AMc4tX0cMfsGR4QKjn3WSPF32mZ4giO8oOpt3x4y9zMkZhw6Y/Jjj+ag",
-	"nRF+OMNjnOEZW/9p46m/cDRz9gTwIcOpsz0ixl85Rs4oNus2XBNr8JB1fd/v9ciSSOw2OCiVqcWO30gb",
-	"Kf5lDypfb0qF4CRkVYkzx9sQL/AcZ1QdFTvBE4Z/4whPqFB7iB/Y5pMfQkawhUEQZ5GI25k24Z36nfoi",
-	"eZLDgMQ0JtQucEITxr7CKWminDbMDmnhADsvoRpQZNvHqR3gBE/tr2UmvzmQ3uOMQpeCMtKQmvg9kb5g",
-	"G0VOHYbdbwvD7scSUkM1cY/vgdIljXu36FJmOaQilzzkt/26f5t7riU5DQV7t4LW5dRxvSErR+xVPTwC",
-	"w6hPsXZp6XPnVgnap8nA1zNtFvPLxVg8OLZubigLk2DlQdLbLq8HaHOvavJRlhpIXXYiz2MZuejBc00p",
-	"dpdeGJ9rXqvviWs9lu6q+1BOYAfTd/X6N0mgGvIug6uAz1s2i6U2PlksdwGH6PL939omvGiIzPG+6u4B",
-	"7EGc5QmJpLTiHi9UXLWCFU3z3nbvnwAAAP//KO71lEIKAAA=",
+	"H4sIAAAAAAAC/+xce28bt5b/KsTsAtsAY8lumou7+s83r+YiSY3YuS1QBQE9Q0tsRuSU5Dh2DQGOXaRb",
+	"uG2AYhcLLFosttgPoDpWo9ix8hU43+jikPOSNLIUv6C0/cueGb7OOT+eJ6ktx+OtkDPClHRqW06IBW4R",
+	"RYR5+uwB+TIiUt258THBPhHwzifSEzRUlDOn5jxk9MuIIGHbIeoTpugaJcJxHQoNmraj6zDcIk7N+Wwu",
+	"GXPuzg3HdaAjFcR3akpExHWk1yQtDPOscdHCyqk5UUR9x3XUZgj9pRKUNZx2uw2dZciZJGat15tY3efq",
+	"Fo+YD88eZ4owBf/iMAyoh2HJ1S8krHurMNG/CrLm1Jx/qeacqNqvsnpTCC4eJNPYSQfph1kR4wqtwbwV",
+	"p+06d9g6Dqh/j0iJG+TylpJMiFa5v4moRKQVqk2E1xQRiAEzA/qVmdiFr4pzFHDWQFwgWB+mTCLOgk3U",
+	"wuJJFLp1xgXCDGGlsNdsEaaQhxmQukpQQNkT4iPFkWoS1LIzV+oM6L/P1aKUtMHIJcphBZaBGW4QAdTB",
+	"MnGyCCCCh4QBpZTNhYI3BJEShYKvBqSF+JohwmtiZeS3TLDwmg8ZXsc0wKvBJYrQTg0E+FTC1D5aJR6O",
+	"ZMZjkC4lEmFBEGGe2AwVUKiQgO33gSRinXpEVpJvlLMKYWakK7U6AzrXoiCYU2RDIcp8slEQ6mpEA4X4",
+	"OhHIo2GTCGiVCHWF83uYbSZ7V14eS5IZkcAKYNeiCpENjxCf+BXHTdSLWc8DosTm3CLgfVRPLROPM1+i",
+	"iCkaGHkzYEGmtyTCQcCf2kHzpSdKhzJFGkTA+trt9LuZdDHbHfAUCh4SoSgpMmjFjLE1rMBcZ40G5D5u",
+	"lX+k/mQl6Dobcw0+l7yEP7KSL+jOjWKDOdoKubCrxKrp1JwGVc1oteLxVvUWlV4zerJMRINsVmEnzCVI",
+	"qgLpguGgaoZ3DPn0KzKwOMrUXz7KV5dxy3UiEYzKQv8Yb+uufqO7+lgf6078AsXP4mfxnj7Sh7qD4B2K",
+	"n8H/8Te6p/d1Rx/rnu6i+Gvd0a/1ke5URi2Cme3mRkgFkYtqYIE+VmRO0RYZ7dUu2qDPHcPlTDDugAwT",
+	"yi1VQ7M9ygbmq18QT8FyclFkAB/BiI/VxD2SjzOyXNO9dO7Ip2rRsxzfcgiLWtAeR6r5eA3TgACdiU55",
+	"THyqBl74JCD2TaIkH6fKtPDKC7g0LzzO1mjjsdfErEF859EIj5Pl3Fwv3SY4W+WJTCgQ1HahExelG8cT",
+	"BCviT4+AM+y1jKoL22s0LCWyRRROoYN9nwJbcLBU4Kt1p0ZwkSi8O6ekOPMHL4hchUWDqBKSy7ZpApyi",
+	"yMduBSMluZQ4ZIMAJOup90sVacmpkGjGc9rZdFgIvAnPYFauR0LyEiOk/yfejbfjZ7ofbyN9oI+M5tNH",
+	"uqsP4t34h/hb3dWvQRnuxNtW6cXP470K0v9lmvVANXbjnWk6uSje0X3TAel93dev9D58j7/VHRgjfha/",
+	"ACXKoiBxcgYQM4bvCavK2AyesLxLZZkhhE9T8/eedeNgvFEGD63Hjly2HONYlNlkn5QZ9kz3TcaeGSJv",
+	"P3by8UqfpGub6BmNCsC8LZvTBD6FoGNIyUaqycVpt/1DScSF7Xn4ftqFAUgubmGXZ0kSuV0YKZLRMCSq",
+	"RCX9v1EaL603ZlVLX/f1r0atgC55gfQ++njl3t0aaJSuPgRNg+Lv9WGmbrZ13zppbp2B86Zf6wPr3MV7",
+	"umu1lWmBYGTdjbf1cbwb78R7MHY9mp+/6kHUaf4zYeRUbloCHDcHd1FmOdGTdssYs5Bs8OkVV3HMP55p",
+	"yNhVxu7bRBW9YeNEjHJ8ZmOeUfSNIzL3NcZSeTZ/d4JQXMd7B4Tpt2bv90wU9lz3dG+y2F1nTfBWyfg/",
+	"m3CtA3sdBoZd3tN9fQDbfl8f6qP4BwjnjBI5ir/Tx7oPKCtVrBPXEOIGWU7iUJ+s4ShQTu3avOu08AZt",
+	"QbBzbR6eKLNPC2PHLLgAufc5cX7Fyzis+2YXPh+hP9lq58qE9hQQPFPYOeQ3Tx173ibKOINnmz73J99l",
+	"4o+pVFxsjt18F787SpG5UETmwrsisz2B1rOwecAGTs3poo9e6u7PpkMXUMJOvbSLdYLN0pYEkYR5ZJLQ",
+	"snbtLB1zWqqWku4XRViyvGWFVSSLqShIyZsi0eM0I1+SOSoJ96ybl0qySP/wZCNcPQHJic6aqD5mFtrv",
+	"qV4rke4JUlommYM93r3KnUFfljDkv4H4eDve1b8VYhTdj3f0W+Ml76fmGXLUCDzrLjjSiREvtjsqDrBv",
+	"gpzf0qgJrHoWNcxkGr+FN+7YBYIUh4MVqCJOaTv+Bk1nN5Afp0MMhaVgG5tEmQCtHwchUBJLV5D+T903",
+	"AdmufgvQQfE3xlXs6leo2iiGSTZY7uuefoPiXXCtoR+MA5UReAmvdnRn9qE2DK4ZzkadBve/nzwRlQ+I",
+	"jz1F/DJ4FxJAVh9mmR7I5PSQfgW4BJzqw2JayKA83tX7pud+MfyxuvMAWiUPH0BMBOkoULLIFAG34x0X",
+	"Xf/HP1yUKub4awi14r0rlTrTP5nd1dUHsPXAzO3rnj6AWcDU2bF29GG8i+rO9OuId83jkSWv7gykpVY5",
+	"DwhmY+oSeTrKoGmAre6QFplUvShibVQkv+QJuTJd83+Gi/uWK29NQLqv+ylvuvolhOqHUFbtx9vAJ3T/",
+	"1nWQZEp8/MImlXZzmxe/MImlnk3s9fQbM+RRvFdnujf0CkGez7AQXrxG8X8YWPSAoxWkf9IdfZj06CTh",
+	"MFSDwU+x7omF1EGelwJAgOS/1j39ElybxIWxea6E3F78THesvFp44y5hDdgVV+cT/yR9sVCyMc85JXjJ",
+	"2cA6u6h04OjI+jBfo+6kg4zpD+jbNbsuqfbvGO1wmIrpHFONxRhqUHoBlmqZELaoxp9JsO5xP2Wb0Suv",
+	"kcHpjtk9x9Z8614F6Z9P4JgLbmPCsJHe8XeGi7oHPXU3seud06fDOAsoK9auximopGEZ4+zho3QD/L4y",
+	"KK7zZUSE0aAFjfDhtb9MUAhD3LODTMO8s6RkRmsTU+dl/gyQZjdAGpLh2PBjQIbnkNqbHj0Pw4Bjf4pC",
+	"DRyQGhDbKmVYbE4s25l+ozO3XUcSLxJUbS7D2u0kqwQLIhYj1cyfbqUT/v3TlfSgnlF35ms+f1Op0IZ+",
+	"lK2VpOoXl+4U7GrBa0iDqr7er6A60z/CZ+uFAKhBe+8h82c3/jbR3KlTlFg6626Cl9XXb9DSJ8srlXyg",
+	"vmnftbXPnmn7Q7yTe2CdeAdt1Y2I6k4NbVUqlXYbWoIh2arb+n/+pVJndab/N95OxvomPSpXQwmJhWBT",
+	"94vBpv5Vd/QrIDTe06/Rwwd3awjYVqtWA+7hoMmlqv11/q/z+eLBhu/Aft8HrkGJ47nuGVfsMNXv4Coe",
+	"moGt328INlZvsOiB4u8Nk8ADffjgrqHjAcEBGLw548v+Gu/BZPGLjArdGWJ1gZpPyeoy954QVUO3b66g",
+	"6lOZ2aBD40ya6jZQCwrLNEmOCxebvhmMJAwK6kz/YlyVrj5O6zrdQZd03wLkJTi90Acao0UTBSRHrhMJ",
+	"uvAXWG+8hV39ykY5LgyxTLy5jIq5JcEV93hQq7O6U1RUc2HyxU0K54o/Icz8S+pOqc+fHK3spA5hkbcf",
+	"rGyGlDVMmcdFicYwT1eK0Z5FyRuQPUj3wOh3ePz78if3oepfYPKQCUhDCHDzX5qYEQKDZN5l2mA4uFJB",
+	"FsMoE/Rxxsf4WZGgV8aZ2o73TGSZEQQC6RnR2YOi+jDbgAvz8wtJ7EYVqC3nb5g9QctRCHYCmQP8102e",
+	"GLaM4zrrREirJdYXjG8VEoZD6tScq5X5ylXHNYbFqKjq+sJgzgZehlyWOJi3iUL86cBx+qdUNRFGa4LI",
+	"JvL5Uwba1+wG9AnzCJLQiDKE08Pfrj3BXRjCnFuWHGVn1dMj+dnh9/RQ/DrFOeQH1myZA+rdQBWyMs4S",
+	"l2qgZu+4A1dDPi+3PHmT6sjVkfaj7PRhGsyeywny0qMFQ1k/cAqH74t8OD9/bmsoOelbcpS9wHLA1Ucf",
+	"/vu4cbOFVofP3dsD6Cnu8grtycBLrSvC0APZU3wuYuQpkQqtUSEBBYtFECVQsXdCToJIYQ0zjZHRkxmX",
+	"DJIxtfkyoBSkhCAaOie8JNWtk7Fi7sIYffhv0lyKQU3bbQgv4wCRTjLLYBgq810+EIZL9+Ovc0kUUHku",
+	"CqNU+Z+MhYKlyY2RwcTTJhHEXv7Kb13lN67Sy1VunZ1g5d5d4eTV2j9N0+yapgLSCkX1k6E2XtO4iDIv",
+	"iHzKGqkjJFGT+j5hCI6iFTRWpc6KFwFbkTQX205zE7DOzoLOItkzC88Tjz68Lzpx/upkpBZvpJo+H03u",
+	"M3Cd+Ny3hJy8GaSND07Aa0Hhphdx7Swu4oEP+ycF9uk9vEGMnJuLd3EoGjx5WIahRDkYHp+ntpMDKeDx",
+	"Ar6VXcC1PZIbt1bkI2a1iSWIv4mLVrWgEQWRPFgnPuKMSLfOMPPLtaTVkPIsKBhMcs+uYiuvZFyyRhtT",
+	"EShBpNExmdCG3exTA9R1rs0vTO43et29DNqDV4tKcQ156zxXkaqkgVCigmbAPBfy6zNvm0vqOZcO49Fq",
+	"ROkvFzCV/Q6ENbLzk7E39FMZ75E9P7uyT53d8kD6T1X7B1O156ti4T+IyJOzRdaVhLaG0sIPtqzkP98C",
+	"7X0S0HUico9yJI9s8JvVKcap2vdCx/7xlOvZdF40VB4ej1NbSEZQ7i3kkMo13XDR+aLx0ooCRUMsVBWq",
+	"13NpIX06gY2rkM9kUscu1vw80Plkdwp1eiOYYoX+80fAdihSpmIbXMwNsk4CHia5RGiV/JBKzSmtOjvt",
+	"R+1/DgDaTJkJnUwAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
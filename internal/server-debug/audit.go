@@ -0,0 +1,57 @@
+package serverdebug
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const headerRequestID = "X-Request-ID"
+
+// AuditRecorder записывает события в журнал аудита.
+type AuditRecorder interface {
+	Record(ctx context.Context, e audit.Event) error
+}
+
+// auditGlobalLevel записывает в журнал аудита успешное изменение глобального уровня логирования.
+func (s *Server) auditGlobalLevel(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(eCtx echo.Context) error {
+		if err := next(eCtx); err != nil {
+			return err
+		}
+		if eCtx.Response().Status == http.StatusOK {
+			s.recordConfigChange(eCtx, "log_level", map[string]any{"level": logger.GlobalLevel.Level().String()})
+		}
+		return nil
+	}
+}
+
+// recordConfigChange записывает в журнал аудита изменение конфигурации через отладочный сервер.
+// Ошибка записи не отменяет изменение и только логируется.
+func (s *Server) recordConfigChange(eCtx echo.Context, target string, metadata map[string]any) {
+	if s.audit == nil {
+		return
+	}
+
+	req := eCtx.Request()
+	actor, _, _ := req.BasicAuth()
+	requestID, _ := types.Parse[types.RequestID](req.Header.Get(headerRequestID))
+
+	event := audit.Event{
+		Actor:     actor,
+		Action:    audit.ActionConfigChanged,
+		Target:    target,
+		Metadata:  metadata,
+		RequestID: requestID,
+		IP:        eCtx.RealIP(),
+	}
+	if err := s.audit.Record(context.WithoutCancel(req.Context()), event); err != nil {
+		s.lg.Error("record config change", zap.String("target", target), zap.Error(err))
+	}
+}
@@ -0,0 +1,43 @@
+package serverdebug
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"github.com/FischukSergey/chat-service/internal/servertls"
+)
+
+// newBasicAuth закрывает отладочный сервер Basic-аутентификацией.
+// Пробы остаются открытыми: оркестратор обращается к ним без учетных данных.
+func newBasicAuth(username, password string) echo.MiddlewareFunc {
+	return middleware.BasicAuthWithConfig(middleware.BasicAuthConfig{
+		Skipper: isProbe,
+		Validator: func(u, p string, _ echo.Context) (bool, error) {
+			userOK := subtle.ConstantTimeCompare([]byte(u), []byte(username)) == 1
+			passOK := subtle.ConstantTimeCompare([]byte(p), []byte(password)) == 1
+			return userOK && passOK, nil
+		},
+		Realm: "chat-service debug",
+	})
+}
+
+// newClientCertAuth пускает только клиентов с сертификатом, проверенным при TLS-рукопожатии (mTLS).
+// Как и для Basic-аутентификации, пробы остаются открытыми.
+func newClientCertAuth() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !isProbe(c) && !servertls.HasVerifiedClientCert(c.Request()) {
+				return echo.NewHTTPError(http.StatusUnauthorized, "client certificate required")
+			}
+			return next(c)
+		}
+	}
+}
+
+func isProbe(c echo.Context) bool {
+	return strings.HasPrefix(c.Request().URL.Path, "/health/")
+}
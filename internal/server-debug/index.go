@@ -51,7 +51,37 @@ func (i indexPage) handler(eCtx echo.Context) error {
 		<input type="submit" value="Change"></input>
 	</form>
 	
+	<h2>Component Log Levels</h2>
+	<table>
+		<tr><th align="left">Component</th><th align="left">Level</th><th></th></tr>
+	{{- range $, $c := .Components }}
+		<tr>
+			<td>{{ $c.Component }}</td>
+			<td>{{ $c.Level }}{{ if $c.Inherited }} (global){{ end }}</td>
+			<td>
+				<select id="log-level-{{ $c.Component }}">
+					<option value="inherit" {{ if $c.Inherited }}selected{{ end }}>GLOBAL</option>
+					<option value="debug" {{ if and (not $c.Inherited) (eq $c.Level "debug") }}selected{{ end }}>DEBUG</option>
+					<option value="info" {{ if and (not $c.Inherited) (eq $c.Level "info") }}selected{{ end }}>INFO</option>
+					<option value="warn" {{ if and (not $c.Inherited) (eq $c.Level "warn") }}selected{{ end }}>WARN</option>
+					<option value="error" {{ if and (not $c.Inherited) (eq $c.Level "error") }}selected{{ end }}>ERROR</option>
+				</select>
+				<button onClick="putComponentLogLevel('{{ $c.Component }}')">Change</button>
+			</td>
+		</tr>
+	{{- end }}
+	</table>
+
 	<script>
+		function putComponentLogLevel(component) {
+			const req = new XMLHttpRequest();
+			req.open('PUT', '/log/level/' + encodeURIComponent(component), false);
+			req.setRequestHeader('Content-Type', 'application/x-www-form-urlencoded');
+			req.setRequestHeader('Accept', 'application/json');
+			req.onload = function() { window.location.reload(); };
+			req.send('level='+document.getElementById('log-level-' + component).value);
+		};
+
 		function putLogLevel() {
 			const req = new XMLHttpRequest();
 			req.open('PUT', '/log/level', false);
@@ -64,10 +94,12 @@ func (i indexPage) handler(eCtx echo.Context) error {
 </body>
 </html>
 `)).Execute(eCtx.Response(), struct {
-		Pages    []page
-		LogLevel string
+		Pages      []page
+		LogLevel   string
+		Components []logger.ComponentLevel
 	}{
-		Pages:    i.pages,
-		LogLevel: logger.GlobalLevel.String(),
+		Pages:      i.pages,
+		LogLevel:   logger.GlobalLevel.String(),
+		Components: logger.Components(),
 	})
 }
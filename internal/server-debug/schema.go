@@ -0,0 +1,103 @@
+package serverdebug
+
+import (
+	"html/template"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	swaggerfiles "github.com/swaggo/files/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// swaggerUIAssets отдает встроенные скрипты и стили Swagger UI.
+var swaggerUIAssets = http.StripPrefix(swaggerUIAssetsPath, http.FileServer(http.FS(swaggerfiles.FS)))
+
+const (
+	mimeApplicationYAML = "application/yaml"
+	swaggerUIAssetsPath = "/swagger-ui/"
+)
+
+// yamlMIMETypes - типы из заголовка Accept, при которых спецификация отдается в YAML.
+var yamlMIMETypes = map[string]struct{}{
+	mimeApplicationYAML:  {},
+	"application/x-yaml": {},
+	"text/yaml":          {},
+	"text/x-yaml":        {},
+}
+
+// Schema - возвращает OpenAPI-спецификацию по имени.
+// По умолчанию отдается JSON, YAML - если клиент запросил его в заголовке Accept.
+func (s *Server) Schema(eCtx echo.Context) error {
+	spec, ok := s.specs[eCtx.Param("name")]
+	if !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "unknown specification")
+	}
+
+	if !acceptsYAML(eCtx.Request().Header.Get(echo.HeaderAccept)) {
+		return eCtx.JSON(http.StatusOK, spec)
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to marshal specification")
+	}
+	return eCtx.Blob(http.StatusOK, mimeApplicationYAML, data)
+}
+
+// SchemaUI - возвращает страницу Swagger UI для спецификации.
+func (s *Server) SchemaUI(eCtx echo.Context) error {
+	name := eCtx.Param("name")
+	if _, ok := s.specs[name]; !ok {
+		return echo.NewHTTPError(http.StatusNotFound, "unknown specification")
+	}
+
+	eCtx.Response().Header().Set(echo.HeaderContentType, echo.MIMETextHTMLCharsetUTF8)
+	eCtx.Response().WriteHeader(http.StatusOK)
+	return swaggerUITemplate.Execute(eCtx.Response(), struct {
+		Name       string
+		SpecURL    string
+		AssetsPath string
+	}{
+		Name:       name,
+		SpecURL:    "/schema/" + name,
+		AssetsPath: swaggerUIAssetsPath,
+	})
+}
+
+func acceptsYAML(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if _, ok := yamlMIMETypes[mediaType]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// swaggerUITemplate - страница Swagger UI. Скрипты и стили встроены в бинарник и отдаются с swaggerUIAssetsPath.
+var swaggerUITemplate = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="utf-8">
+	<title>{{ .Name }} API - Chat Service Debug</title>
+	<link rel="stylesheet" href="{{ .AssetsPath }}swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="{{ .AssetsPath }}swagger-ui-bundle.js"></script>
+	<script>
+		window.onload = function() {
+			window.ui = SwaggerUIBundle({
+				url: '{{ .SpecURL }}',
+				dom_id: '#swagger-ui',
+			});
+		};
+	</script>
+</body>
+</html>
+`))
@@ -0,0 +1,103 @@
+package serverdebug_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	clientv1 "github.com/FischukSergey/chat-service/internal/server-client/v1"
+	serverdebug "github.com/FischukSergey/chat-service/internal/server-debug"
+)
+
+func TestServer_Schema(t *testing.T) {
+	swagger, err := clientv1.GetSwagger()
+	require.NoError(t, err)
+
+	srv, err := serverdebug.New(serverdebug.NewOptions(":80",
+		serverdebug.WithSpecs(map[string]*openapi3.T{"client": swagger})))
+	require.NoError(t, err)
+
+	testSrv := httptest.NewServer(srv.Handler())
+	t.Cleanup(testSrv.Close)
+
+	t.Run("json by default", func(t *testing.T) {
+		resp, body := doGet(t, testSrv.URL+"/schema/client", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(body, &spec))
+		assert.Equal(t, swagger.OpenAPI, spec["openapi"])
+		assert.Contains(t, spec["paths"], "/v1/getHistory")
+	})
+
+	t.Run("yaml by accept", func(t *testing.T) {
+		resp, body := doGet(t, testSrv.URL+"/schema/client", "application/yaml, */*;q=0.5")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/yaml", resp.Header.Get(echo.HeaderContentType))
+
+		var spec map[string]any
+		require.NoError(t, yaml.Unmarshal(body, &spec))
+		assert.Equal(t, swagger.OpenAPI, spec["openapi"])
+	})
+
+	t.Run("unknown spec", func(t *testing.T) {
+		resp, _ := doGet(t, testSrv.URL+"/schema/manager", "")
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("swagger ui", func(t *testing.T) {
+		resp, body := doGet(t, testSrv.URL+"/schema/client/ui", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Contains(t, string(body), `url: '\/schema\/client'`)
+
+		resp, _ = doGet(t, testSrv.URL+"/swagger-ui/swagger-ui-bundle.js", "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("old path redirects", func(t *testing.T) {
+		client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}}
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, testSrv.URL+"/shema/client/", nil)
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "/schema/client", resp.Header.Get(echo.HeaderLocation))
+	})
+}
+
+func doGet(t *testing.T, url, accept string) (*http.Response, []byte) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if accept != "" {
+		req.Header.Set(echo.HeaderAccept, accept)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, resp.Body.Close()) }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
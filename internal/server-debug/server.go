@@ -2,37 +2,75 @@ package serverdebug
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/http/pprof"
-	"os"
-	"path/filepath"
+	"slices"
 	"time"
 
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
-	"golang.org/x/sync/errgroup"
-	"gopkg.in/yaml.v3"
 
 	"github.com/FischukSergey/chat-service/internal/buildinfo"
+	"github.com/FischukSergey/chat-service/internal/health"
 	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/servertls"
 )
 
 const (
 	readHeaderTimeout = time.Second
-	shutdownTimeout   = 3 * time.Second
 )
 
 //go:generate options-gen -out-filename=server_options.gen.go -from-struct=Options
 type Options struct {
 	addr string `option:"mandatory" validate:"required,hostname_port"`
+	// metricsGatherer - источник метрик для /metrics. Если не задан, ручка не регистрируется.
+	metricsGatherer prometheus.Gatherer `option:"optional"`
+	// health - проверки для /health/ready. Если не задан, ручки проб не регистрируются.
+	health *health.Health `option:"optional"`
+	// cfg - эффективный конфиг для /debug/config, секреты маскируются при выводе.
+	cfg ConfigProvider `option:"optional"`
+	// routeProviders - серверы по именам, маршруты которых показываются на /debug/routes.
+	routeProviders map[string]RoutesProvider `option:"optional"`
+	// realtime и presence - источники данных для /debug/realtime.
+	realtime SubscribersLister   `option:"optional"`
+	presence PresenceSnapshotter `option:"optional"`
+	// workload - источник загрузки менеджеров для /debug/workload.
+	workload WorkloadProvider `option:"optional"`
+	// specs - OpenAPI-спецификации по именам для /schema/{name}.
+	specs map[string]*openapi3.T `option:"optional"`
+	// audit записывает в журнал аудита изменения конфигурации через отладочный сервер.
+	audit AuditRecorder `option:"optional"`
+	// basicAuthUsername и basicAuthPassword закрывают сервер Basic-аутентификацией, кроме проб.
+	// Если не заданы, доступ открыт.
+	basicAuthUsername string
+	basicAuthPassword string
+	// tlsConfig включает TLS. Если в нем заданы ClientCAs, все маршруты, кроме проб,
+	// требуют клиентский сертификат.
+	tlsConfig *tls.Config `option:"optional"`
+	// h2c включает HTTP/2 без TLS.
+	h2c bool
 }
 
 type Server struct {
 	lg  *zap.Logger
 	srv *http.Server
+	e   *echo.Echo
+
+	cfg            ConfigProvider
+	routeProviders map[string]RoutesProvider
+	realtime       SubscribersLister
+	presence       PresenceSnapshotter
+	workload       WorkloadProvider
+	specs          map[string]*openapi3.T
+	audit          AuditRecorder
 }
 
 func New(opts Options) (*Server, error) {
@@ -40,22 +78,43 @@ func New(opts Options) (*Server, error) {
 	if err := opts.Validate(); err != nil {
 		return nil, fmt.Errorf("validate options: %v", err)
 	}
+	if (opts.basicAuthUsername == "") != (opts.basicAuthPassword == "") {
+		return nil, errors.New("validate options: basic auth username and password must be set together")
+	}
 
-	// создание логгера c именем "server-debug" и уровнем как у глобального логгера
-	lg := zap.L().Named("server-debug")
+	// создание логгера компонента "server-debug", его уровень можно менять отдельно от глобального
+	lg := logger.Named("server-debug")
 
 	// создание эхо-сервера
 	e := echo.New()
+	// Адрес в журнале аудита берется из соединения: заголовки X-Forwarded-For и X-Real-IP подделываются.
+	e.IPExtractor = echo.ExtractIPDirect()
 	e.Use(middleware.Recover())
+	if opts.tlsConfig != nil && opts.tlsConfig.ClientCAs != nil {
+		e.Use(newClientCertAuth())
+	}
+	if opts.basicAuthUsername != "" {
+		e.Use(newBasicAuth(opts.basicAuthUsername, opts.basicAuthPassword))
+	}
 
 	// создание сервера в котором будет запущен эхо-сервер
 	s := &Server{
 		lg: lg,
 		srv: &http.Server{
 			Addr:              opts.addr,
-			Handler:           e,
+			Handler:           servertls.Handler(e, opts.h2c),
 			ReadHeaderTimeout: readHeaderTimeout,
+			TLSConfig:         opts.tlsConfig,
 		},
+		e: e,
+
+		cfg:            opts.cfg,
+		routeProviders: opts.routeProviders,
+		realtime:       opts.realtime,
+		presence:       opts.presence,
+		workload:       opts.workload,
+		specs:          opts.specs,
+		audit:          opts.audit,
 	}
 	index := newIndexPage()
 
@@ -63,10 +122,46 @@ func New(opts Options) (*Server, error) {
 	index.addPage("/version", "Get build information")
 
 	// обработка "/log/level"
-	e.PUT("/log/level", echo.WrapHandler(logger.GlobalLevel))
+	e.PUT("/log/level", echo.WrapHandler(logger.GlobalLevel), s.auditGlobalLevel)
 	e.GET("/log/level", echo.WrapHandler(logger.GlobalLevel))
 	index.addPage("/log/level", "Get log level")
 
+	// обработка "/log/level/{component}"
+	e.GET("/log/level/:component", s.GetComponentLevel)
+	e.PUT("/log/level/:component", s.PutComponentLevel)
+
+	// обработка "/metrics"
+	if opts.metricsGatherer != nil {
+		e.GET("/metrics", echo.WrapHandler(promhttp.HandlerFor(opts.metricsGatherer, promhttp.HandlerOpts{})))
+		index.addPage("/metrics", "Prometheus metrics")
+	}
+
+	// обработка "/health/live" и "/health/ready"
+	if opts.health != nil {
+		e.GET("/health/live", opts.health.LiveHandler)
+		index.addPage("/health/live", "Liveness probe")
+		e.GET("/health/ready", opts.health.ReadyHandler)
+		index.addPage("/health/ready", "Readiness probe with dependency checks")
+	}
+
+	// состояние сервиса
+	if opts.cfg != nil {
+		e.GET("/debug/config", s.Config)
+		index.addPage("/debug/config", "Effective config with masked secrets")
+	}
+	e.GET("/debug/routes", s.AllRoutes)
+	index.addPage("/debug/routes", "Routes of all servers")
+	if opts.realtime != nil || opts.presence != nil {
+		e.GET("/debug/realtime", s.RealtimeClients)
+		index.addPage("/debug/realtime", "Connected realtime clients and presence per user")
+	}
+	if opts.workload != nil {
+		e.GET("/debug/workload", s.ManagersWorkload)
+		index.addPage("/debug/workload", "Current managers workload")
+	}
+	e.GET("/debug/goroutines", s.Goroutines)
+	index.addPage("/debug/goroutines", "Goroutines grouped by stack")
+
 	// добавляем ручку для тестирования ERROR логов
 	e.GET("/debug/error", s.DebugError)
 	index.addPage("/debug/error", "Debug Sentry error event")
@@ -85,36 +180,36 @@ func New(opts Options) (*Server, error) {
 		index.addPage("/debug/pprof/profile?seconds=30", "Take half-min profile")
 	}
 
-	// добавляем ручку для отображения схемы API
-	e.GET("/shema/client/*", s.ClientSchema)
-	index.addPage("/shema/client/", "Get client OpenAPI specification")
+	// добавляем ручки для отображения спецификаций API
+	e.GET("/schema/:name", s.Schema)
+	e.GET("/schema/:name/ui", s.SchemaUI)
+	e.GET(swaggerUIAssetsPath+"*", echo.WrapHandler(swaggerUIAssets))
+	for _, name := range slices.Sorted(maps.Keys(opts.specs)) {
+		index.addPage("/schema/"+name, "Get "+name+" OpenAPI specification (JSON or YAML by Accept)")
+		index.addPage("/schema/"+name+"/ui", "Swagger UI for "+name+" API")
+	}
+	// старый адрес с опечаткой
+	e.GET("/shema/client/*", func(eCtx echo.Context) error {
+		return eCtx.Redirect(http.StatusMovedPermanently, "/schema/client")
+	})
 
 	e.GET("/", index.handler)
 	return s, nil
 }
 
-func (s *Server) Run(ctx context.Context) error {
-	eg, ctx := errgroup.WithContext(ctx)
+// Run обслуживает запросы, пока сервер не будет остановлен через Shutdown.
+func (s *Server) Run() error {
+	s.lg.Info("listen and serve", zap.String("addr", s.srv.Addr), zap.Bool("tls", s.srv.TLSConfig != nil))
 
-	eg.Go(func() error {
-		<-ctx.Done()
-
-		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
-		defer cancel()
-
-		return s.srv.Shutdown(ctx) //nolint:contextcheck // graceful shutdown with new context
-	})
-
-	eg.Go(func() error {
-		s.lg.Info("listen and serve", zap.String("addr", s.srv.Addr))
-
-		if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			return fmt.Errorf("listen and serve: %v", err)
-		}
-		return nil
-	})
+	if err := servertls.ListenAndServe(s.srv); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("listen and serve: %v", err)
+	}
+	return nil
+}
 
-	return eg.Wait()
+// Shutdown перестает принимать соединения и ждет завершения активных запросов, пока не завершится ctx.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
 }
 
 // Version - возвращает информацию о сборке в формате JSON.
@@ -146,23 +241,38 @@ func (s *Server) DebugError(eCtx echo.Context) error {
 	})
 }
 
-// ClientSchema - возвращает схему API клиента.
-func (s *Server) ClientSchema(eCtx echo.Context) error {
-	schemaPath := filepath.Join("api", "client.v1.swagger.yml")
-	schema, err := os.ReadFile(schemaPath)
+// GetComponentLevel - возвращает уровень логирования компонента.
+func (s *Server) GetComponentLevel(eCtx echo.Context) error {
+	lvl, err := logger.GetComponentLevel(eCtx.Param("component"))
 	if err != nil {
-		return eCtx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to read schema file",
-		})
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	return eCtx.JSON(http.StatusOK, lvl)
+}
+
+// PutComponentLevel - меняет уровень логирования компонента.
+// Уровень передается так же, как для "/log/level": формой или JSON-объектом с полем level.
+func (s *Server) PutComponentLevel(eCtx echo.Context) error {
+	var req struct {
+		Level string `json:"level" form:"level"`
+	}
+	if err := eCtx.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request format")
 	}
 
-	var yamlObj any
-	if err := yaml.Unmarshal(schema, &yamlObj); err != nil {
-		return eCtx.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to parse YAML",
-		})
+	component := eCtx.Param("component")
+	if err := logger.SetComponentLevel(component, req.Level); err != nil {
+		if errors.Is(err, logger.ErrUnknownComponent) {
+			return echo.NewHTTPError(http.StatusNotFound, err.Error())
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	// Преобразуем YAML-структуру в JSON и отправляем клиенту
-	return eCtx.JSON(http.StatusOK, yamlObj)
+	lvl, err := logger.GetComponentLevel(component)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+	s.lg.Info("component log level changed", zap.String("component", component), zap.String("level", lvl.Level))
+	s.recordConfigChange(eCtx, "log_level:"+component, map[string]any{"level": req.Level})
+	return eCtx.JSON(http.StatusOK, lvl)
 }
@@ -2,10 +2,14 @@
 package serverdebug
 
 import (
+	"crypto/tls"
 	fmt461e464ebed9 "fmt"
 
+	"github.com/FischukSergey/chat-service/internal/health"
+	"github.com/getkin/kin-openapi/openapi3"
 	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
 	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type OptOptionsSetter func(o *Options)
@@ -26,6 +30,109 @@ func NewOptions(
 	return o
 }
 
+// metricsGatherer - источник метрик для /metrics. Если не задан, ручка не регистрируется.
+func WithMetricsGatherer(opt prometheus.Gatherer) OptOptionsSetter {
+	return func(o *Options) {
+		o.metricsGatherer = opt
+
+	}
+}
+
+// health - проверки для /health/ready. Если не задан, ручки проб не регистрируются.
+func WithHealth(opt *health.Health) OptOptionsSetter {
+	return func(o *Options) {
+		o.health = opt
+
+	}
+}
+
+// cfg - эффективный конфиг для /debug/config, секреты маскируются при выводе.
+func WithCfg(opt ConfigProvider) OptOptionsSetter {
+	return func(o *Options) {
+		o.cfg = opt
+
+	}
+}
+
+// routeProviders - серверы по именам, маршруты которых показываются на /debug/routes.
+func WithRouteProviders(opt map[string]RoutesProvider) OptOptionsSetter {
+	return func(o *Options) {
+		o.routeProviders = opt
+
+	}
+}
+
+// realtime и presence - источники данных для /debug/realtime.
+func WithRealtime(opt SubscribersLister) OptOptionsSetter {
+	return func(o *Options) {
+		o.realtime = opt
+
+	}
+}
+
+func WithPresence(opt PresenceSnapshotter) OptOptionsSetter {
+	return func(o *Options) {
+		o.presence = opt
+
+	}
+}
+
+// workload - источник загрузки менеджеров для /debug/workload.
+func WithWorkload(opt WorkloadProvider) OptOptionsSetter {
+	return func(o *Options) {
+		o.workload = opt
+
+	}
+}
+
+// specs - OpenAPI-спецификации по именам для /schema/{name}.
+func WithSpecs(opt map[string]*openapi3.T) OptOptionsSetter {
+	return func(o *Options) {
+		o.specs = opt
+
+	}
+}
+
+// audit записывает в журнал аудита изменения конфигурации через отладочный сервер.
+func WithAudit(opt AuditRecorder) OptOptionsSetter {
+	return func(o *Options) {
+		o.audit = opt
+
+	}
+}
+
+// basicAuthUsername и basicAuthPassword закрывают сервер Basic-аутентификацией, кроме проб.
+// Если не заданы, доступ открыт.
+func WithBasicAuthUsername(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.basicAuthUsername = opt
+
+	}
+}
+
+func WithBasicAuthPassword(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.basicAuthPassword = opt
+
+	}
+}
+
+// tlsConfig включает TLS, в том числе проверку клиентских сертификатов, если она в нем задана.
+func WithTlsConfig(opt *tls.Config) OptOptionsSetter {
+	return func(o *Options) {
+		o.tlsConfig = opt
+
+	}
+}
+
+// h2c включает HTTP/2 без TLS.
+func WithH2c(opt bool) OptOptionsSetter {
+	return func(o *Options) {
+		o.h2c = opt
+
+	}
+}
+
 func (o *Options) Validate() error {
 	errs := new(errors461e464ebed9.ValidationErrors)
 	errs.Add(errors461e464ebed9.NewValidationError("addr", _validate_Options_addr(o)))
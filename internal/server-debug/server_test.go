@@ -11,11 +11,13 @@ import (
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/FischukSergey/chat-service/internal/logger"
 	serverdebug "github.com/FischukSergey/chat-service/internal/server-debug"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
 )
 
 func TestServer_LoggerLevel(t *testing.T) {
@@ -84,6 +86,62 @@ func TestServer_LoggerLevel(t *testing.T) {
 	}
 }
 
+func TestServer_ComponentLoggerLevel(t *testing.T) {
+	// Arrange.
+	err := logger.Init(logger.NewOptions("info"))
+	require.NoError(t, err)
+
+	srv, err := serverdebug.New(serverdebug.NewOptions(":80"))
+	require.NoError(t, err)
+
+	testSrv := httptest.NewServer(srv.Handler())
+	t.Cleanup(testSrv.Close)
+
+	componentURL := testSrv.URL + "/log/level/server-debug"
+
+	// Action & Assert.
+	assert.Equal(t, "info", getLevel(t, componentURL))
+
+	require.Equal(t, http.StatusOK, setLevel(t, componentURL, "debug"))
+	assert.Equal(t, "debug", getLevel(t, componentURL))
+	assert.Equal(t, "info", getLevel(t, testSrv.URL+"/log/level"))
+
+	require.Equal(t, http.StatusOK, setLevel(t, componentURL, logger.InheritLevel))
+	assert.Equal(t, "info", getLevel(t, componentURL))
+
+	assert.Equal(t, http.StatusBadRequest, setLevel(t, componentURL, "any_invalid_level"))
+	assert.Equal(t, http.StatusNotFound, setLevel(t, testSrv.URL+"/log/level/unknown-component", "debug"))
+}
+
+func TestServer_AuditLogLevelChanges(t *testing.T) {
+	// Arrange.
+	err := logger.Init(logger.NewOptions("info"))
+	require.NoError(t, err)
+
+	recorder := new(auditRecorderStub)
+	srv, err := serverdebug.New(serverdebug.NewOptions(":80", serverdebug.WithAudit(recorder)))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, logger.SetComponentLevel("server-debug", logger.InheritLevel)) })
+
+	testSrv := httptest.NewServer(srv.Handler())
+	t.Cleanup(testSrv.Close)
+
+	// Action.
+	require.Equal(t, http.StatusOK, setLevel(t, testSrv.URL+"/log/level", "warn"))
+	require.Equal(t, http.StatusOK, setLevel(t, testSrv.URL+"/log/level/server-debug", "debug"))
+	require.Equal(t, http.StatusBadRequest, setLevel(t, testSrv.URL+"/log/level", "any_invalid_level"))
+	require.Equal(t, http.StatusNotFound, setLevel(t, testSrv.URL+"/log/level/unknown-component", "debug"))
+
+	// Assert.
+	require.Len(t, recorder.events, 2)
+	assert.Equal(t, audit.ActionConfigChanged, recorder.events[0].Action)
+	assert.Equal(t, "log_level", recorder.events[0].Target)
+	assert.Equal(t, map[string]any{"level": "warn"}, recorder.events[0].Metadata)
+	assert.NotEmpty(t, recorder.events[0].IP)
+	assert.Equal(t, "log_level:server-debug", recorder.events[1].Target)
+	assert.Equal(t, map[string]any{"level": "debug"}, recorder.events[1].Metadata)
+}
+
 func setLevel(t *testing.T, url, level string) int {
 	t.Helper()
 
@@ -124,3 +182,27 @@ func getLevel(t *testing.T, url string) string {
 
 	return data.Level
 }
+
+func TestServer_Metrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_counter", Help: "Test counter."})
+	reg.MustRegister(counter)
+	counter.Inc()
+
+	srv, err := serverdebug.New(serverdebug.NewOptions(":80", serverdebug.WithMetricsGatherer(reg)))
+	require.NoError(t, err)
+
+	testSrv := httptest.NewServer(srv.Handler())
+	t.Cleanup(testSrv.Close)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, testSrv.URL+"/metrics", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "test_counter 1")
+}
@@ -0,0 +1,146 @@
+package serverdebug
+
+import (
+	"context"
+	"net/http"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	problemsrepo "github.com/FischukSergey/chat-service/internal/repositories/problems"
+	"github.com/FischukSergey/chat-service/internal/services/presence"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const debugServerName = "debug"
+
+// ConfigProvider возвращает действующий конфиг, который меняется при перечитывании.
+type ConfigProvider interface {
+	Current() config.Config
+}
+
+// RoutesProvider - сервер, маршруты которого показываются на /debug/routes.
+type RoutesProvider interface {
+	Routes() []*echo.Route
+}
+
+// SubscribersLister возвращает количество realtime-подключений каждого пользователя.
+type SubscribersLister interface {
+	Subscribers() map[types.UserID]int
+}
+
+// PresenceSnapshotter возвращает статусы присутствия пользователей.
+type PresenceSnapshotter interface {
+	Snapshot() map[types.UserID]presence.Status
+}
+
+// WorkloadProvider возвращает текущую загрузку менеджеров.
+type WorkloadProvider interface {
+	ManagersWorkload(ctx context.Context) ([]problemsrepo.ManagerWorkload, error)
+}
+
+type route struct {
+	Server string `json:"server"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+type realtimeClient struct {
+	UserID      types.UserID `json:"user_id"`
+	Connections int          `json:"connections"`
+	Online      bool         `json:"online"`
+	LastSeen    *time.Time   `json:"last_seen,omitempty"`
+}
+
+// Config - возвращает эффективный конфиг в формате TOML с замаскированными секретами.
+func (s *Server) Config(eCtx echo.Context) error {
+	eCtx.Response().Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
+	eCtx.Response().WriteHeader(http.StatusOK)
+	return config.WriteMasked(eCtx.Response(), s.cfg.Current())
+}
+
+// AllRoutes - возвращает маршруты всех серверов сервиса.
+func (s *Server) AllRoutes(eCtx echo.Context) error {
+	servers := make(map[string]RoutesProvider, len(s.routeProviders)+1)
+	for name, p := range s.routeProviders {
+		servers[name] = p
+	}
+	servers[debugServerName] = s.e
+
+	var routes []route
+	for name, p := range servers {
+		for _, r := range p.Routes() {
+			routes = append(routes, route{Server: name, Method: r.Method, Path: r.Path})
+		}
+	}
+	slices.SortFunc(routes, func(a, b route) int {
+		if c := strings.Compare(a.Server, b.Server); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Method, b.Method)
+	})
+
+	return eCtx.JSON(http.StatusOK, routes)
+}
+
+// RealtimeClients - возвращает realtime-подключения и присутствие пользователей.
+func (s *Server) RealtimeClients(eCtx echo.Context) error {
+	clients := make(map[types.UserID]*realtimeClient)
+	get := func(userID types.UserID) *realtimeClient {
+		c, ok := clients[userID]
+		if !ok {
+			c = &realtimeClient{UserID: userID}
+			clients[userID] = c
+		}
+		return c
+	}
+
+	if s.realtime != nil {
+		for userID, n := range s.realtime.Subscribers() {
+			get(userID).Connections = n
+		}
+	}
+	if s.presence != nil {
+		for userID, st := range s.presence.Snapshot() {
+			c := get(userID)
+			c.Online = st.Online
+			lastSeen := st.LastSeen
+			c.LastSeen = &lastSeen
+		}
+	}
+
+	res := make([]realtimeClient, 0, len(clients))
+	for _, c := range clients {
+		res = append(res, *c)
+	}
+	slices.SortFunc(res, func(a, b realtimeClient) int {
+		return strings.Compare(a.UserID.String(), b.UserID.String())
+	})
+
+	return eCtx.JSON(http.StatusOK, res)
+}
+
+// ManagersWorkload - возвращает количество нерешенных проблем у каждого менеджера.
+func (s *Server) ManagersWorkload(eCtx echo.Context) error {
+	workload, err := s.workload.ManagersWorkload(eCtx.Request().Context())
+	if err != nil {
+		s.lg.Error("get managers workload", zap.Error(err))
+		return echo.NewHTTPError(http.StatusInternalServerError, "failed to get managers workload")
+	}
+	return eCtx.JSON(http.StatusOK, workload)
+}
+
+// Goroutines - возвращает стеки горутин, сгруппированные по одинаковым стекам.
+func (s *Server) Goroutines(eCtx echo.Context) error {
+	eCtx.Response().Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
+	eCtx.Response().WriteHeader(http.StatusOK)
+	return pprof.Lookup("goroutine").WriteTo(eCtx.Response(), 1)
+}
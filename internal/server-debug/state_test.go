@@ -0,0 +1,210 @@
+package serverdebug_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/health"
+	problemsrepo "github.com/FischukSergey/chat-service/internal/repositories/problems"
+	serverdebug "github.com/FischukSergey/chat-service/internal/server-debug"
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+	"github.com/FischukSergey/chat-service/internal/services/presence"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const (
+	debugUser     = "admin"
+	debugPassword = "debug-password"
+)
+
+func TestServer_StatePages(t *testing.T) {
+	userA, userB, managerID := types.NewUserID(), types.NewUserID(), types.NewUserID()
+	lastSeen := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cfg := config.Config{}
+	cfg.Clients.Keycloak.ClientID = "chat-service"
+	cfg.Clients.Keycloak.ClientSecret = "keycloak-secret"
+
+	clientServer := echo.New()
+	clientServer.POST("/v1/getHistory", func(echo.Context) error { return nil })
+
+	srv, err := serverdebug.New(serverdebug.NewOptions(
+		":80",
+		serverdebug.WithCfg(configStub(cfg)),
+		serverdebug.WithRouteProviders(map[string]serverdebug.RoutesProvider{"server-client": clientServer}),
+		serverdebug.WithRealtime(subscribersStub{userA: 2}),
+		serverdebug.WithPresence(presenceStub{userB: {Online: true, LastSeen: lastSeen}}),
+		serverdebug.WithWorkload(workloadStub{{ManagerID: managerID, Open: 1, InProgress: 3}}),
+		serverdebug.WithBasicAuthUsername(debugUser),
+		serverdebug.WithBasicAuthPassword(debugPassword),
+	))
+	require.NoError(t, err)
+
+	testSrv := httptest.NewServer(srv.Handler())
+	t.Cleanup(testSrv.Close)
+
+	t.Run("basic auth is required", func(t *testing.T) {
+		status, _ := get(t, testSrv.URL+"/debug/routes", false)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("config with masked secrets", func(t *testing.T) {
+		status, body := get(t, testSrv.URL+"/debug/config", true)
+		require.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, `client_id = "chat-service"`)
+		assert.Contains(t, body, `client_secret = "***"`)
+		assert.NotContains(t, body, "keycloak-secret")
+	})
+
+	t.Run("routes of all servers", func(t *testing.T) {
+		status, body := get(t, testSrv.URL+"/debug/routes", true)
+		require.Equal(t, http.StatusOK, status)
+
+		var routes []debugRoute
+		require.NoError(t, json.Unmarshal([]byte(body), &routes))
+		assert.Contains(t, routes, debugRoute{Server: "server-client", Method: http.MethodPost, Path: "/v1/getHistory"})
+		assert.Contains(t, routes, debugRoute{Server: "debug", Method: http.MethodGet, Path: "/debug/routes"})
+	})
+
+	t.Run("realtime clients", func(t *testing.T) {
+		status, body := get(t, testSrv.URL+"/debug/realtime", true)
+		require.Equal(t, http.StatusOK, status)
+
+		var clients []struct {
+			UserID      types.UserID `json:"user_id"`
+			Connections int          `json:"connections"`
+			Online      bool         `json:"online"`
+			LastSeen    *time.Time   `json:"last_seen"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(body), &clients))
+		require.Len(t, clients, 2)
+		for _, c := range clients {
+			switch c.UserID {
+			case userA:
+				assert.Equal(t, 2, c.Connections)
+				assert.False(t, c.Online)
+				assert.Nil(t, c.LastSeen)
+			case userB:
+				assert.Equal(t, 0, c.Connections)
+				assert.True(t, c.Online)
+				require.NotNil(t, c.LastSeen)
+				assert.True(t, lastSeen.Equal(*c.LastSeen))
+			default:
+				t.Fatalf("unexpected user %v", c.UserID)
+			}
+		}
+	})
+
+	t.Run("managers workload", func(t *testing.T) {
+		status, body := get(t, testSrv.URL+"/debug/workload", true)
+		require.Equal(t, http.StatusOK, status)
+
+		var workload []problemsrepo.ManagerWorkload
+		require.NoError(t, json.Unmarshal([]byte(body), &workload))
+		assert.Equal(t, []problemsrepo.ManagerWorkload{{ManagerID: managerID, Open: 1, InProgress: 3}}, workload)
+	})
+
+	t.Run("goroutines", func(t *testing.T) {
+		status, body := get(t, testSrv.URL+"/debug/goroutines", true)
+		require.Equal(t, http.StatusOK, status)
+		assert.Contains(t, body, "goroutine profile: total")
+	})
+}
+
+func TestServer_BasicAuthValidation(t *testing.T) {
+	_, err := serverdebug.New(serverdebug.NewOptions(":80", serverdebug.WithBasicAuthUsername(debugUser)))
+	require.Error(t, err)
+}
+
+func TestServer_ClientCertAuth(t *testing.T) {
+	h, err := health.New(health.NewOptions())
+	require.NoError(t, err)
+	srv, err := serverdebug.New(serverdebug.NewOptions(":80",
+		serverdebug.WithHealth(h),
+		serverdebug.WithTlsConfig(&tls.Config{ClientCAs: x509.NewCertPool(), MinVersion: tls.VersionTLS12}),
+	))
+	require.NoError(t, err)
+
+	// Рукопожатие выполняет http.Server, поэтому результат проверки сертификата подставляется в запрос.
+	serve := func(path string, verified bool) int {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.TLS = &tls.ConnectionState{}
+		if verified {
+			req.TLS.VerifiedChains = [][]*x509.Certificate{{{}}}
+		}
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("/version", false))
+	assert.Equal(t, http.StatusOK, serve("/version", true))
+	// Пробы оркестратора проходят без сертификата.
+	assert.Equal(t, http.StatusOK, serve("/health/live", false))
+}
+
+func get(t *testing.T, url string, withAuth bool) (int, string) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	require.NoError(t, err)
+	if withAuth {
+		req.SetBasicAuth(debugUser, debugPassword)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, resp.Body.Close()) }()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+type debugRoute struct {
+	Server string `json:"server"`
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+type configStub config.Config
+
+func (c configStub) Current() config.Config { return config.Config(c) }
+
+type subscribersStub map[types.UserID]int
+
+func (s subscribersStub) Subscribers() map[types.UserID]int { return s }
+
+type presenceStub map[types.UserID]presence.Status
+
+func (p presenceStub) Snapshot() map[types.UserID]presence.Status { return p }
+
+type workloadStub []problemsrepo.ManagerWorkload
+
+func (w workloadStub) ManagersWorkload(context.Context) ([]problemsrepo.ManagerWorkload, error) {
+	return w, nil
+}
+
+type auditRecorderStub struct {
+	events []audit.Event
+}
+
+func (r *auditRecorderStub) Record(_ context.Context, e audit.Event) error {
+	r.events = append(r.events, e)
+	return nil
+}
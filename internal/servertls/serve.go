@@ -0,0 +1,31 @@
+package servertls
+
+import (
+	"net/http"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// Handler оборачивает обработчик сервера для HTTP/2 без TLS (h2c), если он включен.
+// С TLS HTTP/2 согласовывается через ALPN и обертка не нужна.
+func Handler(h http.Handler, enableH2C bool) http.Handler {
+	if !enableH2C {
+		return h
+	}
+	return h2c.NewHandler(h, &http2.Server{})
+}
+
+// ListenAndServe запускает сервер с TLS, если задан srv.TLSConfig, иначе без него.
+func ListenAndServe(srv *http.Server) error {
+	if srv.TLSConfig != nil {
+		// Сертификат отдает srv.TLSConfig.GetCertificate.
+		return srv.ListenAndServeTLS("", "")
+	}
+	return srv.ListenAndServe()
+}
+
+// HasVerifiedClientCert сообщает, предъявил ли клиент сертификат, подписанный доверенным CA (см. clientCAFile).
+func HasVerifiedClientCert(r *http.Request) bool {
+	return r.TLS != nil && len(r.TLS.VerifiedChains) > 0
+}
@@ -0,0 +1,119 @@
+package servertls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/filewatch"
+	"github.com/FischukSergey/chat-service/internal/logger"
+)
+
+var versions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+//go:generate options-gen -out-filename=servertls_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	name     string `option:"mandatory" validate:"required"`
+	certFile string `option:"mandatory" validate:"required"`
+	keyFile  string `option:"mandatory" validate:"required"`
+	// minVersion - минимальная версия TLS: "1.2" или "1.3".
+	minVersion string `validate:"oneof=1.2 1.3"`
+	// clientCAFile - PEM-файл с корневыми сертификатами клиентов (mTLS).
+	// Если задан, сервер отклоняет соединения с сертификатом, не подписанным одним из них.
+	clientCAFile string
+	// debounce - сколько ждать после последнего изменения файлов: сертификат и ключ обычно обновляются по очереди.
+	debounce time.Duration `validate:"min=0"`
+}
+
+var defaultOptions = Options{
+	minVersion: "1.2",
+	debounce:   time.Second,
+}
+
+// CertReloader отдает TLS-конфигурацию сервера и перечитывает сертификат с ключом при изменении файлов,
+// чтобы продлить сертификат можно было без перезапуска. Новые соединения получают новый сертификат.
+type CertReloader struct {
+	Options
+	lg        *zap.Logger
+	cert      atomic.Pointer[tls.Certificate]
+	clientCAs *x509.CertPool
+}
+
+func New(opts Options) (*CertReloader, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+
+	r := &CertReloader{Options: opts, lg: logger.Named("tls-" + opts.name)}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	if opts.clientCAFile != "" {
+		pem, err := os.ReadFile(opts.clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client CA file: %v", err)
+		}
+		r.clientCAs = x509.NewCertPool()
+		if !r.clientCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates in client CA file")
+		}
+	}
+	return r, nil
+}
+
+// TLSConfig возвращает конфигурацию для http.Server.TLSConfig.
+func (r *CertReloader) TLSConfig() *tls.Config {
+	cfg := &tls.Config{
+		MinVersion: versions[r.minVersion],
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return r.cert.Load(), nil
+		},
+	}
+	// Сертификат проверяется при рукопожатии, но не требуется: на одном адресе обслуживаются и пробы оркестратора.
+	// Маршруты, которым нужен клиентский сертификат, проверяют его через HasVerifiedClientCert.
+	if r.clientCAs != nil {
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		cfg.ClientCAs = r.clientCAs
+	}
+	return cfg
+}
+
+// Reload перечитывает сертификат и ключ. При ошибке продолжает использоваться прежний сертификат.
+func (r *CertReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load key pair: %v", err)
+	}
+	r.cert.Store(&cert)
+	return nil
+}
+
+// Run перечитывает сертификат при изменении файлов, пока не завершится контекст.
+func (r *CertReloader) Run(ctx context.Context) error {
+	w, err := filewatch.New(filewatch.NewOptions(r.lg, []string{r.certFile, r.keyFile}, r.reload,
+		filewatch.WithDebounce(r.debounce),
+	))
+	if err != nil {
+		return fmt.Errorf("create watcher: %v", err)
+	}
+	return w.Run(ctx)
+}
+
+func (r *CertReloader) reload(context.Context, string) {
+	if err := r.Reload(); err != nil {
+		r.lg.Error("reload certificate", zap.Error(err))
+		return
+	}
+	r.lg.Info("certificate reloaded", zap.Time("not_after", r.cert.Load().Leaf.NotAfter))
+}
@@ -0,0 +1,115 @@
+// Code generated by options-gen. DO NOT EDIT.
+package servertls
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	name string,
+	certFile string,
+	keyFile string,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.name = defaultOptions.name
+
+	o.certFile = defaultOptions.certFile
+
+	o.keyFile = defaultOptions.keyFile
+
+	o.minVersion = defaultOptions.minVersion
+
+	o.clientCAFile = defaultOptions.clientCAFile
+
+	o.debounce = defaultOptions.debounce
+
+	o.name = name
+
+	o.certFile = certFile
+
+	o.keyFile = keyFile
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// minVersion - минимальная версия TLS: "1.2" или "1.3".
+func WithMinVersion(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.minVersion = opt
+
+	}
+}
+
+// clientCAFile - PEM-файл с корневыми сертификатами клиентов.
+// Если задан, сервер принимает только клиентов с сертификатом, подписанным одним из них (mTLS).
+func WithClientCAFile(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.clientCAFile = opt
+
+	}
+}
+
+// debounce - сколько ждать после последнего изменения файлов: сертификат и ключ обычно обновляются по очереди.
+func WithDebounce(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.debounce = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("name", _validate_Options_name(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("certFile", _validate_Options_certFile(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("keyFile", _validate_Options_keyFile(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("minVersion", _validate_Options_minVersion(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("debounce", _validate_Options_debounce(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_name(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.name, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `name` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_certFile(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.certFile, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `certFile` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_keyFile(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.keyFile, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `keyFile` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_minVersion(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.minVersion, "oneof=1.2 1.3"); err != nil {
+		return fmt461e464ebed9.Errorf("field `minVersion` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_debounce(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.debounce, "min=0"); err != nil {
+		return fmt461e464ebed9.Errorf("field `debounce` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package servertls_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+
+	"github.com/FischukSergey/chat-service/internal/servertls"
+)
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	ca.issue(t, 1, false).write(t, certFile, keyFile)
+
+	certs, err := servertls.New(servertls.NewOptions("test", certFile, keyFile,
+		servertls.WithDebounce(50*time.Millisecond)))
+	require.NoError(t, err)
+	addr := serve(t, certs.TLSConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- certs.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	clientCfg := &tls.Config{RootCAs: ca.pool(), ServerName: "localhost", MinVersion: tls.VersionTLS12}
+	assert.Equal(t, int64(1), servedSerial(t, addr, clientCfg))
+
+	// Наблюдение начинается асинхронно, поэтому сертификат перезаписывается, пока замена не будет замечена.
+	next := ca.issue(t, 2, false)
+	require.Eventually(t, func() bool {
+		next.write(t, certFile, keyFile)
+		return servedSerial(t, addr, clientCfg) == 2
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestCertReloader_MinVersion(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	ca.issue(t, 1, false).write(t, certFile, keyFile)
+
+	certs, err := servertls.New(servertls.NewOptions("test", certFile, keyFile, servertls.WithMinVersion("1.3")))
+	require.NoError(t, err)
+	addr := serve(t, certs.TLSConfig())
+
+	_, err = dial(addr, &tls.Config{ //nolint:gosec // checking that old versions are rejected
+		RootCAs: ca.pool(), ServerName: "localhost", MaxVersion: tls.VersionTLS12,
+	})
+	require.Error(t, err)
+}
+
+func TestCertReloader_ClientCA(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t)
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	ca.issue(t, 1, false).write(t, certFile, keyFile)
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM, 0o600))
+
+	certs, err := servertls.New(servertls.NewOptions("test", certFile, keyFile, servertls.WithClientCAFile(caFile)))
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, servertls.HasVerifiedClientCert(r))
+	}))
+	srv.TLS = certs.TLSConfig()
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	get := func(clientCerts ...keyPair) (string, error) {
+		cfg := &tls.Config{RootCAs: ca.pool(), ServerName: "localhost", MinVersion: tls.VersionTLS12}
+		for _, kp := range clientCerts {
+			cert, err := tls.X509KeyPair(kp.certPEM, kp.keyPEM)
+			require.NoError(t, err)
+			cfg.Certificates = append(cfg.Certificates, cert)
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body), nil
+	}
+
+	t.Run("without certificate", func(t *testing.T) {
+		// Рукопожатие проходит, требовать сертификат должен маршрут.
+		verified, err := get()
+		require.NoError(t, err)
+		assert.Equal(t, "false", verified)
+	})
+
+	t.Run("trusted certificate", func(t *testing.T) {
+		verified, err := get(ca.issue(t, 3, true))
+		require.NoError(t, err)
+		assert.Equal(t, "true", verified)
+	})
+
+	t.Run("untrusted certificate", func(t *testing.T) {
+		_, err := get(newCA(t).issue(t, 4, true))
+		require.Error(t, err)
+	})
+}
+
+func TestHandler_H2C(t *testing.T) {
+	srv := httptest.NewServer(servertls.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}), true))
+	t.Cleanup(srv.Close)
+
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return new(net.Dialer).DialContext(ctx, network, addr)
+		},
+	}}
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, 2, resp.ProtoMajor)
+}
+
+// serve запускает TLS-сервер, который отвечает серийным номером своего сертификата.
+func serve(t *testing.T, cfg *tls.Config) string {
+	t.Helper()
+
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.(*tls.Conn).Handshake()
+				_, _ = conn.Write([]byte{0})
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func dial(addr string, cfg *tls.Config) (*tls.Conn, error) {
+	conn, err := tls.Dial("tcp", addr, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// В TLS 1.3 сервер проверяет клиентский сертификат после рукопожатия клиента,
+	// поэтому отказ виден только при чтении.
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+func servedSerial(t *testing.T, addr string, cfg *tls.Config) int64 {
+	t.Helper()
+
+	conn, err := dial(addr, cfg)
+	require.NoError(t, err)
+	return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+}
+
+type keyPair struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func (kp keyPair) write(t *testing.T, certFile, keyFile string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(certFile, kp.certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, kp.keyPEM, 0o600))
+}
+
+func (kp keyPair) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(kp.cert)
+	return pool
+}
+
+func newCA(t *testing.T) keyPair {
+	t.Helper()
+	return newKeyPair(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(100),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+}
+
+func (kp keyPair) issue(t *testing.T, serial int64, client bool) keyPair {
+	t.Helper()
+
+	usage := x509.ExtKeyUsageServerAuth
+	if client {
+		usage = x509.ExtKeyUsageClientAuth
+	}
+	return newKeyPair(t, &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}, &kp)
+}
+
+func newKeyPair(t *testing.T, tmpl *x509.Certificate, parent *keyPair) keyPair {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	parentCert, parentKey := tmpl, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return keyPair{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
@@ -0,0 +1,216 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/blobstore"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/attachment"
+	"github.com/FischukSergey/chat-service/internal/store/chat"
+	"github.com/FischukSergey/chat-service/internal/store/message"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// sniffLen - сколько первых байт файла анализируется для определения MIME-типа.
+const sniffLen = 512
+
+var (
+	ErrTooLarge           = errors.New("attachment is too large")
+	ErrEmpty              = errors.New("attachment is empty")
+	ErrMIMETypeNotAllowed = errors.New("attachment mime type is not allowed")
+	ErrNotFound           = errors.New("attachment not found")
+)
+
+//go:generate options-gen -out-filename=attachments_options.gen.go -from-struct=Options
+type Options struct {
+	store            *store.Client       `option:"mandatory" validate:"required"`
+	blobs            blobstore.BlobStore `option:"mandatory" validate:"required"`
+	maxSize          int64               `option:"mandatory" validate:"min=1"`
+	allowedMIMETypes []string            `option:"mandatory" validate:"min=1,dive,required"`
+	urlTTL           time.Duration       `option:"mandatory" validate:"min=1s"`
+}
+
+// Service сохраняет вложения в хранилище BLOB-объектов и выдает на них подписанные ссылки.
+type Service struct {
+	Options
+}
+
+// Attachment - загруженное вложение со ссылкой на скачивание.
+type Attachment struct {
+	ID           types.AttachmentID
+	FileName     string
+	ContentType  string
+	Size         int64
+	URL          string
+	URLExpiresAt time.Time
+}
+
+func New(opts Options) (*Service, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &Service{Options: opts}, nil
+}
+
+// MaxSize возвращает максимальный размер вложения в байтах.
+func (s *Service) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Upload сохраняет вложение пользователя uploaderID. Файл передается в хранилище потоком, не накапливаясь в памяти.
+// MIME-тип определяется по содержимому файла, а не по имени или заголовкам запроса.
+func (s *Service) Upload(ctx context.Context, uploaderID types.UserID, fileName string, r io.Reader) (*Attachment, error) {
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("read attachment: %v", err)
+	}
+	if n == 0 {
+		return nil, ErrEmpty
+	}
+	head = head[:n]
+
+	contentType := detectContentType(head)
+	if !slices.Contains(s.allowedMIMETypes, contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrMIMETypeNotAllowed, contentType)
+	}
+
+	// Размер известен заранее, только если файл целиком уместился в прочитанное начало.
+	size := int64(-1)
+	if n < sniffLen {
+		size = int64(n)
+	}
+	body := newLimitedReader(io.MultiReader(bytes.NewReader(head), r), s.maxSize)
+
+	id := types.NewAttachmentID()
+	key := blobKey(uploaderID, id)
+
+	if err := s.blobs.Put(ctx, key, body, size, contentType); err != nil {
+		// Хранилище прерывает запись при ошибке чтения и не сохраняет недописанный объект.
+		if body.exceeded() {
+			return nil, ErrTooLarge
+		}
+		return nil, fmt.Errorf("put blob: %v", err)
+	}
+
+	a, err := s.store.Attachment.Create().
+		SetID(id).
+		SetUploaderID(uploaderID).
+		SetFileName(sanitizeFileName(fileName)).
+		SetContentType(contentType).
+		SetSize(body.n).
+		SetBlobKey(key).
+		Save(ctx)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("create attachment: %v", err), s.blobs.Delete(ctx, key))
+	}
+
+	return s.withURL(ctx, a)
+}
+
+// Get возвращает вложение со свежей ссылкой на скачивание. Вложение доступно загрузившему его пользователю,
+// а после отправки сообщения - и собеседнику: клиенту чата или менеджеру проблемы, к которой относится сообщение.
+func (s *Service) Get(ctx context.Context, userID types.UserID, id types.AttachmentID) (*Attachment, error) {
+	a, err := s.store.Attachment.Query().
+		Where(
+			attachment.ID(id),
+			attachment.Or(
+				attachment.UploaderID(userID),
+				attachment.HasMessageWith(message.Or(
+					message.HasChatWith(chat.ClientID(userID)),
+					message.HasProblemWith(problem.ManagerID(userID)),
+				)),
+			),
+		).
+		Only(ctx)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("query attachment: %v", err)
+	}
+
+	return s.withURL(ctx, a)
+}
+
+func (s *Service) withURL(ctx context.Context, a *store.Attachment) (*Attachment, error) {
+	expiresAt := time.Now().Add(s.urlTTL)
+	u, err := s.blobs.SignedURL(ctx, a.BlobKey, s.urlTTL)
+	if err != nil {
+		return nil, fmt.Errorf("sign url: %v", err)
+	}
+
+	return &Attachment{
+		ID:           a.ID,
+		FileName:     a.FileName,
+		ContentType:  a.ContentType,
+		Size:         a.Size,
+		URL:          u,
+		URLExpiresAt: expiresAt,
+	}, nil
+}
+
+func detectContentType(data []byte) string {
+	ct := http.DetectContentType(data[:min(len(data), sniffLen)])
+	mediaType, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return ct
+	}
+	return mediaType
+}
+
+func blobKey(uploaderID types.UserID, id types.AttachmentID) string {
+	return uploaderID.String() + "/" + id.String()
+}
+
+// sanitizeFileName оставляет от имени файла только базовое имя без управляющих символов.
+func sanitizeFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	return name
+}
+
+// limitedReader считает прочитанные байты и возвращает ErrTooLarge, как только их становится больше limit.
+// Ошибка прерывает запись в хранилище, поэтому файл больше лимита не сохраняется целиком.
+type limitedReader struct {
+	r     io.Reader
+	limit int64
+	n     int64
+}
+
+func newLimitedReader(r io.Reader, limit int64) *limitedReader {
+	// Читаем не больше чем на байт сверх лимита, чтобы отличить файл ровно максимального размера от большего.
+	return &limitedReader{r: io.LimitReader(r, limit+1), limit: limit}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.exceeded() {
+		return n, ErrTooLarge
+	}
+	return n, err
+}
+
+func (l *limitedReader) exceeded() bool {
+	return l.n > l.limit
+}
@@ -0,0 +1,87 @@
+// Code generated by options-gen. DO NOT EDIT.
+package attachments
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/blobstore"
+	"github.com/FischukSergey/chat-service/internal/store"
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	store *store.Client,
+	blobs blobstore.BlobStore,
+	maxSize int64,
+	allowedMIMETypes []string,
+	urlTTL time.Duration,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from field tag (if present)
+
+	o.store = store
+
+	o.blobs = blobs
+
+	o.maxSize = maxSize
+
+	o.allowedMIMETypes = allowedMIMETypes
+
+	o.urlTTL = urlTTL
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("store", _validate_Options_store(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("blobs", _validate_Options_blobs(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("maxSize", _validate_Options_maxSize(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("allowedMIMETypes", _validate_Options_allowedMIMETypes(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("urlTTL", _validate_Options_urlTTL(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_store(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.store, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `store` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_blobs(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.blobs, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `blobs` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_maxSize(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.maxSize, "min=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `maxSize` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_allowedMIMETypes(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.allowedMIMETypes, "min=1,dive,required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `allowedMIMETypes` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_urlTTL(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.urlTTL, "min=1s"); err != nil {
+		return fmt461e464ebed9.Errorf("field `urlTTL` did not pass the test: %w", err)
+	}
+	return nil
+}
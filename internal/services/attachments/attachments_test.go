@@ -0,0 +1,173 @@
+package attachments_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/blobstore"
+	"github.com/FischukSergey/chat-service/internal/services/attachments"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const maxSize = 1024
+
+// pngHeader - сигнатура PNG, по которой определяется MIME-тип.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func newService(t *testing.T) (*attachments.Service, *store.Client, *blobstore.LocalStore) {
+	t.Helper()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	blobs, err := blobstore.NewLocal(blobstore.NewLocalOptions(
+		t.TempDir(), "http://localhost:8080", []byte("0123456789abcdef"),
+	))
+	require.NoError(t, err)
+
+	svc, err := attachments.New(attachments.NewOptions(
+		client,
+		blobs,
+		maxSize,
+		[]string{"image/png", "application/pdf"},
+		time.Minute,
+	))
+	require.NoError(t, err)
+	return svc, client, blobs
+}
+
+func TestService_Upload(t *testing.T) {
+	ctx := context.Background()
+	svc, client, blobs := newService(t)
+
+	uploaderID := types.NewUserID()
+	data := append(append([]byte{}, pngHeader...), make([]byte, 100)...)
+
+	a, err := svc.Upload(ctx, uploaderID, "../../screenshot.png", bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "screenshot.png", a.FileName)
+	assert.Equal(t, "image/png", a.ContentType)
+	assert.Equal(t, int64(len(data)), a.Size)
+	assert.NotEmpty(t, a.URL)
+	assert.True(t, a.URLExpiresAt.After(time.Now()))
+
+	stored := client.Attachment.GetX(ctx, a.ID)
+	assert.Equal(t, uploaderID, stored.UploaderID)
+
+	rc, err := blobs.Get(ctx, stored.BlobKey)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+
+	got, err := svc.Get(ctx, uploaderID, a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, a.ID, got.ID)
+
+	// Чужое вложение недоступно.
+	_, err = svc.Get(ctx, types.NewUserID(), a.ID)
+	require.ErrorIs(t, err, attachments.ErrNotFound)
+}
+
+func TestService_Upload_Rejected(t *testing.T) {
+	ctx := context.Background()
+	svc, client, _ := newService(t)
+
+	cases := []struct {
+		name   string
+		data   []byte
+		expErr error
+	}{
+		{
+			name:   "empty",
+			data:   nil,
+			expErr: attachments.ErrEmpty,
+		},
+		{
+			name:   "too large",
+			data:   append(append([]byte{}, pngHeader...), make([]byte, maxSize)...),
+			expErr: attachments.ErrTooLarge,
+		},
+		{
+			name:   "html disguised as png",
+			data:   []byte("<html><script>alert(1)</script></html>"),
+			expErr: attachments.ErrMIMETypeNotAllowed,
+		},
+		{
+			name:   "plain text",
+			data:   []byte(strings.Repeat("a", 100)),
+			expErr: attachments.ErrMIMETypeNotAllowed,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Upload(ctx, types.NewUserID(), "file.png", bytes.NewReader(tt.data))
+			require.ErrorIs(t, err, tt.expErr)
+		})
+	}
+
+	assert.Zero(t, client.Attachment.Query().CountX(ctx))
+}
+
+// endlessReader отдает бесконечный поток байт.
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+func TestService_Upload_Stream(t *testing.T) {
+	ctx := context.Background()
+	svc, client, _ := newService(t)
+
+	t.Run("exactly max size", func(t *testing.T) {
+		r := io.MultiReader(bytes.NewReader(pngHeader), io.LimitReader(endlessReader{}, maxSize-int64(len(pngHeader))))
+		a, err := svc.Upload(ctx, types.NewUserID(), "big.png", r)
+		require.NoError(t, err)
+		assert.Equal(t, int64(maxSize), a.Size)
+	})
+
+	t.Run("endless stream", func(t *testing.T) {
+		_, err := svc.Upload(ctx, types.NewUserID(), "endless.png", io.MultiReader(bytes.NewReader(pngHeader), endlessReader{}))
+		require.ErrorIs(t, err, attachments.ErrTooLarge)
+	})
+
+	assert.Equal(t, 1, client.Attachment.Query().CountX(ctx))
+}
+
+func TestService_Get_ChatParticipants(t *testing.T) {
+	ctx := context.Background()
+	svc, client, _ := newService(t)
+
+	clientID, managerID, otherID := types.NewUserID(), types.NewUserID(), types.NewUserID()
+	a, err := svc.Upload(ctx, clientID, "screenshot.png", bytes.NewReader(pngHeader))
+	require.NoError(t, err)
+
+	// Пока вложение не отправлено, его видит только загрузивший.
+	_, err = svc.Get(ctx, managerID, a.ID)
+	require.ErrorIs(t, err, attachments.ErrNotFound)
+
+	chat := client.Chat.Create().SetClientID(clientID).SaveX(ctx)
+	p := client.Problem.Create().SetChatID(chat.ID).SetManagerID(managerID).SaveX(ctx)
+	msg := client.Message.Create().SetChatID(chat.ID).SetProblemID(p.ID).SetAuthorID(clientID).SetBody("скриншот").SaveX(ctx)
+	client.Attachment.UpdateOneID(a.ID).SetMessageID(msg.ID).ExecX(ctx)
+
+	for _, userID := range []types.UserID{clientID, managerID} {
+		got, err := svc.Get(ctx, userID, a.ID)
+		require.NoError(t, err)
+		assert.Equal(t, a.ID, got.ID)
+	}
+
+	_, err = svc.Get(ctx, otherID, a.ID)
+	require.ErrorIs(t, err, attachments.ErrNotFound)
+}
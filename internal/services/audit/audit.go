@@ -0,0 +1,227 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/auditevent"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// Action - действие, попадающее в журнал аудита.
+type Action string
+
+const (
+	ActionAuthFailed      Action = Action(auditevent.ActionAuthFailed)
+	ActionMessageEdited   Action = Action(auditevent.ActionMessageEdited)
+	ActionMessageDeleted  Action = Action(auditevent.ActionMessageDeleted)
+	ActionProblemAssigned Action = Action(auditevent.ActionProblemAssigned)
+	ActionProblemClosed   Action = Action(auditevent.ActionProblemClosed)
+	ActionConfigChanged   Action = Action(auditevent.ActionConfigChanged)
+)
+
+// Validate проверяет, что действие известно журналу.
+func (a Action) Validate() error {
+	return auditevent.ActionValidator(auditevent.Action(a))
+}
+
+//go:generate options-gen -out-filename=audit_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	store *store.Client `option:"mandatory" validate:"required"`
+	// retention - сколько хранятся события аудита.
+	retention       time.Duration `option:"mandatory" validate:"min=1h"`
+	cleanupInterval time.Duration `validate:"min=1s"`
+	// queueSize - сколько событий может ждать записи в Run, см. Enqueue.
+	queueSize int `validate:"min=1"`
+	now       func() time.Time
+}
+
+var defaultOptions = Options{
+	cleanupInterval: time.Hour,
+	queueSize:       1024,
+	now:             time.Now,
+}
+
+// droppedReportInterval - как часто в лог попадает количество отброшенных событий.
+const droppedReportInterval = time.Minute
+
+// Event - событие аудита.
+type Event struct {
+	ID types.AuditEventID
+	// Actor - кто совершил действие. Пустой, если пользователь неизвестен.
+	Actor  string
+	Action Action
+	// Target - объект действия, например "message:<id>".
+	Target    string
+	Metadata  map[string]any
+	RequestID types.RequestID
+	IP        string
+	CreatedAt time.Time
+}
+
+// Recorder записывает события аудита в журнал и удаляет события старше срока хранения.
+// Журнал только дополняется: изменить записанное событие нельзя.
+type Recorder struct {
+	Options
+	lg *zap.Logger
+
+	queue   chan Event
+	dropped atomic.Int64
+
+	// pending - время постановки в очередь событий, еще не записанных Run, в порядке очереди.
+	pendingMu sync.Mutex
+	pending   []time.Time
+}
+
+func New(opts Options) (*Recorder, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &Recorder{
+		Options: opts,
+		lg:      logger.Named("audit"),
+		queue:   make(chan Event, opts.queueSize),
+	}, nil
+}
+
+// Record записывает событие. ID события заполняется журналом, CreatedAt - если не задан.
+func (r *Recorder) Record(ctx context.Context, e Event) error {
+	if err := e.Action.Validate(); err != nil {
+		return err
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = r.now()
+	}
+
+	create := r.store.AuditEvent.Create().
+		SetAction(auditevent.Action(e.Action)).
+		SetCreatedAt(e.CreatedAt)
+	if e.Actor != "" {
+		create.SetActor(e.Actor)
+	}
+	if e.Target != "" {
+		create.SetTarget(e.Target)
+	}
+	if len(e.Metadata) > 0 {
+		create.SetMetadata(e.Metadata)
+	}
+	if !e.RequestID.IsZero() {
+		create.SetRequestID(e.RequestID)
+	}
+	if e.IP != "" {
+		create.SetIP(e.IP)
+	}
+
+	if err := create.Exec(ctx); err != nil {
+		return fmt.Errorf("create audit event: %v", err)
+	}
+	return nil
+}
+
+// Enqueue ставит событие в очередь на запись в Run и не ждет базу. Используется для событий,
+// которые может порождать поток запросов (например, отказов в аутентификации): если очередь
+// переполнена, событие отбрасывается, а количество отброшенных событий попадает в лог.
+func (r *Recorder) Enqueue(e Event) {
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = r.now()
+	}
+
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+
+	select {
+	case r.queue <- e:
+		r.pending = append(r.pending, r.now())
+	default:
+		r.dropped.Add(1)
+	}
+}
+
+// QueueLag возвращает, сколько ждет записи самое старое событие очереди Enqueue, или 0, если очередь пуста.
+// Растет, если база не успевает записывать события или Run не запущен.
+func (r *Recorder) QueueLag() time.Duration {
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+
+	if len(r.pending) == 0 {
+		return 0
+	}
+	return r.now().Sub(r.pending[0])
+}
+
+// Run записывает события из очереди Enqueue и периодически удаляет события старше срока хранения,
+// пока не завершится контекст. При завершении записывает события, оставшиеся в очереди.
+func (r *Recorder) Run(ctx context.Context) error {
+	cleanup := time.NewTicker(r.cleanupInterval)
+	defer cleanup.Stop()
+	report := time.NewTicker(droppedReportInterval)
+	defer report.Stop()
+
+	// Запись события из очереди не прерывается остановкой, иначе оно потеряется.
+	writeCtx := context.WithoutCancel(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			r.flush(writeCtx)
+			return nil
+		case e := <-r.queue:
+			r.write(writeCtx, e)
+		case <-report.C:
+			r.reportDropped()
+		case <-cleanup.C:
+			if _, err := r.Cleanup(ctx); err != nil {
+				r.lg.Error("audit events cleanup", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (r *Recorder) flush(ctx context.Context) {
+	for {
+		select {
+		case e := <-r.queue:
+			r.write(ctx, e)
+		default:
+			r.reportDropped()
+			return
+		}
+	}
+}
+
+// write записывает событие из очереди. Событие считается ожидающим, пока запись не завершится.
+func (r *Recorder) write(ctx context.Context, e Event) {
+	if err := r.Record(ctx, e); err != nil {
+		r.lg.Error("record audit event", zap.String("action", string(e.Action)), zap.Error(err))
+	}
+
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+	r.pending = r.pending[1:]
+}
+
+func (r *Recorder) reportDropped() {
+	if n := r.dropped.Swap(0); n > 0 {
+		r.lg.Warn("audit queue is full, events dropped", zap.Int64("count", n))
+	}
+}
+
+// Cleanup удаляет события старше срока хранения и возвращает их количество.
+func (r *Recorder) Cleanup(ctx context.Context) (int, error) {
+	n, err := r.store.AuditEvent.Delete().
+		Where(auditevent.CreatedAtLT(r.now().Add(-r.retention))).
+		Exec(withCleanup(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("delete expired audit events: %v", err)
+	}
+	if n > 0 {
+		r.lg.Info("expired audit events deleted", zap.Int("count", n))
+	}
+	return n, nil
+}
@@ -0,0 +1,100 @@
+// Code generated by options-gen. DO NOT EDIT.
+package audit
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	store *store.Client,
+	retention time.Duration,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.store = defaultOptions.store
+
+	o.retention = defaultOptions.retention
+
+	o.cleanupInterval = defaultOptions.cleanupInterval
+
+	o.queueSize = defaultOptions.queueSize
+
+	o.now = defaultOptions.now
+
+	o.store = store
+
+	o.retention = retention
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithCleanupInterval(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.cleanupInterval = opt
+
+	}
+}
+
+// queueSize - сколько событий может ждать записи в Run, см. Enqueue.
+func WithQueueSize(opt int) OptOptionsSetter {
+	return func(o *Options) {
+		o.queueSize = opt
+
+	}
+}
+
+func WithNow(opt func() time.Time) OptOptionsSetter {
+	return func(o *Options) {
+		o.now = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("store", _validate_Options_store(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("retention", _validate_Options_retention(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("cleanupInterval", _validate_Options_cleanupInterval(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("queueSize", _validate_Options_queueSize(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_store(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.store, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `store` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_retention(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.retention, "min=1h"); err != nil {
+		return fmt461e464ebed9.Errorf("field `retention` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_cleanupInterval(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.cleanupInterval, "min=1s"); err != nil {
+		return fmt461e464ebed9.Errorf("field `cleanupInterval` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_queueSize(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.queueSize, "min=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `queueSize` did not pass the test: %w", err)
+	}
+	return nil
+}
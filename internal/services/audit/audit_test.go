@@ -0,0 +1,271 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/audit"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/auditevent"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder := newRecorder(t, client, &now)
+
+	requestID := types.NewRequestID()
+
+	record := func(e audit.Event) {
+		t.Helper()
+		require.NoError(t, recorder.Record(ctx, e))
+		now = now.Add(time.Minute)
+	}
+	record(audit.Event{
+		Action:    audit.ActionAuthFailed,
+		Target:    "route:/v1/getHistory",
+		Metadata:  map[string]any{"reason": "token is not active"},
+		RequestID: requestID,
+		IP:        "10.0.0.1",
+	})
+	record(audit.Event{Actor: "admin", Action: audit.ActionConfigChanged, Target: "log_level:server-client"})
+	record(audit.Event{Actor: "admin", Action: audit.ActionConfigChanged, Target: "log_level:audit"})
+	record(audit.Event{Action: audit.ActionConfigChanged, Target: "config"})
+
+	t.Run("unknown action", func(t *testing.T) {
+		require.Error(t, recorder.Record(ctx, audit.Event{Action: "unknown"}))
+	})
+
+	t.Run("all events newest first", func(t *testing.T) {
+		page, err := recorder.Query(ctx, audit.Filter{})
+		require.NoError(t, err)
+		require.Len(t, page.Events, 4)
+		assert.Empty(t, page.NextCursor)
+		assert.Equal(t, audit.ActionConfigChanged, page.Events[0].Action)
+
+		authFailed := page.Events[3]
+		assert.Equal(t, audit.ActionAuthFailed, authFailed.Action)
+		assert.Empty(t, authFailed.Actor)
+		assert.Equal(t, "route:/v1/getHistory", authFailed.Target)
+		assert.Equal(t, map[string]any{"reason": "token is not active"}, authFailed.Metadata)
+		assert.Equal(t, requestID, authFailed.RequestID)
+		assert.Equal(t, "10.0.0.1", authFailed.IP)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		page, err := recorder.Query(ctx, audit.Filter{Actor: "admin"})
+		require.NoError(t, err)
+		assert.Len(t, page.Events, 2)
+
+		page, err = recorder.Query(ctx, audit.Filter{Action: audit.ActionAuthFailed})
+		require.NoError(t, err)
+		require.Len(t, page.Events, 1)
+		assert.Equal(t, "route:/v1/getHistory", page.Events[0].Target)
+
+		start := time.Date(2024, 6, 1, 12, 1, 0, 0, time.UTC)
+		page, err = recorder.Query(ctx, audit.Filter{From: start, To: start.Add(2 * time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, page.Events, 2)
+		assert.Equal(t, "log_level:audit", page.Events[0].Target)
+		assert.Equal(t, "log_level:server-client", page.Events[1].Target)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		var actions []audit.Action
+		var cursor string
+		for {
+			page, err := recorder.Query(ctx, audit.Filter{PageSize: 3, Cursor: cursor})
+			require.NoError(t, err)
+			for _, e := range page.Events {
+				actions = append(actions, e.Action)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+		assert.Equal(t, []audit.Action{
+			audit.ActionConfigChanged,
+			audit.ActionConfigChanged,
+			audit.ActionConfigChanged,
+			audit.ActionAuthFailed,
+		}, actions)
+
+		_, err := recorder.Query(ctx, audit.Filter{Cursor: "invalid"})
+		require.ErrorIs(t, err, audit.ErrInvalidCursor)
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		// Срок хранения - час: граница сдвигается на 12:02, и два первых события устаревают.
+		now = now.Add(time.Hour - 2*time.Minute)
+
+		n, err := recorder.Cleanup(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		page, err := recorder.Query(ctx, audit.Filter{})
+		require.NoError(t, err)
+		assert.Len(t, page.Events, 2)
+	})
+}
+
+func TestRecorder_Enqueue(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder, err := audit.New(audit.NewOptions(client, time.Hour,
+		audit.WithQueueSize(2),
+		audit.WithNow(func() time.Time { return now })))
+	require.NoError(t, err)
+
+	// Очередь не ждет базу: третье событие не помещается и отбрасывается.
+	for i := range 3 {
+		recorder.Enqueue(audit.Event{Action: audit.ActionAuthFailed, Target: fmt.Sprintf("route:%d", i)})
+	}
+
+	// Пока Run не запущен, события ждут записи.
+	now = now.Add(time.Minute)
+	assert.Equal(t, time.Minute, recorder.QueueLag())
+
+	done := make(chan error)
+	go func() { done <- recorder.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		return recorder.QueueLag() == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, client.AuditEvent.Query().CountX(ctx))
+
+	// События, оставшиеся в очереди при остановке, записываются.
+	cancel()
+	require.NoError(t, <-done)
+	recorder.Enqueue(audit.Event{Action: audit.ActionAuthFailed, Target: "route:late"})
+	require.NoError(t, recorder.Run(ctx))
+
+	page, err := recorder.Query(context.Background(), audit.Filter{})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 3)
+	assert.Equal(t, now, page.Events[0].CreatedAt.UTC())
+}
+
+func TestHook(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+	client.AuditEvent.Use(audit.Hook())
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder := newRecorder(t, client, &now)
+	require.NoError(t, recorder.Record(ctx, audit.Event{Action: audit.ActionConfigChanged, Target: "config"}))
+	e := client.AuditEvent.Query().OnlyX(ctx)
+
+	t.Run("update", func(t *testing.T) {
+		// Поля событий неизменяемые, но хук не пропускает и обновления без полей, например через модификаторы SQL.
+		require.ErrorIs(t, client.AuditEvent.UpdateOne(e).Exec(ctx), audit.ErrImmutable)
+
+		_, err := client.AuditEvent.Update().Save(ctx)
+		require.ErrorIs(t, err, audit.ErrImmutable)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.ErrorIs(t, client.AuditEvent.DeleteOne(e).Exec(ctx), audit.ErrImmutable)
+
+		_, err := client.AuditEvent.Delete().Exec(ctx)
+		require.ErrorIs(t, err, audit.ErrImmutable)
+	})
+
+	t.Run("retention cleanup", func(t *testing.T) {
+		now = now.Add(2 * time.Hour)
+
+		n, err := recorder.Cleanup(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+}
+
+func TestRecorder_StoreHooks(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	recorder := newRecorder(t, client, &now)
+	client.Message.Use(recorder.MessageHook())
+	client.Problem.Use(recorder.ProblemHook())
+
+	clientID, managerID := types.NewUserID(), types.NewUserID()
+	chat := client.Chat.Create().SetClientID(clientID).SaveX(ctx)
+	p := client.Problem.Create().SetChatID(chat.ID).SetManagerID(managerID).SaveX(ctx)
+	client.Problem.UpdateOne(p).SetStatus(problem.StatusInProgress).ExecX(ctx)
+	client.Problem.UpdateOne(p).SetStatus(problem.StatusResolved).SetResolvedAt(now).ExecX(ctx)
+
+	msg := client.Message.Create().SetChatID(chat.ID).SetAuthorID(clientID).SetBody("hello").SaveX(ctx)
+	client.Message.UpdateOne(msg).SetIsVisibleForClient(false).ExecX(ctx)
+	client.Message.DeleteOne(msg).ExecX(ctx)
+
+	// Изменения откаченной транзакции в журнал не попадают.
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	other := tx.Message.Create().SetChatID(chat.ID).SetAuthorID(clientID).SetBody("draft").SaveX(ctx)
+	tx.Message.UpdateOne(other).SetBody("edited").ExecX(ctx)
+	require.NoError(t, tx.Rollback())
+
+	// Run с завершенным контекстом записывает события из очереди.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	require.NoError(t, recorder.Run(cancelled))
+
+	events := client.AuditEvent.Query().Order(auditevent.ByCreatedAt(), auditevent.ByAction()).AllX(ctx)
+	require.Len(t, events, 4)
+
+	byAction := make(map[auditevent.Action]*store.AuditEvent, len(events))
+	for _, e := range events {
+		byAction[e.Action] = e
+	}
+
+	assigned := byAction[auditevent.ActionProblemAssigned]
+	require.NotNil(t, assigned)
+	assert.Equal(t, "problem:"+p.ID.String(), assigned.Target)
+	assert.Equal(t, managerID.String(), assigned.Metadata["manager_id"])
+
+	closed := byAction[auditevent.ActionProblemClosed]
+	require.NotNil(t, closed)
+	assert.Equal(t, "problem:"+p.ID.String(), closed.Target)
+
+	edited := byAction[auditevent.ActionMessageEdited]
+	require.NotNil(t, edited)
+	assert.Equal(t, "message:"+msg.ID.String(), edited.Target)
+	assert.Equal(t, []any{"is_visible_for_client"}, edited.Metadata["fields"])
+
+	deleted := byAction[auditevent.ActionMessageDeleted]
+	require.NotNil(t, deleted)
+	assert.Equal(t, "message:"+msg.ID.String(), deleted.Target)
+}
+
+func newRecorder(t *testing.T, client *store.Client, now *time.Time) *audit.Recorder {
+	t.Helper()
+
+	recorder, err := audit.New(audit.NewOptions(client, time.Hour,
+		audit.WithNow(func() time.Time { return *now })))
+	require.NoError(t, err)
+	return recorder
+}
@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/hook"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// ErrImmutable - попытка изменить событие аудита или удалить его не по сроку хранения.
+var ErrImmutable = errors.New("audit events are immutable")
+
+type cleanupCtxKey struct{}
+
+// Hook возвращает ent-хук, который запрещает изменять события аудита и удалять их где-либо, кроме Cleanup.
+func Hook() store.Hook {
+	return hook.On(func(next store.Mutator) store.Mutator {
+		return hook.AuditEventFunc(func(ctx context.Context, m *store.AuditEventMutation) (store.Value, error) {
+			if m.Op().Is(ent.OpDelete) && ctx.Value(cleanupCtxKey{}) != nil {
+				return next.Mutate(ctx, m)
+			}
+			return nil, ErrImmutable
+		})
+	}, ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne)
+}
+
+// MessageHook возвращает ent-хук, который ставит в очередь журнала события изменения и удаления сообщений.
+// В событие попадают только имена измененных полей, но не их значения.
+func (r *Recorder) MessageHook() store.Hook {
+	return hook.On(func(next store.Mutator) store.Mutator {
+		return hook.MessageFunc(func(ctx context.Context, m *store.MessageMutation) (store.Value, error) {
+			// Идентификаторы берутся до мутации: после удаления их уже не найти.
+			ids, err := m.IDs(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("get message ids: %v", err)
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+
+			action, metadata := ActionMessageEdited, map[string]any{"fields": m.Fields()}
+			if m.Op().Is(ent.OpDelete | ent.OpDeleteOne) {
+				action, metadata = ActionMessageDeleted, nil
+			}
+			events := make([]Event, 0, len(ids))
+			for _, id := range ids {
+				events = append(events, Event{Action: action, Target: "message:" + id.String(), Metadata: metadata})
+			}
+			r.enqueueOnCommit(m, events...)
+			return v, nil
+		})
+	}, ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne)
+}
+
+// ProblemHook возвращает ent-хук, который ставит в очередь журнала события назначения менеджера
+// (manager_id задается при создании проблемы) и решения проблемы (установка resolved_at).
+func (r *Recorder) ProblemHook() store.Hook {
+	return hook.On(func(next store.Mutator) store.Mutator {
+		return hook.ProblemFunc(func(ctx context.Context, m *store.ProblemMutation) (store.Value, error) {
+			managerID, assigned := m.ManagerID()
+			resolvedAt, resolved := m.ResolvedAt()
+			if !assigned && !resolved {
+				return next.Mutate(ctx, m)
+			}
+
+			var ids []types.ProblemID
+			if m.Op().Is(ent.OpCreate) {
+				id, _ := m.ID()
+				ids = append(ids, id)
+			} else {
+				var err error
+				if ids, err = m.IDs(ctx); err != nil {
+					return nil, fmt.Errorf("get problem ids: %v", err)
+				}
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+
+			var events []Event
+			for _, id := range ids {
+				target := "problem:" + id.String()
+				if assigned {
+					events = append(events, Event{
+						Action:   ActionProblemAssigned,
+						Target:   target,
+						Metadata: map[string]any{"manager_id": managerID.String()},
+					})
+				}
+				if resolved {
+					events = append(events, Event{
+						Action:   ActionProblemClosed,
+						Target:   target,
+						Metadata: map[string]any{"resolved_at": resolvedAt},
+					})
+				}
+			}
+			r.enqueueOnCommit(m, events...)
+			return v, nil
+		})
+	}, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne)
+}
+
+// enqueueOnCommit ставит события в очередь сразу или, если мутация выполняется в транзакции, после ее фиксации:
+// изменения откаченной транзакции в журнал не попадают.
+func (r *Recorder) enqueueOnCommit(m interface{ Tx() (*store.Tx, error) }, events ...Event) {
+	tx, err := m.Tx()
+	if err != nil {
+		for _, e := range events {
+			r.Enqueue(e)
+		}
+		return
+	}
+
+	tx.OnCommit(func(next store.Committer) store.Committer {
+		return store.CommitFunc(func(ctx context.Context, tx *store.Tx) error {
+			if err := next.Commit(ctx, tx); err != nil {
+				return err
+			}
+			for _, e := range events {
+				r.Enqueue(e)
+			}
+			return nil
+		})
+	})
+}
+
+func withCleanup(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cleanupCtxKey{}, struct{}{})
+}
@@ -0,0 +1,139 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/auditevent"
+	"github.com/FischukSergey/chat-service/internal/store/predicate"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Filter - условия выборки событий аудита. Пустые поля не ограничивают выборку.
+type Filter struct {
+	Actor  string
+	Action Action
+	Target string
+	// From и To ограничивают время события: From включительно, To - не включительно.
+	From     time.Time
+	To       time.Time
+	PageSize int
+	Cursor   string
+}
+
+type Page struct {
+	Events []Event
+	// NextCursor пустой, если следующей страницы нет.
+	NextCursor string
+}
+
+type cursor struct {
+	CreatedAt time.Time          `json:"created_at"`
+	ID        types.AuditEventID `json:"id"`
+}
+
+// Query возвращает события аудита, упорядоченные от новых к старым.
+func (r *Recorder) Query(ctx context.Context, f Filter) (Page, error) {
+	pageSize := f.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	pageSize = min(pageSize, maxPageSize)
+
+	var where []predicate.AuditEvent
+	if f.Actor != "" {
+		where = append(where, auditevent.Actor(f.Actor))
+	}
+	if f.Action != "" {
+		if err := f.Action.Validate(); err != nil {
+			return Page{}, err
+		}
+		where = append(where, auditevent.ActionEQ(auditevent.Action(f.Action)))
+	}
+	if f.Target != "" {
+		where = append(where, auditevent.Target(f.Target))
+	}
+	if !f.From.IsZero() {
+		where = append(where, auditevent.CreatedAtGTE(f.From))
+	}
+	if !f.To.IsZero() {
+		where = append(where, auditevent.CreatedAtLT(f.To))
+	}
+	if f.Cursor != "" {
+		c, err := decodeCursor(f.Cursor)
+		if err != nil {
+			return Page{}, err
+		}
+		where = append(where, auditevent.Or(
+			auditevent.CreatedAtLT(c.CreatedAt),
+			auditevent.And(auditevent.CreatedAt(c.CreatedAt), auditevent.IDLT(c.ID)),
+		))
+	}
+
+	// Запрашиваем на одно событие больше, чтобы узнать, есть ли следующая страница.
+	rows, err := r.store.AuditEvent.Query().
+		Where(where...).
+		Order(auditevent.ByCreatedAt(sql.OrderDesc()), auditevent.ByID(sql.OrderDesc())).
+		Limit(pageSize + 1).
+		All(ctx)
+	if err != nil {
+		return Page{}, fmt.Errorf("query audit events: %v", err)
+	}
+
+	var page Page
+	if len(rows) > pageSize {
+		rows = rows[:pageSize]
+		last := rows[len(rows)-1]
+		page.NextCursor = encodeCursor(cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+	page.Events = make([]Event, 0, len(rows))
+	for _, row := range rows {
+		page.Events = append(page.Events, adaptEvent(row))
+	}
+	return page, nil
+}
+
+func adaptEvent(e *store.AuditEvent) Event {
+	return Event{
+		ID:        e.ID,
+		Actor:     e.Actor,
+		Action:    Action(e.Action),
+		Target:    e.Target,
+		Metadata:  e.Metadata,
+		RequestID: e.RequestID,
+		IP:        e.IP,
+		CreatedAt: e.CreatedAt,
+	}
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cursor{}, ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID.IsZero() || c.CreatedAt.IsZero() {
+		return cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,163 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Формат зашифрованного значения:
+//
+//	enc:v1:<key id>:<base64(обернутый ключ данных)>:<base64(nonce || шифротекст)>
+//
+// Ключ данных (DEK) генерируется для каждого значения и шифруется мастер-ключом (KEK).
+const (
+	envelopePrefix = "enc:v1:"
+	envelopeParts  = 3
+	keySize        = 32
+)
+
+var (
+	ErrUnknownKey        = errors.New("unknown master key")
+	ErrMalformedEnvelope = errors.New("malformed envelope")
+)
+
+var b64 = base64.RawStdEncoding
+
+//go:generate options-gen -out-filename=encryptor_options.gen.go -from-struct=Options
+type Options struct {
+	currentKeyID string            `option:"mandatory" validate:"required,excludesall=:"`
+	masterKeys   map[string][]byte `option:"mandatory" validate:"min=1,dive,keys,required,excludesall=:,endkeys,len=32"`
+}
+
+// Encryptor реализует конвертное шифрование AES-256-GCM.
+// Новые значения шифруются текущим мастер-ключом, расшифровываются - любым известным.
+type Encryptor struct {
+	currentKeyID string
+	keys         map[string]cipher.AEAD
+}
+
+func New(opts Options) (*Encryptor, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+
+	keys := make(map[string]cipher.AEAD, len(opts.masterKeys))
+	for id, key := range opts.masterKeys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("init master key %q: %v", id, err)
+		}
+		keys[id] = aead
+	}
+
+	if _, ok := keys[opts.currentKeyID]; !ok {
+		return nil, fmt.Errorf("current key %q: %w", opts.currentKeyID, ErrUnknownKey)
+	}
+
+	return &Encryptor{
+		currentKeyID: opts.currentKeyID,
+		keys:         keys,
+	}, nil
+}
+
+// CurrentKeyID возвращает идентификатор мастер-ключа, которым шифруются новые значения.
+func (e *Encryptor) CurrentKeyID() string {
+	return e.currentKeyID
+}
+
+// Encrypt шифрует значение текущим мастер-ключом.
+func (e *Encryptor) Encrypt(plaintext string) (string, error) {
+	dek := make([]byte, keySize)
+	if _, err := rand.Read(dek); err != nil {
+		return "", fmt.Errorf("generate data key: %v", err)
+	}
+
+	wrapped, err := seal(e.keys[e.currentKeyID], dek)
+	if err != nil {
+		return "", fmt.Errorf("wrap data key: %v", err)
+	}
+
+	dataAEAD, err := newAEAD(dek)
+	if err != nil {
+		return "", fmt.Errorf("init data key: %v", err)
+	}
+	ciphertext, err := seal(dataAEAD, []byte(plaintext))
+	if err != nil {
+		return "", fmt.Errorf("encrypt value: %v", err)
+	}
+
+	return envelopePrefix + e.currentKeyID + ":" + b64.EncodeToString(wrapped) + ":" + b64.EncodeToString(ciphertext), nil
+}
+
+// Decrypt расшифровывает значение, полученное от Encrypt.
+// Зашифровано ли сообщение, определяет IsEncrypted, а не формат значения:
+// пользователь может прислать текст, похожий на конверт.
+func (e *Encryptor) Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, envelopePrefix) {
+		return "", ErrMalformedEnvelope
+	}
+
+	parts := strings.Split(strings.TrimPrefix(value, envelopePrefix), ":")
+	if len(parts) != envelopeParts {
+		return "", ErrMalformedEnvelope
+	}
+	keyID, wrappedB64, ciphertextB64 := parts[0], parts[1], parts[2]
+
+	kek, ok := e.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("key %q: %w", keyID, ErrUnknownKey)
+	}
+
+	wrapped, err := b64.DecodeString(wrappedB64)
+	if err != nil {
+		return "", fmt.Errorf("decode data key: %w", ErrMalformedEnvelope)
+	}
+	dek, err := open(kek, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("unwrap data key: %v", err)
+	}
+
+	ciphertext, err := b64.DecodeString(ciphertextB64)
+	if err != nil {
+		return "", fmt.Errorf("decode ciphertext: %w", ErrMalformedEnvelope)
+	}
+	dataAEAD, err := newAEAD(dek)
+	if err != nil {
+		return "", fmt.Errorf("init data key: %v", err)
+	}
+	plaintext, err := open(dataAEAD, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decrypt value: %v", err)
+	}
+
+	return string(plaintext), nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func open(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, ErrMalformedEnvelope
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
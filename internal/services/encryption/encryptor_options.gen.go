@@ -0,0 +1,51 @@
+// Code generated by options-gen. DO NOT EDIT.
+package encryption
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	currentKeyID string,
+	masterKeys map[string][]byte,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from field tag (if present)
+
+	o.currentKeyID = currentKeyID
+
+	o.masterKeys = masterKeys
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("currentKeyID", _validate_Options_currentKeyID(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("masterKeys", _validate_Options_masterKeys(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_currentKeyID(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.currentKeyID, "required,excludesall=:"); err != nil {
+		return fmt461e464ebed9.Errorf("field `currentKeyID` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_masterKeys(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.masterKeys, "min=1,dive,keys,required,excludesall=:,endkeys,len=32"); err != nil {
+		return fmt461e464ebed9.Errorf("field `masterKeys` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package encryption_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/encryption"
+)
+
+var (
+	key1 = bytes.Repeat([]byte{1}, 32)
+	key2 = bytes.Repeat([]byte{2}, 32)
+)
+
+func TestEncryptor_EncryptDecrypt(t *testing.T) {
+	e, err := encryption.New(encryption.NewOptions("k1", map[string][]byte{"k1": key1}))
+	require.NoError(t, err)
+
+	const plaintext = "Привет! Не могу снять денег с карты"
+
+	encrypted, err := e.Encrypt(plaintext)
+	require.NoError(t, err)
+	assert.NotContains(t, encrypted, plaintext)
+	assert.True(t, strings.HasPrefix(encrypted, "enc:v1:k1:"))
+
+	encrypted2, err := e.Encrypt(plaintext)
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, encrypted2, "each value must use its own data key and nonce")
+
+	decrypted, err := e.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	t.Run("plaintext is not an envelope", func(t *testing.T) {
+		_, err := e.Decrypt(plaintext)
+		require.ErrorIs(t, err, encryption.ErrMalformedEnvelope)
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		tampered := encrypted[:len(encrypted)-2] + "AA"
+		_, err := e.Decrypt(tampered)
+		require.Error(t, err)
+	})
+
+	t.Run("malformed envelope", func(t *testing.T) {
+		_, err := e.Decrypt("enc:v1:k1:abc")
+		require.ErrorIs(t, err, encryption.ErrMalformedEnvelope)
+	})
+}
+
+func TestEncryptor_KeyRotation(t *testing.T) {
+	old, err := encryption.New(encryption.NewOptions("k1", map[string][]byte{"k1": key1}))
+	require.NoError(t, err)
+
+	encrypted, err := old.Encrypt("secret")
+	require.NoError(t, err)
+
+	rotated, err := encryption.New(encryption.NewOptions("k2", map[string][]byte{"k1": key1, "k2": key2}))
+	require.NoError(t, err)
+	assert.Equal(t, "k2", rotated.CurrentKeyID())
+
+	decrypted, err := rotated.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", decrypted)
+
+	withoutOldKey, err := encryption.New(encryption.NewOptions("k2", map[string][]byte{"k2": key2}))
+	require.NoError(t, err)
+	_, err = withoutOldKey.Decrypt(encrypted)
+	require.ErrorIs(t, err, encryption.ErrUnknownKey)
+}
+
+func TestNew_InvalidOptions(t *testing.T) {
+	_, err := encryption.New(encryption.NewOptions("k1", map[string][]byte{"k2": key2}))
+	require.Error(t, err)
+
+	_, err = encryption.New(encryption.NewOptions("k1", map[string][]byte{"k1": []byte("short")}))
+	require.Error(t, err)
+
+	_, err = encryption.New(encryption.NewOptions("k:1", map[string][]byte{"k:1": key1}))
+	require.Error(t, err)
+}
+
+func TestReadMasterKey(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(key1)
+
+	t.Run("from file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "master.key")
+		require.NoError(t, os.WriteFile(file, []byte(encoded+"\n"), 0o600))
+
+		key, err := encryption.ReadMasterKey(file, "")
+		require.NoError(t, err)
+		assert.Equal(t, key1, key)
+	})
+
+	t.Run("from env", func(t *testing.T) {
+		t.Setenv("TEST_CHAT_SERVICE_MASTER_KEY", encoded)
+
+		key, err := encryption.ReadMasterKey("", "TEST_CHAT_SERVICE_MASTER_KEY")
+		require.NoError(t, err)
+		assert.Equal(t, key1, key)
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		t.Setenv("TEST_CHAT_SERVICE_MASTER_KEY", base64.StdEncoding.EncodeToString([]byte("short")))
+
+		_, err := encryption.ReadMasterKey("", "TEST_CHAT_SERVICE_MASTER_KEY")
+		require.Error(t, err)
+	})
+
+	t.Run("no source", func(t *testing.T) {
+		_, err := encryption.ReadMasterKey("", "")
+		require.Error(t, err)
+	})
+}
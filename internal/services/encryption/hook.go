@@ -0,0 +1,103 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+
+	"entgo.io/ent"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/hook"
+)
+
+// Hook возвращает ent-хук, шифрующий body и original_body сообщения перед записью в хранилище.
+// Возвращаемая из мутации сущность содержит исходные (расшифрованные) значения.
+func (e *Encryptor) Hook() store.Hook {
+	return hook.On(func(next store.Mutator) store.Mutator {
+		return hook.MessageFunc(func(ctx context.Context, m *store.MessageMutation) (store.Value, error) {
+			body, hasBody := m.Body()
+			originalBody, hasOriginalBody := m.OriginalBody()
+			if !hasBody && !hasOriginalBody {
+				return next.Mutate(ctx, m)
+			}
+
+			if hasBody {
+				encrypted, err := e.Encrypt(body)
+				if err != nil {
+					return nil, fmt.Errorf("encrypt body: %v", err)
+				}
+				m.SetBody(encrypted)
+				m.SetBodyKeyID(e.currentKeyID)
+			}
+			if hasOriginalBody {
+				encrypted, err := e.Encrypt(originalBody)
+				if err != nil {
+					return nil, fmt.Errorf("encrypt original body: %v", err)
+				}
+				m.SetOriginalBody(encrypted)
+			}
+
+			v, err := next.Mutate(ctx, m)
+			if err != nil {
+				return nil, err
+			}
+
+			if msg, ok := v.(*store.Message); ok {
+				if err := e.decryptMessage(msg); err != nil {
+					return nil, err
+				}
+			}
+			return v, nil
+		})
+	}, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne)
+}
+
+// Interceptor возвращает ent-интерсептор, расшифровывающий сообщения, полученные из хранилища.
+// Проекции отдельных полей (Select(...).Strings() и т.п.) не расшифровываются.
+func (e *Encryptor) Interceptor() store.Interceptor {
+	return store.InterceptFunc(func(next store.Querier) store.Querier {
+		return store.QuerierFunc(func(ctx context.Context, q store.Query) (store.Value, error) {
+			v, err := next.Query(ctx, q)
+			if err != nil {
+				return nil, err
+			}
+
+			if msgs, ok := v.([]*store.Message); ok {
+				for _, msg := range msgs {
+					if err := e.decryptMessage(msg); err != nil {
+						return nil, fmt.Errorf("message %v: %v", msg.ID, err)
+					}
+				}
+			}
+			return v, nil
+		})
+	})
+}
+
+func (e *Encryptor) decryptMessage(msg *store.Message) error {
+	if !IsEncrypted(msg) {
+		return nil
+	}
+
+	body, err := e.Decrypt(msg.Body)
+	if err != nil {
+		return fmt.Errorf("decrypt body: %v", err)
+	}
+	msg.Body = body
+
+	if msg.OriginalBody != nil {
+		originalBody, err := e.Decrypt(*msg.OriginalBody)
+		if err != nil {
+			return fmt.Errorf("decrypt original body: %v", err)
+		}
+		msg.OriginalBody = &originalBody
+	}
+	return nil
+}
+
+// IsEncrypted проверяет, зашифрованы ли body и original_body сообщения.
+// Признак шифрования - ключ в body_key_id, который хук записывает вместе с зашифрованным текстом.
+// Сообщения без ключа записаны до включения шифрования и хранятся как есть.
+func IsEncrypted(msg *store.Message) bool {
+	return msg.BodyKeyID != nil
+}
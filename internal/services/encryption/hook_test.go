@@ -0,0 +1,133 @@
+package encryption_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/encryption"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/store/message"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestEncryptor_HookAndRotation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const dsn = "file:encryption.TestEncryptor_HookAndRotation?mode=memory&cache=shared&_fk=1"
+
+	// Сообщение, записанное до включения шифрования.
+	plainClient := enttest.Open(t, "sqlite3", dsn)
+	defer func() { require.NoError(t, plainClient.Close()) }()
+
+	clientID := types.NewUserID()
+	chat := plainClient.Chat.Create().SetClientID(clientID).SaveX(ctx)
+	legacy := plainClient.Message.Create().
+		SetChatID(chat.ID).
+		SetAuthorID(clientID).
+		SetBody("legacy message").
+		SaveX(ctx)
+	// Текст, похожий на конверт, не должен приниматься за зашифрованный.
+	lookalike := plainClient.Message.Create().
+		SetChatID(chat.ID).
+		SetAuthorID(clientID).
+		SetBody("enc:v1:k1:abc:def").
+		SaveX(ctx)
+
+	// Шифрование ключом k1.
+	e1, err := encryption.New(encryption.NewOptions("k1", map[string][]byte{"k1": key1}))
+	require.NoError(t, err)
+	client1 := openEncrypted(t, dsn, e1)
+
+	msg := client1.Message.Create().
+		SetChatID(chat.ID).
+		SetAuthorID(clientID).
+		SetBody("Мой пин-код не подходит").
+		SetOriginalBody("original").
+		SaveX(ctx)
+	assert.Equal(t, "Мой пин-код не подходит", msg.Body, "created entity must contain plaintext")
+
+	rawBodies := rawBodiesByID(ctx, t, plainClient)
+	assert.True(t, strings.HasPrefix(rawBodies[msg.ID], "enc:v1:k1:"))
+	assert.Equal(t, "legacy message", rawBodies[legacy.ID])
+
+	got := client1.Message.GetX(ctx, msg.ID)
+	assert.True(t, encryption.IsEncrypted(got))
+	assert.Equal(t, "Мой пин-код не подходит", got.Body)
+	require.NotNil(t, got.OriginalBody)
+	assert.Equal(t, "original", *got.OriginalBody)
+	require.NotNil(t, got.BodyKeyID)
+	assert.Equal(t, "k1", *got.BodyKeyID)
+
+	gotLookalike := client1.Message.GetX(ctx, lookalike.ID)
+	assert.False(t, encryption.IsEncrypted(gotLookalike))
+	assert.Equal(t, "enc:v1:k1:abc:def", gotLookalike.Body)
+
+	// Ротация на ключ k2.
+	e2, err := encryption.New(encryption.NewOptions("k2", map[string][]byte{"k1": key1, "k2": key2}))
+	require.NoError(t, err)
+	client2 := openEncrypted(t, dsn, e2)
+
+	n, err := e2.RotateMessages(ctx, client2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+
+	n, err = e2.RotateMessages(ctx, client2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	keyIDs := client2.Message.Query().Select(message.FieldBodyKeyID).StringsX(ctx)
+	assert.Equal(t, []string{"k2", "k2", "k2"}, keyIDs)
+
+	// После ротации старый ключ больше не нужен.
+	e3, err := encryption.New(encryption.NewOptions("k2", map[string][]byte{"k2": key2}))
+	require.NoError(t, err)
+	client3 := openEncrypted(t, dsn, e3)
+
+	bodies := map[types.MessageID]string{}
+	for _, m := range client3.Message.Query().AllX(ctx) {
+		bodies[m.ID] = m.Body
+	}
+	assert.Equal(t, map[types.MessageID]string{
+		legacy.ID:    "legacy message",
+		lookalike.ID: "enc:v1:k1:abc:def",
+		msg.ID:       "Мой пин-код не подходит",
+	}, bodies)
+
+	for id, body := range rawBodiesByID(ctx, t, plainClient) {
+		assert.True(t, strings.HasPrefix(body, "enc:v1:k2:"), id)
+	}
+}
+
+func openEncrypted(t *testing.T, dsn string, e *encryption.Encryptor) *store.Client {
+	t.Helper()
+
+	client := enttest.Open(t, "sqlite3", dsn)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+
+	client.Message.Use(e.Hook())
+	client.Message.Intercept(e.Interceptor())
+	return client
+}
+
+func rawBodiesByID(ctx context.Context, t *testing.T, client *store.Client) map[types.MessageID]string {
+	t.Helper()
+
+	var rows []struct {
+		ID   types.MessageID `json:"id"`
+		Body string          `json:"body"`
+	}
+	client.Message.Query().Select(message.FieldID, message.FieldBody).ScanX(ctx, &rows)
+
+	result := make(map[types.MessageID]string, len(rows))
+	for _, r := range rows {
+		result[r.ID] = r.Body
+	}
+	return result
+}
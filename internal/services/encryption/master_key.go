@@ -0,0 +1,42 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadMasterKey читает мастер-ключ, закодированный в base64, из файла или переменной окружения.
+// Файл имеет приоритет над переменной окружения.
+func ReadMasterKey(file, env string) ([]byte, error) {
+	var encoded string
+	switch {
+	case file != "":
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read key file: %v", err)
+		}
+		encoded = string(data)
+
+	case env != "":
+		v, ok := os.LookupEnv(env)
+		if !ok {
+			return nil, fmt.Errorf("env %q is not set", env)
+		}
+		encoded = v
+
+	default:
+		return nil, errors.New("neither key file nor env is specified")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("decode key: %v", err)
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid key size: got %d bytes, want %d", len(key), keySize)
+	}
+	return key, nil
+}
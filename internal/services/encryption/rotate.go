@@ -0,0 +1,73 @@
+package encryption
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/message"
+)
+
+// RotateMessages перешифровывает текущим мастер-ключом сообщения, зашифрованные другими ключами
+// или еще не зашифрованные. Работает пачками по batchSize сообщений, каждая пачка - в своей транзакции.
+// Клиент должен использовать Hook и Interceptor этого же Encryptor и не должен иметь других хуков
+// сообщений: перешифрование не должно менять текст уже сохраненных сообщений.
+// Возвращает количество перешифрованных сообщений.
+func (e *Encryptor) RotateMessages(ctx context.Context, client *store.Client, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		return 0, errors.New("batch size must be positive")
+	}
+
+	var total int
+	for {
+		n, err := e.rotateBatch(ctx, client, batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += n
+
+		if n < batchSize {
+			return total, nil
+		}
+	}
+}
+
+func (e *Encryptor) rotateBatch(ctx context.Context, client *store.Client, batchSize int) (_ int, errReturned error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("begin tx: %v", err)
+	}
+	defer func() {
+		if errReturned != nil {
+			errReturned = errors.Join(errReturned, tx.Rollback())
+		}
+	}()
+
+	msgs, err := tx.Message.Query().
+		Where(message.Or(
+			message.BodyKeyIDIsNil(),
+			message.BodyKeyIDNEQ(e.currentKeyID),
+		)).
+		Order(message.ByID()).
+		Limit(batchSize).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("query messages: %v", err)
+	}
+
+	for _, msg := range msgs {
+		upd := tx.Message.UpdateOne(msg).SetBody(msg.Body)
+		if msg.OriginalBody != nil {
+			upd.SetOriginalBody(*msg.OriginalBody)
+		}
+		if err := upd.Exec(ctx); err != nil {
+			return 0, fmt.Errorf("update message %v: %v", msg.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit tx: %v", err)
+	}
+	return len(msgs), nil
+}
@@ -0,0 +1,163 @@
+package eventstream
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// Event - событие, доставляемое пользователю через realtime-соединение.
+type Event interface {
+	isEvent()
+}
+
+// TypingEvent - собеседник набирает сообщение в чате.
+type TypingEvent struct {
+	ChatID types.ChatID
+	UserID types.UserID
+	At     time.Time
+}
+
+func (TypingEvent) isEvent() {}
+
+// MessageEvent - в чат отправлено новое сообщение.
+type MessageEvent struct {
+	ChatID        types.ChatID
+	MessageID     types.MessageID
+	AuthorID      types.UserID
+	Body          string
+	IsRedacted    bool
+	AttachmentIDs []types.AttachmentID
+	CreatedAt     time.Time
+}
+
+func (MessageEvent) isEvent() {}
+
+//go:generate options-gen -out-filename=eventstream_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	// bufferSize - размер буфера событий подписчика. События для переполненного подписчика отбрасываются.
+	bufferSize int `validate:"min=1"`
+}
+
+var defaultOptions = Options{
+	bufferSize: 16,
+}
+
+// Stream - шина событий в памяти процесса. У пользователя может быть несколько подписок
+// (например, несколько открытых вкладок), событие доставляется в каждую.
+type Stream struct {
+	Options
+
+	mu     sync.RWMutex
+	subs   map[types.UserID]map[chan Event]struct{}
+	closed bool
+	// active - подписки, владельцы которых еще не завершили контекст подписки.
+	active sync.WaitGroup
+}
+
+func New(opts Options) (*Stream, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+
+	return &Stream{
+		Options: opts,
+		subs:    make(map[types.UserID]map[chan Event]struct{}),
+	}, nil
+}
+
+// Subscribe подписывает на события пользователя. Канал закрывается после завершения ctx или при Close:
+// получив закрытый канал, владелец подписки должен завершить соединение с клиентом и отменить ctx.
+func (s *Stream) Subscribe(ctx context.Context, userID types.UserID) <-chan Event {
+	ch := make(chan Event, s.bufferSize)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		close(ch)
+		return ch
+	}
+	if s.subs[userID] == nil {
+		s.subs[userID] = make(map[chan Event]struct{})
+	}
+	s.subs[userID][ch] = struct{}{}
+	s.active.Add(1)
+
+	go func() {
+		defer s.active.Done()
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// При Close канал уже закрыт и удален.
+		if _, ok := s.subs[userID][ch]; !ok {
+			return
+		}
+		delete(s.subs[userID], ch)
+		if len(s.subs[userID]) == 0 {
+			delete(s.subs, userID)
+		}
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Close закрывает каналы всех подписок, чтобы realtime-клиенты получили кадр закрытия,
+// и ждет, пока владельцы подписок завершат их, или пока не завершится ctx.
+// Новые подписки после Close сразу получают закрытый канал.
+func (s *Stream) Close(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	for userID, chs := range s.subs {
+		for ch := range chs {
+			close(ch)
+		}
+		delete(s.subs, userID)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.active.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("wait subscribers: %w", ctx.Err())
+	}
+}
+
+// Publish доставляет событие всем подпискам пользователя без блокировки.
+func (s *Stream) Publish(_ context.Context, userID types.UserID, event Event) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for ch := range s.subs[userID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribers возвращает количество подписок каждого пользователя.
+func (s *Stream) Subscribers() map[types.UserID]int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make(map[types.UserID]int, len(s.subs))
+	for userID, chs := range s.subs {
+		res[userID] = len(chs)
+	}
+	return res
+}
@@ -0,0 +1,46 @@
+// Code generated by options-gen. DO NOT EDIT.
+package eventstream
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.bufferSize = defaultOptions.bufferSize
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// bufferSize - размер буфера событий подписчика. События для переполненного подписчика отбрасываются.
+func WithBufferSize(opt int) OptOptionsSetter {
+	return func(o *Options) {
+		o.bufferSize = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("bufferSize", _validate_Options_bufferSize(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_bufferSize(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.bufferSize, "min=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `bufferSize` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package eventstream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s, err := eventstream.New(eventstream.NewOptions(eventstream.WithBufferSize(1)))
+	require.NoError(t, err)
+
+	userID := types.NewUserID()
+	subCtx, subCancel := context.WithCancel(ctx)
+	events1 := s.Subscribe(subCtx, userID)
+	events2 := s.Subscribe(ctx, userID)
+	assert.Equal(t, map[types.UserID]int{userID: 2}, s.Subscribers())
+
+	event := eventstream.TypingEvent{ChatID: types.NewChatID(), UserID: types.NewUserID(), At: time.Now()}
+	require.NoError(t, s.Publish(ctx, userID, event))
+	assert.Equal(t, eventstream.Event(event), <-events1)
+	assert.Equal(t, eventstream.Event(event), <-events2)
+
+	// Переполненный подписчик не блокирует публикацию.
+	require.NoError(t, s.Publish(ctx, userID, event))
+	require.NoError(t, s.Publish(ctx, userID, event))
+
+	// События другим пользователям не доставляются.
+	require.NoError(t, s.Publish(ctx, types.NewUserID(), event))
+	assert.Len(t, events1, 1)
+
+	subCancel()
+	require.Eventually(t, func() bool {
+		return s.Subscribers()[userID] == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestStream_Close(t *testing.T) {
+	s, err := eventstream.New(eventstream.NewOptions())
+	require.NoError(t, err)
+
+	// Обработчик соединения завершается, когда канал событий закрывается.
+	handlerCtx, handlerCancel := context.WithCancel(context.Background())
+	events := s.Subscribe(handlerCtx, types.NewUserID())
+	go func() {
+		defer handlerCancel()
+		for range events { //nolint:revive // drain events until the stream is closed
+		}
+	}()
+
+	// Второй обработчик не реагирует на закрытие канала.
+	stuckCtx, stuckCancel := context.WithCancel(context.Background())
+	defer stuckCancel()
+	_ = s.Subscribe(stuckCtx, types.NewUserID())
+
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer closeCancel()
+	require.ErrorIs(t, s.Close(closeCtx), context.DeadlineExceeded)
+	assert.Empty(t, s.Subscribers())
+
+	stuckCancel()
+	require.NoError(t, s.Close(context.Background()))
+
+	_, ok := <-s.Subscribe(context.Background(), types.NewUserID())
+	assert.False(t, ok)
+}
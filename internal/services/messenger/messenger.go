@@ -0,0 +1,317 @@
+package messenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+	messagesrepo "github.com/FischukSergey/chat-service/internal/repositories/messages"
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/attachment"
+	"github.com/FischukSergey/chat-service/internal/store/chat"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+var (
+	ErrChatNotFound       = errors.New("chat not found")
+	ErrNotAssigned        = errors.New("manager is not assigned to the chat")
+	ErrAttachmentNotFound = errors.New("attachment not found or already sent")
+)
+
+type historyRepo interface {
+	History(ctx context.Context, params messagesrepo.HistoryParams) (messagesrepo.HistoryPage, error)
+}
+
+type publisher interface {
+	Publish(ctx context.Context, userID types.UserID, event eventstream.Event) error
+}
+
+//go:generate options-gen -out-filename=messenger_options.gen.go -from-struct=Options
+type Options struct {
+	store     *store.Client `option:"mandatory" validate:"required"`
+	history   historyRepo   `option:"mandatory" validate:"required"`
+	publisher publisher     `option:"mandatory" validate:"required"`
+}
+
+// Service отправляет сообщения в чаты и отдает историю чатов.
+// Тело сообщения нормализуется и проверяется хуками хранилища: ошибки нормализатора
+// (normalizer.ErrEmptyBody, normalizer.ErrTooLong, normalizer.ErrMarkupOnly) возвращаются обернутыми.
+type Service struct {
+	Options
+}
+
+func New(opts Options) (*Service, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &Service{Options: opts}, nil
+}
+
+// SendClientMessage отправляет сообщение клиента. Чат клиента создается с первым сообщением.
+// Сообщение привязывается к нерешенной проблеме чата и доставляется ее менеджеру.
+// attachmentIDs - загруженные клиентом и еще не отправленные вложения.
+func (s *Service) SendClientMessage(
+	ctx context.Context,
+	clientID types.UserID,
+	body string,
+	attachmentIDs []types.AttachmentID,
+) (messagesrepo.Message, error) {
+	chatID, err := s.clientChat(ctx, clientID)
+	if err != nil {
+		return messagesrepo.Message{}, err
+	}
+
+	p, err := s.activeProblem(ctx, chatID)
+	if err != nil {
+		return messagesrepo.Message{}, err
+	}
+
+	m := newMessage{chatID: chatID, authorID: clientID, body: body, attachmentIDs: attachmentIDs}
+	recipients := []types.UserID{clientID}
+	if p != nil {
+		m.problemID = p.ID
+		recipients = append(recipients, p.ManagerID)
+	}
+
+	return s.send(ctx, m, recipients)
+}
+
+// SendManagerMessage отправляет сообщение менеджера в чат, где ему назначена нерешенная проблема.
+// attachmentIDs - загруженные менеджером и еще не отправленные вложения.
+func (s *Service) SendManagerMessage(
+	ctx context.Context,
+	managerID types.UserID,
+	chatID types.ChatID,
+	body string,
+	attachmentIDs []types.AttachmentID,
+) (messagesrepo.Message, error) {
+	c, p, err := s.assignedChat(ctx, managerID, chatID)
+	if err != nil {
+		return messagesrepo.Message{}, err
+	}
+
+	m := newMessage{chatID: c.ID, problemID: p.ID, authorID: managerID, body: body, attachmentIDs: attachmentIDs}
+	return s.send(ctx, m, []types.UserID{c.ClientID, managerID})
+}
+
+// ClientHistory возвращает историю чата клиента. Если клиент еще не писал, история пустая.
+func (s *Service) ClientHistory(
+	ctx context.Context,
+	clientID types.UserID,
+	pageSize int,
+	cursor string,
+) (messagesrepo.HistoryPage, error) {
+	c, err := s.store.Chat.Query().Where(chat.ClientID(clientID)).Only(ctx)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return messagesrepo.HistoryPage{}, nil
+		}
+		return messagesrepo.HistoryPage{}, fmt.Errorf("query chat: %v", err)
+	}
+
+	return s.history.History(ctx, messagesrepo.HistoryParams{
+		ChatID:     c.ID,
+		Visibility: messagesrepo.VisibleForClient,
+		PageSize:   pageSize,
+		Cursor:     cursor,
+	})
+}
+
+// ManagerHistory возвращает историю чата, где менеджеру назначена нерешенная проблема,
+// включая сообщения, скрытые от клиента.
+func (s *Service) ManagerHistory(
+	ctx context.Context,
+	managerID types.UserID,
+	chatID types.ChatID,
+	pageSize int,
+	cursor string,
+) (messagesrepo.HistoryPage, error) {
+	if _, _, err := s.assignedChat(ctx, managerID, chatID); err != nil {
+		return messagesrepo.HistoryPage{}, err
+	}
+
+	return s.history.History(ctx, messagesrepo.HistoryParams{
+		ChatID:     chatID,
+		Visibility: messagesrepo.VisibleForManager,
+		PageSize:   pageSize,
+		Cursor:     cursor,
+	})
+}
+
+type newMessage struct {
+	chatID        types.ChatID
+	problemID     types.ProblemID
+	authorID      types.UserID
+	body          string
+	attachmentIDs []types.AttachmentID
+}
+
+func (s *Service) send(ctx context.Context, nm newMessage, recipients []types.UserID) (messagesrepo.Message, error) {
+	msg, err := s.create(ctx, nm)
+	if err != nil {
+		return messagesrepo.Message{}, err
+	}
+
+	// Сообщение уже сохранено: ошибка доставки не должна приводить к повторной отправке.
+	event := eventstream.MessageEvent{
+		ChatID:        msg.ChatID,
+		MessageID:     msg.ID,
+		AuthorID:      msg.AuthorID,
+		Body:          msg.Body,
+		IsRedacted:    msg.IsRedacted,
+		AttachmentIDs: msg.AttachmentIDs,
+		CreatedAt:     msg.CreatedAt,
+	}
+	for _, userID := range recipients {
+		if err := s.publisher.Publish(ctx, userID, event); err != nil {
+			logger.FromContext(ctx).Warn("publish message event failed",
+				zap.Stringer("message_id", msg.ID), zap.Error(err))
+		}
+	}
+	return msg, nil
+}
+
+// create сохраняет сообщение и прикрепляет к нему вложения в одной транзакции.
+func (s *Service) create(ctx context.Context, nm newMessage) (_ messagesrepo.Message, errReturned error) {
+	tx, err := s.store.Tx(ctx)
+	if err != nil {
+		return messagesrepo.Message{}, fmt.Errorf("begin tx: %v", err)
+	}
+	defer func() {
+		if errReturned != nil {
+			errReturned = errors.Join(errReturned, tx.Rollback())
+		}
+	}()
+
+	create := tx.Message.Create().
+		SetChatID(nm.chatID).
+		SetAuthorID(nm.authorID).
+		SetBody(nm.body)
+	if !nm.problemID.IsZero() {
+		create.SetProblemID(nm.problemID)
+	}
+	// Хук шифрования возвращает созданное сообщение расшифрованным.
+	m, err := create.Save(ctx)
+	if err != nil {
+		return messagesrepo.Message{}, fmt.Errorf("create message: %w", err)
+	}
+
+	attachmentIDs := uniqueAttachmentIDs(nm.attachmentIDs)
+	if len(attachmentIDs) > 0 {
+		// Прикрепить можно только свои вложения, которые еще не отправлены в другом сообщении.
+		n, err := tx.Attachment.Update().
+			Where(
+				attachment.IDIn(attachmentIDs...),
+				attachment.UploaderID(nm.authorID),
+				attachment.MessageIDIsNil(),
+			).
+			SetMessageID(m.ID).
+			Save(ctx)
+		if err != nil {
+			return messagesrepo.Message{}, fmt.Errorf("link attachments: %v", err)
+		}
+		if n != len(attachmentIDs) {
+			return messagesrepo.Message{}, ErrAttachmentNotFound
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return messagesrepo.Message{}, fmt.Errorf("commit tx: %v", err)
+	}
+
+	msg := messagesrepo.NewMessage(m)
+	msg.AttachmentIDs = attachmentIDs
+	return msg, nil
+}
+
+// uniqueAttachmentIDs убирает повторы, сохраняя порядок вложений.
+func uniqueAttachmentIDs(ids []types.AttachmentID) []types.AttachmentID {
+	res := make([]types.AttachmentID, 0, len(ids))
+	for _, id := range ids {
+		if !slices.Contains(res, id) {
+			res = append(res, id)
+		}
+	}
+	return res
+}
+
+// clientChat возвращает чат клиента, создавая его при первом сообщении.
+func (s *Service) clientChat(ctx context.Context, clientID types.UserID) (types.ChatID, error) {
+	c, err := s.store.Chat.Query().Where(chat.ClientID(clientID)).Only(ctx)
+	if err == nil {
+		return c.ID, nil
+	}
+	if !store.IsNotFound(err) {
+		return types.ChatID{}, fmt.Errorf("query chat: %v", err)
+	}
+
+	c, err = s.store.Chat.Create().SetClientID(clientID).Save(ctx)
+	if err == nil {
+		return c.ID, nil
+	}
+	if !store.IsConstraintError(err) {
+		return types.ChatID{}, fmt.Errorf("create chat: %v", err)
+	}
+
+	// Чат уже создан параллельным запросом того же клиента.
+	c, err = s.store.Chat.Query().Where(chat.ClientID(clientID)).Only(ctx)
+	if err != nil {
+		return types.ChatID{}, fmt.Errorf("query chat: %v", err)
+	}
+	return c.ID, nil
+}
+
+// activeProblem возвращает самую старую нерешенную проблему чата или nil.
+func (s *Service) activeProblem(ctx context.Context, chatID types.ChatID) (*store.Problem, error) {
+	p, err := s.store.Problem.Query().
+		Where(
+			problem.ChatID(chatID),
+			problem.StatusIn(problem.StatusOpen, problem.StatusInProgress),
+		).
+		Order(problem.ByCreatedAt(), problem.ByID()).
+		First(ctx)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return nil, nil //nolint:nilnil // chat may have no active problem
+		}
+		return nil, fmt.Errorf("query problem: %v", err)
+	}
+	return p, nil
+}
+
+// assignedChat возвращает чат и нерешенную проблему, назначенную в нем менеджеру.
+func (s *Service) assignedChat(
+	ctx context.Context,
+	managerID types.UserID,
+	chatID types.ChatID,
+) (*store.Chat, *store.Problem, error) {
+	c, err := s.store.Chat.Get(ctx, chatID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return nil, nil, ErrChatNotFound
+		}
+		return nil, nil, fmt.Errorf("get chat: %v", err)
+	}
+
+	p, err := s.store.Problem.Query().
+		Where(
+			problem.ChatID(c.ID),
+			problem.ManagerID(managerID),
+			problem.StatusIn(problem.StatusOpen, problem.StatusInProgress),
+		).
+		Order(problem.ByCreatedAt(), problem.ByID()).
+		First(ctx)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return nil, nil, ErrNotAssigned
+		}
+		return nil, nil, fmt.Errorf("query problem: %v", err)
+	}
+	return c, p, nil
+}
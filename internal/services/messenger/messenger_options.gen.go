@@ -0,0 +1,63 @@
+// Code generated by options-gen. DO NOT EDIT.
+package messenger
+
+import (
+	fmt461e464ebed9 "fmt"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	store *store.Client,
+	history historyRepo,
+	publisher publisher,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from field tag (if present)
+
+	o.store = store
+
+	o.history = history
+
+	o.publisher = publisher
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("store", _validate_Options_store(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("history", _validate_Options_history(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("publisher", _validate_Options_publisher(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_store(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.store, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `store` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_history(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.history, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `history` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_publisher(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.publisher, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `publisher` did not pass the test: %w", err)
+	}
+	return nil
+}
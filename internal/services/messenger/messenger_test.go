@@ -0,0 +1,155 @@
+package messenger_test
+
+import (
+	"context"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	messagesrepo "github.com/FischukSergey/chat-service/internal/repositories/messages"
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/services/messenger"
+	"github.com/FischukSergey/chat-service/internal/services/normalizer"
+	"github.com/FischukSergey/chat-service/internal/store/chat"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+type fakePublisher struct {
+	events map[types.UserID][]eventstream.Event
+}
+
+func (p *fakePublisher) Publish(_ context.Context, userID types.UserID, event eventstream.Event) error {
+	p.events[userID] = append(p.events[userID], event)
+	return nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	defer func() { require.NoError(t, client.Close()) }()
+
+	n, err := normalizer.New(normalizer.NewOptions(20))
+	require.NoError(t, err)
+	client.Message.Use(n.Hook())
+
+	repo, err := messagesrepo.New(messagesrepo.NewOptions(client, dialect.SQLite))
+	require.NoError(t, err)
+	pub := &fakePublisher{events: make(map[types.UserID][]eventstream.Event)}
+	svc, err := messenger.New(messenger.NewOptions(client, repo, pub))
+	require.NoError(t, err)
+
+	clientID, managerID := types.NewUserID(), types.NewUserID()
+
+	t.Run("empty history before first message", func(t *testing.T) {
+		page, err := svc.ClientHistory(ctx, clientID, 10, "")
+		require.NoError(t, err)
+		assert.Empty(t, page.Messages)
+	})
+
+	t.Run("first message creates chat", func(t *testing.T) {
+		msg, err := svc.SendClientMessage(ctx, clientID, " Здравствуйте​ ", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Здравствуйте", msg.Body)
+		assert.Equal(t, clientID, msg.AuthorID)
+
+		c := client.Chat.Query().Where(chat.ClientID(clientID)).OnlyX(ctx)
+		assert.Equal(t, c.ID, msg.ChatID)
+		// Менеджера еще нет, событие получают только подключения клиента.
+		assert.Len(t, pub.events[clientID], 1)
+		assert.Empty(t, pub.events[managerID])
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		_, err := svc.SendClientMessage(ctx, clientID, " ​ ", nil)
+		require.ErrorIs(t, err, normalizer.ErrEmptyBody)
+
+		_, err = svc.SendClientMessage(ctx, clientID, "очень длинное сообщение клиента", nil)
+		require.ErrorIs(t, err, normalizer.ErrTooLong)
+	})
+
+	c := client.Chat.Query().Where(chat.ClientID(clientID)).OnlyX(ctx)
+
+	t.Run("not assigned manager", func(t *testing.T) {
+		_, err := svc.SendManagerMessage(ctx, managerID, c.ID, "Добрый день", nil)
+		require.ErrorIs(t, err, messenger.ErrNotAssigned)
+
+		_, err = svc.ManagerHistory(ctx, managerID, c.ID, 10, "")
+		require.ErrorIs(t, err, messenger.ErrNotAssigned)
+
+		_, err = svc.SendManagerMessage(ctx, managerID, types.NewChatID(), "Добрый день", nil)
+		require.ErrorIs(t, err, messenger.ErrChatNotFound)
+	})
+
+	p := client.Problem.Create().SetChatID(c.ID).SetManagerID(managerID).SetStatus(problem.StatusInProgress).SaveX(ctx)
+	pub.events = make(map[types.UserID][]eventstream.Event)
+
+	t.Run("messages are linked to problem and delivered", func(t *testing.T) {
+		fromClient, err := svc.SendClientMessage(ctx, clientID, "Карта заблокирована", nil)
+		require.NoError(t, err)
+		fromManager, err := svc.SendManagerMessage(ctx, managerID, c.ID, "Добрый день", nil)
+		require.NoError(t, err)
+
+		for _, id := range []types.MessageID{fromClient.ID, fromManager.ID} {
+			assert.Equal(t, p.ID, client.Message.GetX(ctx, id).ProblemID)
+		}
+
+		for _, userID := range []types.UserID{clientID, managerID} {
+			require.Len(t, pub.events[userID], 2)
+			e, ok := pub.events[userID][1].(eventstream.MessageEvent)
+			require.True(t, ok)
+			assert.Equal(t, fromManager.ID, e.MessageID)
+			assert.Equal(t, c.ID, e.ChatID)
+			assert.Equal(t, "Добрый день", e.Body)
+		}
+	})
+
+	t.Run("history", func(t *testing.T) {
+		clientPage, err := svc.ClientHistory(ctx, clientID, 10, "")
+		require.NoError(t, err)
+		managerPage, err := svc.ManagerHistory(ctx, managerID, c.ID, 10, "")
+		require.NoError(t, err)
+
+		for _, page := range []messagesrepo.HistoryPage{clientPage, managerPage} {
+			require.Len(t, page.Messages, 3)
+			assert.Equal(t, "Добрый день", page.Messages[0].Body)
+			assert.Equal(t, "Здравствуйте", page.Messages[2].Body)
+		}
+	})
+
+	t.Run("attachments are linked once", func(t *testing.T) {
+		own := client.Attachment.Create().SetUploaderID(clientID).SetFileName("a.png").
+			SetContentType("image/png").SetSize(1).SetBlobKey("a").SaveX(ctx)
+		foreign := client.Attachment.Create().SetUploaderID(managerID).SetFileName("b.png").
+			SetContentType("image/png").SetSize(1).SetBlobKey("b").SaveX(ctx)
+
+		// Чужое вложение не прикрепляется, сообщение не сохраняется.
+		_, err := svc.SendClientMessage(ctx, clientID, "Скриншоты", []types.AttachmentID{own.ID, foreign.ID})
+		require.ErrorIs(t, err, messenger.ErrAttachmentNotFound)
+		assert.True(t, client.Attachment.GetX(ctx, own.ID).MessageID.IsZero())
+
+		msg, err := svc.SendClientMessage(ctx, clientID, "Скриншот", []types.AttachmentID{own.ID, own.ID})
+		require.NoError(t, err)
+		assert.Equal(t, []types.AttachmentID{own.ID}, msg.AttachmentIDs)
+		assert.Equal(t, msg.ID, client.Attachment.GetX(ctx, own.ID).MessageID)
+
+		// Отправленное вложение нельзя прикрепить повторно.
+		_, err = svc.SendClientMessage(ctx, clientID, "Еще раз", []types.AttachmentID{own.ID})
+		require.ErrorIs(t, err, messenger.ErrAttachmentNotFound)
+
+		page, err := svc.ManagerHistory(ctx, managerID, c.ID, 10, "")
+		require.NoError(t, err)
+		for _, m := range page.Messages {
+			if m.ID == msg.ID {
+				assert.Equal(t, []types.AttachmentID{own.ID}, m.AttachmentIDs)
+			} else {
+				assert.Empty(t, m.AttachmentIDs)
+			}
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package normalizer
+
+import (
+	"context"
+
+	"entgo.io/ent"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/hook"
+)
+
+// Hook возвращает ent-хук, нормализующий и проверяющий тело сообщения перед сохранением.
+// Должен регистрироваться раньше хуков маскирования и шифрования, чтобы они работали с нормализованным текстом.
+func (n *Normalizer) Hook() store.Hook {
+	return hook.On(func(next store.Mutator) store.Mutator {
+		return hook.MessageFunc(func(ctx context.Context, m *store.MessageMutation) (store.Value, error) {
+			body, ok := m.Body()
+			if !ok {
+				return next.Mutate(ctx, m)
+			}
+
+			normalized, err := n.Normalize(body)
+			if err != nil {
+				return nil, err
+			}
+			m.SetBody(normalized)
+
+			return next.Mutate(ctx, m)
+		})
+	}, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne)
+}
@@ -0,0 +1,55 @@
+package normalizer_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/normalizer"
+	"github.com/FischukSergey/chat-service/internal/services/redactor"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestNormalizer_Hook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	defer func() { require.NoError(t, client.Close()) }()
+
+	n, err := normalizer.New(normalizer.NewOptions(100))
+	require.NoError(t, err)
+	r, err := redactor.New(redactor.NewOptions())
+	require.NoError(t, err)
+	client.Message.Use(n.Hook(), r.Hook())
+
+	clientID := types.NewUserID()
+	chat := client.Chat.Create().SetClientID(clientID).SaveX(ctx)
+
+	t.Run("normalized before redaction", func(t *testing.T) {
+		// Символы нулевой ширины внутри номера карты не мешают его маскированию.
+		msg, err := client.Message.Create().
+			SetChatID(chat.ID).
+			SetAuthorID(clientID).
+			SetBody(" Карта 4111\u200B 1111 1111 1111\n").
+			Save(ctx)
+		require.NoError(t, err)
+
+		stored := client.Message.GetX(ctx, msg.ID)
+		assert.Equal(t, "Карта **** **** **** 1111", stored.Body)
+		assert.True(t, stored.IsRedacted)
+	})
+
+	t.Run("invalid body is rejected", func(t *testing.T) {
+		_, err := client.Message.Create().
+			SetChatID(chat.ID).
+			SetAuthorID(clientID).
+			SetBody("<script>alert(1)</script>").
+			Save(ctx)
+		require.ErrorIs(t, err, normalizer.ErrMarkupOnly)
+	})
+}
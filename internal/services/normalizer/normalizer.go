@@ -0,0 +1,87 @@
+package normalizer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrEmptyBody  = errors.New("message body is empty")
+	ErrTooLong    = errors.New("message body is too long")
+	ErrMarkupOnly = errors.New("message body consists of markup only")
+)
+
+var (
+	// Элементы, содержимое которых не является текстом сообщения.
+	scriptRe = regexp.MustCompile(`(?is)<(script|style)\b.*?(?:</(?:script|style)\s*>|$)`)
+	tagRe    = regexp.MustCompile(`(?s)<[!/?]?[a-zA-Z][^<>]*>|<!--.*?-->`)
+)
+
+// zeroWidth - невидимые символы нулевой ширины.
+// Соединитель нулевой ширины (U+200D) сохраняется: из него состоят составные эмодзи.
+var zeroWidth = map[rune]struct{}{
+	'\u200B': {}, // zero width space
+	'\u200C': {}, // zero width non-joiner
+	'\u2060': {}, // word joiner
+	'\uFEFF': {}, // zero width no-break space (BOM)
+}
+
+//go:generate options-gen -out-filename=normalizer_options.gen.go -from-struct=Options
+type Options struct {
+	// maxLength - максимальная длина тела сообщения в символах после нормализации.
+	maxLength int `option:"mandatory" validate:"min=1"`
+}
+
+// Normalizer приводит тело сообщения к единому виду и проверяет его.
+// Правила общие для сообщений клиентов и менеджеров, так как применяются ent-хуком.
+type Normalizer struct {
+	maxLength int
+}
+
+func New(opts Options) (*Normalizer, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &Normalizer{maxLength: opts.maxLength}, nil
+}
+
+// Normalize приводит текст к форме NFC, удаляет управляющие символы (кроме перевода строки
+// и табуляции) и символы нулевой ширины, обрезает пробельные символы по краям.
+// Возвращает ошибку, если текст пустой, длиннее допустимого или состоит только из разметки.
+func (n *Normalizer) Normalize(body string) (string, error) {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	body = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if _, ok := zeroWidth[r]; ok || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, body)
+	body = strings.TrimSpace(norm.NFC.String(body))
+
+	if body == "" {
+		return "", ErrEmptyBody
+	}
+	if l := utf8.RuneCountInString(body); l > n.maxLength {
+		return "", fmt.Errorf("%w: %d > %d", ErrTooLong, l, n.maxLength)
+	}
+	if markupOnly(body) {
+		return "", ErrMarkupOnly
+	}
+	return body, nil
+}
+
+// markupOnly возвращает true, если в тексте есть HTML-разметка и кроме нее нет ничего.
+func markupOnly(body string) bool {
+	text := scriptRe.ReplaceAllString(body, "")
+	text = tagRe.ReplaceAllString(text, "")
+	return text != body && strings.TrimSpace(text) == ""
+}
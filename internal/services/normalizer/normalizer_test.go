@@ -0,0 +1,53 @@
+package normalizer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/normalizer"
+)
+
+func TestNormalizer_Normalize(t *testing.T) {
+	n, err := normalizer.New(normalizer.NewOptions(30))
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		body   string
+		exp    string
+		expErr error
+	}{
+		{name: "plain text", body: "Привет", exp: "Привет"},
+		{name: "nfc", body: "e\u0301", exp: "\u00e9"},
+		{name: "trim spaces", body: "  hi \n", exp: "hi"},
+		{name: "keep newlines and tabs", body: "a\r\nb\tc", exp: "a\nb\tc"},
+		{name: "strip control characters", body: "a\x00b\x1bc\u0085", exp: "abc"},
+		{name: "strip zero width characters", body: "a\u200Bb\uFEFFc\u2060", exp: "abc"},
+		{name: "keep zero width joiner", body: "👨\u200D👩", exp: "👨\u200D👩"},
+		{name: "length in runes", body: strings.Repeat("ё", 30), exp: strings.Repeat("ё", 30)},
+		{name: "text with markup", body: "<b>hi</b>", exp: "<b>hi</b>"},
+		{name: "comparison is not markup", body: "1 < 2 > 0", exp: "1 < 2 > 0"},
+		{name: "empty", body: "", expErr: normalizer.ErrEmptyBody},
+		{name: "whitespace only", body: " \n\t ", expErr: normalizer.ErrEmptyBody},
+		{name: "invisible only", body: "\u200B\x07", expErr: normalizer.ErrEmptyBody},
+		{name: "too long", body: strings.Repeat("a", 31), expErr: normalizer.ErrTooLong},
+		{name: "script", body: "<script>alert(1)</script>", expErr: normalizer.ErrMarkupOnly},
+		{name: "unclosed script", body: "<SCRIPT src=x>", expErr: normalizer.ErrMarkupOnly},
+		{name: "tags only", body: "<img src=x onerror=alert(1)>", expErr: normalizer.ErrMarkupOnly},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := n.Normalize(tt.body)
+			if tt.expErr != nil {
+				require.ErrorIs(t, err, tt.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.exp, body)
+		})
+	}
+}
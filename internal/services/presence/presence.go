@@ -0,0 +1,128 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+//go:generate options-gen -out-filename=presence_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	// onlineTTL - сколько пользователь считается онлайн после последней активности.
+	onlineTTL time.Duration `option:"mandatory" validate:"min=1s"`
+	// retention - сколько хранится время последней активности пользователя.
+	retention       time.Duration `option:"mandatory" validate:"min=1s"`
+	cleanupInterval time.Duration `validate:"min=1s"`
+	now             func() time.Time
+}
+
+var defaultOptions = Options{
+	cleanupInterval: time.Minute,
+	now:             time.Now,
+}
+
+// Status - присутствие пользователя.
+type Status struct {
+	Online   bool
+	LastSeen time.Time
+}
+
+// Presence хранит в памяти время последней активности пользователей.
+// Записи старше retention удаляются в Run.
+type Presence struct {
+	Options
+
+	mu       sync.RWMutex
+	lastSeen map[types.UserID]time.Time
+}
+
+func New(opts Options) (*Presence, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	if opts.retention < opts.onlineTTL {
+		return nil, errors.New("retention must not be less than online ttl")
+	}
+
+	return &Presence{
+		Options:  opts,
+		lastSeen: make(map[types.UserID]time.Time),
+	}, nil
+}
+
+// Touch отмечает активность пользователя.
+func (p *Presence) Touch(userID types.UserID) {
+	now := p.now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lastSeen[userID] = now
+}
+
+// Status возвращает присутствие пользователя. ok == false, если активность пользователя неизвестна.
+func (p *Presence) Status(userID types.UserID) (status Status, ok bool) {
+	p.mu.RLock()
+	lastSeen, ok := p.lastSeen[userID]
+	p.mu.RUnlock()
+
+	if !ok || p.expired(lastSeen, p.retention) {
+		return Status{}, false
+	}
+	return p.status(lastSeen), true
+}
+
+// Snapshot возвращает присутствие всех известных пользователей.
+func (p *Presence) Snapshot() map[types.UserID]Status {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	res := make(map[types.UserID]Status, len(p.lastSeen))
+	for userID, lastSeen := range p.lastSeen {
+		if !p.expired(lastSeen, p.retention) {
+			res[userID] = p.status(lastSeen)
+		}
+	}
+	return res
+}
+
+// Run периодически удаляет устаревшие записи, пока не завершится контекст.
+func (p *Presence) Run(ctx context.Context) error {
+	t := time.NewTicker(p.cleanupInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-t.C:
+			p.cleanup()
+		}
+	}
+}
+
+func (p *Presence) cleanup() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for userID, lastSeen := range p.lastSeen {
+		if p.expired(lastSeen, p.retention) {
+			delete(p.lastSeen, userID)
+		}
+	}
+}
+
+func (p *Presence) status(lastSeen time.Time) Status {
+	return Status{
+		Online:   !p.expired(lastSeen, p.onlineTTL),
+		LastSeen: lastSeen,
+	}
+}
+
+func (p *Presence) expired(lastSeen time.Time, ttl time.Duration) bool {
+	return p.now().Sub(lastSeen) > ttl
+}
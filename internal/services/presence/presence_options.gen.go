@@ -0,0 +1,81 @@
+// Code generated by options-gen. DO NOT EDIT.
+package presence
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	onlineTTL time.Duration,
+	retention time.Duration,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.onlineTTL = defaultOptions.onlineTTL
+
+	o.retention = defaultOptions.retention
+
+	o.cleanupInterval = defaultOptions.cleanupInterval
+
+	o.now = defaultOptions.now
+
+	o.onlineTTL = onlineTTL
+
+	o.retention = retention
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+func WithCleanupInterval(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.cleanupInterval = opt
+
+	}
+}
+
+func WithNow(opt func() time.Time) OptOptionsSetter {
+	return func(o *Options) {
+		o.now = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("onlineTTL", _validate_Options_onlineTTL(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("retention", _validate_Options_retention(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("cleanupInterval", _validate_Options_cleanupInterval(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_onlineTTL(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.onlineTTL, "min=1s"); err != nil {
+		return fmt461e464ebed9.Errorf("field `onlineTTL` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_retention(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.retention, "min=1s"); err != nil {
+		return fmt461e464ebed9.Errorf("field `retention` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_cleanupInterval(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.cleanupInterval, "min=1s"); err != nil {
+		return fmt461e464ebed9.Errorf("field `cleanupInterval` did not pass the test: %w", err)
+	}
+	return nil
+}
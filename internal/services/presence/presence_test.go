@@ -0,0 +1,54 @@
+package presence_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/presence"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestPresence(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	p, err := presence.New(presence.NewOptions(
+		time.Minute,
+		time.Hour,
+		presence.WithNow(func() time.Time { return now }),
+	))
+	require.NoError(t, err)
+
+	userID := types.NewUserID()
+
+	_, ok := p.Status(userID)
+	assert.False(t, ok)
+
+	p.Touch(userID)
+	lastSeen := now
+
+	status, ok := p.Status(userID)
+	require.True(t, ok)
+	assert.True(t, status.Online)
+	assert.Equal(t, lastSeen, status.LastSeen)
+
+	// Онлайн-статус истекает, время последней активности сохраняется.
+	now = now.Add(2 * time.Minute)
+	status, ok = p.Status(userID)
+	require.True(t, ok)
+	assert.False(t, status.Online)
+	assert.Equal(t, lastSeen, status.LastSeen)
+	assert.Equal(t, map[types.UserID]presence.Status{userID: status}, p.Snapshot())
+
+	// После retention пользователь забывается.
+	now = now.Add(time.Hour)
+	_, ok = p.Status(userID)
+	assert.False(t, ok)
+	assert.Empty(t, p.Snapshot())
+}
+
+func TestNew_RetentionLessThanOnlineTTL(t *testing.T) {
+	_, err := presence.New(presence.NewOptions(time.Hour, time.Minute))
+	require.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package redactor
+
+import (
+	"context"
+
+	"entgo.io/ent"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/hook"
+)
+
+// Hook возвращает ent-хук, маскирующий тело сообщения перед сохранением в хранилище.
+// Если в сообщении найдены чувствительные данные, оно помечается флагом is_redacted.
+func (r *Redactor) Hook() store.Hook {
+	return hook.On(func(next store.Mutator) store.Mutator {
+		return hook.MessageFunc(func(ctx context.Context, m *store.MessageMutation) (store.Value, error) {
+			body, ok := m.Body()
+			if !ok {
+				return next.Mutate(ctx, m)
+			}
+
+			res := r.Redact(body)
+			if res.Redacted() {
+				m.SetBody(res.Body)
+				m.SetIsRedacted(true)
+				if r.keepOriginal {
+					m.SetOriginalBody(body)
+				}
+			}
+
+			return next.Mutate(ctx, m)
+		})
+	}, ent.OpCreate|ent.OpUpdate|ent.OpUpdateOne)
+}
@@ -0,0 +1,66 @@
+package redactor_test
+
+import (
+	"context"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/redactor"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestRedactor_Hook(t *testing.T) {
+	cases := []struct {
+		name         string
+		keepOriginal bool
+	}{
+		{name: "drop original", keepOriginal: false},
+		{name: "keep original", keepOriginal: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+			defer func() { require.NoError(t, client.Close()) }()
+
+			r, err := redactor.New(redactor.NewOptions(redactor.WithKeepOriginal(tt.keepOriginal)))
+			require.NoError(t, err)
+			client.Message.Use(r.Hook())
+
+			clientID := types.NewUserID()
+			chat := client.Chat.Create().SetClientID(clientID).SaveX(ctx)
+
+			const body = "Карта 4111 1111 1111 1111 заблокирована"
+			msg := client.Message.Create().
+				SetChatID(chat.ID).
+				SetAuthorID(clientID).
+				SetBody(body).
+				SaveX(ctx)
+
+			stored := client.Message.GetX(ctx, msg.ID)
+			assert.Equal(t, "Карта **** **** **** 1111 заблокирована", stored.Body)
+			assert.True(t, stored.IsRedacted)
+			if tt.keepOriginal {
+				require.NotNil(t, stored.OriginalBody)
+				assert.Equal(t, body, *stored.OriginalBody)
+			} else {
+				assert.Nil(t, stored.OriginalBody)
+			}
+
+			clean := client.Message.Create().
+				SetChatID(chat.ID).
+				SetAuthorID(clientID).
+				SetBody("Спасибо!").
+				SaveX(ctx)
+			assert.Equal(t, "Спасибо!", clean.Body)
+			assert.False(t, clean.IsRedacted)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package redactor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Kind - тип найденных чувствительных данных.
+type Kind string
+
+const (
+	KindCardNumber Kind = "card_number"
+	KindIBAN       Kind = "iban"
+	KindCVV        Kind = "cvv"
+	KindPhone      Kind = "phone"
+)
+
+const maskChar = '*'
+
+var (
+	// 13-19 цифр, допускаются одиночные пробелы и дефисы между группами.
+	cardRe = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	// Код страны, две контрольные цифры и до 30 символов BBAN, допускаются пробелы между группами.
+	ibanRe = regexp.MustCompile(`(?i)\b[a-z]{2}\d{2}(?: ?[a-z0-9]){11,30}\b`)
+	// Ключевое слово CVV/CVC и следующие за ним 3-4 цифры.
+	cvvRe = regexp.MustCompile(`(?i)\b(?:cvv2?|cvc2?|cvn2?|csc|cid)\b[\s:=#-]*\d{3,4}\b`)
+	// Номер телефона в одном из распространенных форматов. Произвольные последовательности цифр
+	// не считаются телефоном, чтобы не маскировать даты, время и суммы вроде "2024-01-02 10:30".
+	phoneRe = regexp.MustCompile(strings.Join([]string{
+		// Международный формат: +7 (999) 123-45-67, +44 20 7946 0958.
+		`\+\d{1,3}[ -]?(?:\(\d{1,5}\)|\d{1,5})(?:[ -]?\d{1,4}){1,5}\b`,
+		// Российский формат с 8 или 7 в начале: 8 (999) 123-45-67, 89991234567.
+		`\b[78][ -]?(?:\(\d{3}\)|\d{3})[ -]?\d{3}(?:[ -]?\d{2}){2}\b`,
+		// Код города в скобках: (495) 123-45-67.
+		`\(\d{3,5}\)[ -]?\d{1,3}(?:[ -]?\d{2,4}){1,3}\b`,
+	}, "|"))
+)
+
+const (
+	phoneMinDigits = 10
+	phoneMaxDigits = 15
+	visibleTail    = 4
+	phoneTail      = 2
+)
+
+//go:generate options-gen -out-filename=redactor_options.gen.go -from-struct=Options
+type Options struct {
+	// keepOriginal - сохранять ли исходный текст сообщения в отдельной колонке.
+	keepOriginal bool
+}
+
+// Redactor маскирует номера карт, IBAN, CVV-коды и номера телефонов в тексте сообщений.
+type Redactor struct {
+	keepOriginal bool
+}
+
+func New(opts Options) (*Redactor, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	return &Redactor{keepOriginal: opts.keepOriginal}, nil
+}
+
+// Result - результат маскирования текста.
+type Result struct {
+	Body  string
+	Kinds []Kind
+}
+
+// Redacted возвращает true, если в тексте были найдены чувствительные данные.
+func (r Result) Redacted() bool {
+	return len(r.Kinds) > 0
+}
+
+// Redact находит в тексте чувствительные данные и заменяет их маской.
+// Номера карт и IBAN проверяются контрольной суммой, чтобы не маскировать случайные числа.
+func (r *Redactor) Redact(body string) Result {
+	res := Result{Body: body}
+
+	apply := func(kind Kind, re *regexp.Regexp, mask func(string) (string, bool)) {
+		found := false
+		res.Body = re.ReplaceAllStringFunc(res.Body, func(s string) string {
+			masked, ok := mask(s)
+			if !ok {
+				return s
+			}
+			found = true
+			return masked
+		})
+		if found {
+			res.Kinds = append(res.Kinds, kind)
+		}
+	}
+
+	apply(KindCardNumber, cardRe, maskCardNumber)
+	apply(KindIBAN, ibanRe, maskIBAN)
+	apply(KindCVV, cvvRe, maskCVV)
+	apply(KindPhone, phoneRe, maskPhone)
+
+	return res
+}
+
+func maskCardNumber(s string) (string, bool) {
+	if !luhnValid(digitsOf(s)) {
+		return "", false
+	}
+	return maskDigits(s, visibleTail), true
+}
+
+func maskIBAN(s string) (string, bool) {
+	if !ibanValid(s) {
+		return "", false
+	}
+
+	// Код страны и последние символы оставляем видимыми.
+	var alnum int
+	for _, c := range s {
+		if isAlnum(c) {
+			alnum++
+		}
+	}
+
+	var b strings.Builder
+	var i int
+	for _, c := range s {
+		if !isAlnum(c) {
+			b.WriteRune(c)
+			continue
+		}
+		if i < 2 || i >= alnum-visibleTail {
+			b.WriteRune(c)
+		} else {
+			b.WriteRune(maskChar)
+		}
+		i++
+	}
+	return b.String(), true
+}
+
+func maskCVV(s string) (string, bool) {
+	return maskDigits(s, 0), true
+}
+
+func maskPhone(s string) (string, bool) {
+	n := len(digitsOf(s))
+	if n < phoneMinDigits || n > phoneMaxDigits {
+		return "", false
+	}
+	return maskDigits(s, phoneTail), true
+}
+
+// maskDigits заменяет все цифры, кроме последних visible, на маску.
+func maskDigits(s string, visible int) string {
+	total := len(digitsOf(s))
+
+	var b strings.Builder
+	var i int
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			b.WriteRune(c)
+			continue
+		}
+		if i >= total-visible {
+			b.WriteRune(c)
+		} else {
+			b.WriteRune(maskChar)
+		}
+		i++
+	}
+	return b.String()
+}
+
+func digitsOf(s string) []byte {
+	digits := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			digits = append(digits, s[i]-'0')
+		}
+	}
+	return digits
+}
+
+func luhnValid(digits []byte) bool {
+	if len(digits) == 0 {
+		return false
+	}
+
+	var sum int
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i])
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// ibanValid проверяет контрольную сумму IBAN по алгоритму ISO 7064 (mod 97).
+func ibanValid(s string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+
+	rearranged := iban[4:] + iban[:4]
+
+	var rem int
+	for _, c := range rearranged {
+		switch {
+		case c >= '0' && c <= '9':
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A') + 10) % 97
+		default:
+			return false
+		}
+	}
+	return rem == 1
+}
+
+func isAlnum(c rune) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,29 @@
+// Code generated by options-gen. DO NOT EDIT.
+package redactor
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from field tag (if present)
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// keepOriginal - сохранять ли исходный текст сообщения в отдельной колонке.
+func WithKeepOriginal(opt bool) OptOptionsSetter {
+	return func(o *Options) {
+		o.keepOriginal = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	return nil
+}
@@ -0,0 +1,132 @@
+package redactor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/redactor"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	r, err := redactor.New(redactor.NewOptions())
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		in       string
+		expBody  string
+		expKinds []redactor.Kind
+	}{
+		{
+			name:     "no sensitive data",
+			in:       "Привет! Не могу снять денег с карты",
+			expBody:  "Привет! Не могу снять денег с карты",
+			expKinds: nil,
+		},
+		{
+			name:     "card number with spaces",
+			in:       "Моя карта 4111 1111 1111 1111, помогите",
+			expBody:  "Моя карта **** **** **** 1111, помогите",
+			expKinds: []redactor.Kind{redactor.KindCardNumber},
+		},
+		{
+			name:     "card number without separators",
+			in:       "5500000000000004",
+			expBody:  "************0004",
+			expKinds: []redactor.Kind{redactor.KindCardNumber},
+		},
+		{
+			name:     "not luhn valid number is not a card",
+			in:       "Номер заявки 1234 5678 9012 3456",
+			expBody:  "Номер заявки 1234 5678 9012 3456",
+			expKinds: nil,
+		},
+		{
+			name:     "iban",
+			in:       "IBAN: DE89 3704 0044 0532 0130 00",
+			expBody:  "IBAN: DE** **** **** **** **30 00",
+			expKinds: []redactor.Kind{redactor.KindIBAN},
+		},
+		{
+			name:     "invalid iban",
+			in:       "DE00 3704 0044 0532 0130 00",
+			expBody:  "DE00 3704 0044 0532 0130 00",
+			expKinds: nil,
+		},
+		{
+			name:     "cvv",
+			in:       "cvv: 123",
+			expBody:  "cvv: ***",
+			expKinds: []redactor.Kind{redactor.KindCVV},
+		},
+		{
+			name:     "phone",
+			in:       "Перезвоните на +7 (999) 123-45-67",
+			expBody:  "Перезвоните на +* (***) ***-**-67",
+			expKinds: []redactor.Kind{redactor.KindPhone},
+		},
+		{
+			name:     "short number is not a phone",
+			in:       "Сумма 100 500",
+			expBody:  "Сумма 100 500",
+			expKinds: nil,
+		},
+		{
+			name:     "phone with area code in parentheses",
+			in:       "городской (495) 123-45-67",
+			expBody:  "городской (***) ***-**-67",
+			expKinds: []redactor.Kind{redactor.KindPhone},
+		},
+		{
+			name:     "international phone",
+			in:       "London +44 20 7946 0958",
+			expBody:  "London +** ** **** **58",
+			expKinds: []redactor.Kind{redactor.KindPhone},
+		},
+		{
+			name:     "date and time are not a phone",
+			in:       "Списание 2024-01-02 10:30, повтор 2024-01-02T10:30:00Z",
+			expBody:  "Списание 2024-01-02 10:30, повтор 2024-01-02T10:30:00Z",
+			expKinds: nil,
+		},
+		{
+			name:     "dotted date is not a phone",
+			in:       "Операция от 02.01.2024 10:30:15",
+			expBody:  "Операция от 02.01.2024 10:30:15",
+			expKinds: nil,
+		},
+		{
+			name:     "amount is not a phone",
+			in:       "Сумма 1 250 000,50 руб, лимит 8 500 000 000",
+			expBody:  "Сумма 1 250 000,50 руб, лимит 8 500 000 000",
+			expKinds: nil,
+		},
+		{
+			name:     "long order number is not a phone",
+			in:       "Заявка 202401021030",
+			expBody:  "Заявка 202401021030",
+			expKinds: nil,
+		},
+		{
+			name:    "everything together",
+			in:      "карта 4111111111111111 CVC 321 тел 89991234567",
+			expBody: "карта ************1111 CVC *** тел *********67",
+			expKinds: []redactor.Kind{
+				redactor.KindCardNumber,
+				redactor.KindCVV,
+				redactor.KindPhone,
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			res := r.Redact(tt.in)
+			assert.Equal(t, tt.expBody, res.Body)
+			assert.Equal(t, tt.expKinds, res.Kinds)
+			assert.Equal(t, len(tt.expKinds) > 0, res.Redacted())
+		})
+	}
+}
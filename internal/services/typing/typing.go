@@ -0,0 +1,163 @@
+package typing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/chat"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// sweepThreshold - при таком количестве записей троттлинга из них удаляются устаревшие.
+const sweepThreshold = 1024
+
+var (
+	ErrChatNotFound = errors.New("chat not found")
+	ErrNotAssigned  = errors.New("manager is not assigned to the chat")
+)
+
+type publisher interface {
+	Publish(ctx context.Context, userID types.UserID, event eventstream.Event) error
+}
+
+//go:generate options-gen -out-filename=typing_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	store     *store.Client `option:"mandatory" validate:"required"`
+	publisher publisher     `option:"mandatory" validate:"required"`
+	// throttle - минимальный интервал между событиями набора текста от одного пользователя в одном чате.
+	throttle time.Duration `validate:"min=100ms"`
+	now      func() time.Time
+}
+
+var defaultOptions = Options{
+	throttle: 3 * time.Second,
+	now:      time.Now,
+}
+
+// Service рассылает собеседнику сигналы о наборе текста.
+type Service struct {
+	Options
+
+	mu       sync.Mutex
+	lastSent map[throttleKey]time.Time
+}
+
+type throttleKey struct {
+	chatID types.ChatID
+	userID types.UserID
+}
+
+func New(opts Options) (*Service, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+
+	return &Service{
+		Options:  opts,
+		lastSent: make(map[throttleKey]time.Time),
+	}, nil
+}
+
+// ClientTyping сообщает менеджеру текущей проблемы, что клиент набирает сообщение.
+// Если у чата нет активной проблемы, сигнал никому не отправляется.
+func (s *Service) ClientTyping(ctx context.Context, clientID types.UserID) error {
+	c, err := s.store.Chat.Query().Where(chat.ClientID(clientID)).Only(ctx)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return ErrChatNotFound
+		}
+		return fmt.Errorf("query chat: %v", err)
+	}
+
+	if !s.allow(c.ID, clientID) {
+		return nil
+	}
+
+	p, err := s.store.Problem.Query().
+		Where(
+			problem.ChatID(c.ID),
+			problem.StatusIn(problem.StatusOpen, problem.StatusInProgress),
+		).
+		Order(problem.ByCreatedAt(), problem.ByID()).
+		First(ctx)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("query problem: %v", err)
+	}
+
+	return s.publish(ctx, p.ManagerID, c.ID, clientID)
+}
+
+// ManagerTyping сообщает клиенту, что менеджер набирает сообщение в его чате.
+// Сигнал принимается только от менеджера, которому назначена нерешенная проблема чата.
+func (s *Service) ManagerTyping(ctx context.Context, managerID types.UserID, chatID types.ChatID) error {
+	c, err := s.store.Chat.Get(ctx, chatID)
+	if err != nil {
+		if store.IsNotFound(err) {
+			return ErrChatNotFound
+		}
+		return fmt.Errorf("get chat: %v", err)
+	}
+
+	assigned, err := s.store.Problem.Query().
+		Where(
+			problem.ChatID(c.ID),
+			problem.ManagerID(managerID),
+			problem.StatusIn(problem.StatusOpen, problem.StatusInProgress),
+		).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("query problem: %v", err)
+	}
+	if !assigned {
+		return ErrNotAssigned
+	}
+
+	if !s.allow(c.ID, managerID) {
+		return nil
+	}
+
+	return s.publish(ctx, c.ClientID, c.ID, managerID)
+}
+
+func (s *Service) publish(ctx context.Context, to types.UserID, chatID types.ChatID, from types.UserID) error {
+	if err := s.publisher.Publish(ctx, to, eventstream.TypingEvent{
+		ChatID: chatID,
+		UserID: from,
+		At:     s.now(),
+	}); err != nil {
+		return fmt.Errorf("publish typing event: %v", err)
+	}
+	return nil
+}
+
+// allow проверяет, можно ли отправить сигнал, и запоминает время отправки.
+func (s *Service) allow(chatID types.ChatID, userID types.UserID) bool {
+	now := s.now()
+	key := throttleKey{chatID: chatID, userID: userID}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if last, ok := s.lastSent[key]; ok && now.Sub(last) < s.throttle {
+		return false
+	}
+
+	if len(s.lastSent) >= sweepThreshold {
+		for k, last := range s.lastSent {
+			if now.Sub(last) >= s.throttle {
+				delete(s.lastSent, k)
+			}
+		}
+	}
+	s.lastSent[key] = now
+	return true
+}
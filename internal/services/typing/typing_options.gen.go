@@ -0,0 +1,83 @@
+// Code generated by options-gen. DO NOT EDIT.
+package typing
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	store *store.Client,
+	publisher publisher,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.store = defaultOptions.store
+
+	o.publisher = defaultOptions.publisher
+
+	o.throttle = defaultOptions.throttle
+
+	o.now = defaultOptions.now
+
+	o.store = store
+
+	o.publisher = publisher
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// throttle - минимальный интервал между событиями набора текста от одного пользователя в одном чате.
+func WithThrottle(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.throttle = opt
+
+	}
+}
+
+func WithNow(opt func() time.Time) OptOptionsSetter {
+	return func(o *Options) {
+		o.now = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("store", _validate_Options_store(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("publisher", _validate_Options_publisher(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("throttle", _validate_Options_throttle(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_store(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.store, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `store` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_publisher(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.publisher, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `publisher` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_throttle(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.throttle, "min=100ms"); err != nil {
+		return fmt461e464ebed9.Errorf("field `throttle` did not pass the test: %w", err)
+	}
+	return nil
+}
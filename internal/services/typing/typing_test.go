@@ -0,0 +1,104 @@
+package typing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/services/typing"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+type publishedEvent struct {
+	to    types.UserID
+	event eventstream.Event
+}
+
+type fakePublisher struct {
+	events []publishedEvent
+}
+
+func (p *fakePublisher) Publish(_ context.Context, userID types.UserID, event eventstream.Event) error {
+	p.events = append(p.events, publishedEvent{to: userID, event: event})
+	return nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+
+	client := enttest.Open(t, "sqlite3", "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	defer func() { require.NoError(t, client.Close()) }()
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pub := new(fakePublisher)
+	svc, err := typing.New(typing.NewOptions(
+		client,
+		pub,
+		typing.WithThrottle(3*time.Second),
+		typing.WithNow(func() time.Time { return now }),
+	))
+	require.NoError(t, err)
+
+	clientID, managerID := types.NewUserID(), types.NewUserID()
+	chat := client.Chat.Create().SetClientID(clientID).SaveX(ctx)
+
+	t.Run("no chat", func(t *testing.T) {
+		err := svc.ClientTyping(ctx, types.NewUserID())
+		require.ErrorIs(t, err, typing.ErrChatNotFound)
+	})
+
+	t.Run("no active problem", func(t *testing.T) {
+		require.NoError(t, svc.ClientTyping(ctx, clientID))
+		assert.Empty(t, pub.events)
+	})
+
+	client.Problem.Create().SetChatID(chat.ID).SetManagerID(managerID).SetStatus(problem.StatusInProgress).SaveX(ctx)
+
+	t.Run("client to manager with throttling", func(t *testing.T) {
+		pub.events = nil
+		now = now.Add(time.Minute)
+
+		require.NoError(t, svc.ClientTyping(ctx, clientID))
+		require.NoError(t, svc.ClientTyping(ctx, clientID))
+		now = now.Add(3 * time.Second)
+		require.NoError(t, svc.ClientTyping(ctx, clientID))
+
+		require.Len(t, pub.events, 2)
+		assert.Equal(t, managerID, pub.events[0].to)
+		assert.Equal(t, eventstream.TypingEvent{ChatID: chat.ID, UserID: clientID, At: now.Add(-3 * time.Second)}, pub.events[0].event)
+	})
+
+	t.Run("manager to client", func(t *testing.T) {
+		pub.events = nil
+
+		require.NoError(t, svc.ManagerTyping(ctx, managerID, chat.ID))
+		require.Len(t, pub.events, 1)
+		assert.Equal(t, clientID, pub.events[0].to)
+
+		err := svc.ManagerTyping(ctx, managerID, types.NewChatID())
+		require.ErrorIs(t, err, typing.ErrChatNotFound)
+	})
+
+	t.Run("manager is not assigned", func(t *testing.T) {
+		pub.events = nil
+		now = now.Add(time.Minute)
+
+		err := svc.ManagerTyping(ctx, types.NewUserID(), chat.ID)
+		require.ErrorIs(t, err, typing.ErrNotAssigned)
+
+		// Назначение на решенную проблему не дает права писать в чат.
+		otherManagerID := types.NewUserID()
+		client.Problem.Create().SetChatID(chat.ID).SetManagerID(otherManagerID).SetStatus(problem.StatusResolved).SaveX(ctx)
+		err = svc.ManagerTyping(ctx, otherManagerID, chat.ID)
+		require.ErrorIs(t, err, typing.ErrNotAssigned)
+
+		assert.Empty(t, pub.events)
+	})
+}
@@ -0,0 +1,555 @@
+// Code generated by ent, DO NOT EDIT.
+
+package attachment
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/FischukSergey/chat-service/internal/store/predicate"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id types.AttachmentID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id types.AttachmentID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id types.AttachmentID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...types.AttachmentID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...types.AttachmentID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id types.AttachmentID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id types.AttachmentID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id types.AttachmentID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id types.AttachmentID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLTE(FieldID, id))
+}
+
+// UploaderID applies equality check predicate on the "uploader_id" field. It's identical to UploaderIDEQ.
+func UploaderID(v types.UserID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldUploaderID, v))
+}
+
+// MessageID applies equality check predicate on the "message_id" field. It's identical to MessageIDEQ.
+func MessageID(v types.MessageID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldMessageID, v))
+}
+
+// FileName applies equality check predicate on the "file_name" field. It's identical to FileNameEQ.
+func FileName(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldFileName, v))
+}
+
+// ContentType applies equality check predicate on the "content_type" field. It's identical to ContentTypeEQ.
+func ContentType(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldContentType, v))
+}
+
+// Size applies equality check predicate on the "size" field. It's identical to SizeEQ.
+func Size(v int64) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldSize, v))
+}
+
+// BlobKey applies equality check predicate on the "blob_key" field. It's identical to BlobKeyEQ.
+func BlobKey(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldBlobKey, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UploaderIDEQ applies the EQ predicate on the "uploader_id" field.
+func UploaderIDEQ(v types.UserID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldUploaderID, v))
+}
+
+// UploaderIDNEQ applies the NEQ predicate on the "uploader_id" field.
+func UploaderIDNEQ(v types.UserID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNEQ(FieldUploaderID, v))
+}
+
+// UploaderIDIn applies the In predicate on the "uploader_id" field.
+func UploaderIDIn(vs ...types.UserID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldIn(FieldUploaderID, vs...))
+}
+
+// UploaderIDNotIn applies the NotIn predicate on the "uploader_id" field.
+func UploaderIDNotIn(vs ...types.UserID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNotIn(FieldUploaderID, vs...))
+}
+
+// UploaderIDGT applies the GT predicate on the "uploader_id" field.
+func UploaderIDGT(v types.UserID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGT(FieldUploaderID, v))
+}
+
+// UploaderIDGTE applies the GTE predicate on the "uploader_id" field.
+func UploaderIDGTE(v types.UserID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGTE(FieldUploaderID, v))
+}
+
+// UploaderIDLT applies the LT predicate on the "uploader_id" field.
+func UploaderIDLT(v types.UserID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLT(FieldUploaderID, v))
+}
+
+// UploaderIDLTE applies the LTE predicate on the "uploader_id" field.
+func UploaderIDLTE(v types.UserID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLTE(FieldUploaderID, v))
+}
+
+// UploaderIDContains applies the Contains predicate on the "uploader_id" field.
+func UploaderIDContains(v types.UserID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldContains(FieldUploaderID, vc))
+}
+
+// UploaderIDHasPrefix applies the HasPrefix predicate on the "uploader_id" field.
+func UploaderIDHasPrefix(v types.UserID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldHasPrefix(FieldUploaderID, vc))
+}
+
+// UploaderIDHasSuffix applies the HasSuffix predicate on the "uploader_id" field.
+func UploaderIDHasSuffix(v types.UserID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldHasSuffix(FieldUploaderID, vc))
+}
+
+// UploaderIDEqualFold applies the EqualFold predicate on the "uploader_id" field.
+func UploaderIDEqualFold(v types.UserID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldEqualFold(FieldUploaderID, vc))
+}
+
+// UploaderIDContainsFold applies the ContainsFold predicate on the "uploader_id" field.
+func UploaderIDContainsFold(v types.UserID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldContainsFold(FieldUploaderID, vc))
+}
+
+// MessageIDEQ applies the EQ predicate on the "message_id" field.
+func MessageIDEQ(v types.MessageID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldMessageID, v))
+}
+
+// MessageIDNEQ applies the NEQ predicate on the "message_id" field.
+func MessageIDNEQ(v types.MessageID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNEQ(FieldMessageID, v))
+}
+
+// MessageIDIn applies the In predicate on the "message_id" field.
+func MessageIDIn(vs ...types.MessageID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldIn(FieldMessageID, vs...))
+}
+
+// MessageIDNotIn applies the NotIn predicate on the "message_id" field.
+func MessageIDNotIn(vs ...types.MessageID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNotIn(FieldMessageID, vs...))
+}
+
+// MessageIDGT applies the GT predicate on the "message_id" field.
+func MessageIDGT(v types.MessageID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGT(FieldMessageID, v))
+}
+
+// MessageIDGTE applies the GTE predicate on the "message_id" field.
+func MessageIDGTE(v types.MessageID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGTE(FieldMessageID, v))
+}
+
+// MessageIDLT applies the LT predicate on the "message_id" field.
+func MessageIDLT(v types.MessageID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLT(FieldMessageID, v))
+}
+
+// MessageIDLTE applies the LTE predicate on the "message_id" field.
+func MessageIDLTE(v types.MessageID) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLTE(FieldMessageID, v))
+}
+
+// MessageIDContains applies the Contains predicate on the "message_id" field.
+func MessageIDContains(v types.MessageID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldContains(FieldMessageID, vc))
+}
+
+// MessageIDHasPrefix applies the HasPrefix predicate on the "message_id" field.
+func MessageIDHasPrefix(v types.MessageID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldHasPrefix(FieldMessageID, vc))
+}
+
+// MessageIDHasSuffix applies the HasSuffix predicate on the "message_id" field.
+func MessageIDHasSuffix(v types.MessageID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldHasSuffix(FieldMessageID, vc))
+}
+
+// MessageIDIsNil applies the IsNil predicate on the "message_id" field.
+func MessageIDIsNil() predicate.Attachment {
+	return predicate.Attachment(sql.FieldIsNull(FieldMessageID))
+}
+
+// MessageIDNotNil applies the NotNil predicate on the "message_id" field.
+func MessageIDNotNil() predicate.Attachment {
+	return predicate.Attachment(sql.FieldNotNull(FieldMessageID))
+}
+
+// MessageIDEqualFold applies the EqualFold predicate on the "message_id" field.
+func MessageIDEqualFold(v types.MessageID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldEqualFold(FieldMessageID, vc))
+}
+
+// MessageIDContainsFold applies the ContainsFold predicate on the "message_id" field.
+func MessageIDContainsFold(v types.MessageID) predicate.Attachment {
+	vc := v.String()
+	return predicate.Attachment(sql.FieldContainsFold(FieldMessageID, vc))
+}
+
+// FileNameEQ applies the EQ predicate on the "file_name" field.
+func FileNameEQ(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldFileName, v))
+}
+
+// FileNameNEQ applies the NEQ predicate on the "file_name" field.
+func FileNameNEQ(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNEQ(FieldFileName, v))
+}
+
+// FileNameIn applies the In predicate on the "file_name" field.
+func FileNameIn(vs ...string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldIn(FieldFileName, vs...))
+}
+
+// FileNameNotIn applies the NotIn predicate on the "file_name" field.
+func FileNameNotIn(vs ...string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNotIn(FieldFileName, vs...))
+}
+
+// FileNameGT applies the GT predicate on the "file_name" field.
+func FileNameGT(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGT(FieldFileName, v))
+}
+
+// FileNameGTE applies the GTE predicate on the "file_name" field.
+func FileNameGTE(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGTE(FieldFileName, v))
+}
+
+// FileNameLT applies the LT predicate on the "file_name" field.
+func FileNameLT(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLT(FieldFileName, v))
+}
+
+// FileNameLTE applies the LTE predicate on the "file_name" field.
+func FileNameLTE(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLTE(FieldFileName, v))
+}
+
+// FileNameContains applies the Contains predicate on the "file_name" field.
+func FileNameContains(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldContains(FieldFileName, v))
+}
+
+// FileNameHasPrefix applies the HasPrefix predicate on the "file_name" field.
+func FileNameHasPrefix(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldHasPrefix(FieldFileName, v))
+}
+
+// FileNameHasSuffix applies the HasSuffix predicate on the "file_name" field.
+func FileNameHasSuffix(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldHasSuffix(FieldFileName, v))
+}
+
+// FileNameEqualFold applies the EqualFold predicate on the "file_name" field.
+func FileNameEqualFold(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEqualFold(FieldFileName, v))
+}
+
+// FileNameContainsFold applies the ContainsFold predicate on the "file_name" field.
+func FileNameContainsFold(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldContainsFold(FieldFileName, v))
+}
+
+// ContentTypeEQ applies the EQ predicate on the "content_type" field.
+func ContentTypeEQ(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldContentType, v))
+}
+
+// ContentTypeNEQ applies the NEQ predicate on the "content_type" field.
+func ContentTypeNEQ(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNEQ(FieldContentType, v))
+}
+
+// ContentTypeIn applies the In predicate on the "content_type" field.
+func ContentTypeIn(vs ...string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldIn(FieldContentType, vs...))
+}
+
+// ContentTypeNotIn applies the NotIn predicate on the "content_type" field.
+func ContentTypeNotIn(vs ...string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNotIn(FieldContentType, vs...))
+}
+
+// ContentTypeGT applies the GT predicate on the "content_type" field.
+func ContentTypeGT(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGT(FieldContentType, v))
+}
+
+// ContentTypeGTE applies the GTE predicate on the "content_type" field.
+func ContentTypeGTE(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGTE(FieldContentType, v))
+}
+
+// ContentTypeLT applies the LT predicate on the "content_type" field.
+func ContentTypeLT(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLT(FieldContentType, v))
+}
+
+// ContentTypeLTE applies the LTE predicate on the "content_type" field.
+func ContentTypeLTE(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLTE(FieldContentType, v))
+}
+
+// ContentTypeContains applies the Contains predicate on the "content_type" field.
+func ContentTypeContains(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldContains(FieldContentType, v))
+}
+
+// ContentTypeHasPrefix applies the HasPrefix predicate on the "content_type" field.
+func ContentTypeHasPrefix(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldHasPrefix(FieldContentType, v))
+}
+
+// ContentTypeHasSuffix applies the HasSuffix predicate on the "content_type" field.
+func ContentTypeHasSuffix(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldHasSuffix(FieldContentType, v))
+}
+
+// ContentTypeEqualFold applies the EqualFold predicate on the "content_type" field.
+func ContentTypeEqualFold(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEqualFold(FieldContentType, v))
+}
+
+// ContentTypeContainsFold applies the ContainsFold predicate on the "content_type" field.
+func ContentTypeContainsFold(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldContainsFold(FieldContentType, v))
+}
+
+// SizeEQ applies the EQ predicate on the "size" field.
+func SizeEQ(v int64) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldSize, v))
+}
+
+// SizeNEQ applies the NEQ predicate on the "size" field.
+func SizeNEQ(v int64) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNEQ(FieldSize, v))
+}
+
+// SizeIn applies the In predicate on the "size" field.
+func SizeIn(vs ...int64) predicate.Attachment {
+	return predicate.Attachment(sql.FieldIn(FieldSize, vs...))
+}
+
+// SizeNotIn applies the NotIn predicate on the "size" field.
+func SizeNotIn(vs ...int64) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNotIn(FieldSize, vs...))
+}
+
+// SizeGT applies the GT predicate on the "size" field.
+func SizeGT(v int64) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGT(FieldSize, v))
+}
+
+// SizeGTE applies the GTE predicate on the "size" field.
+func SizeGTE(v int64) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGTE(FieldSize, v))
+}
+
+// SizeLT applies the LT predicate on the "size" field.
+func SizeLT(v int64) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLT(FieldSize, v))
+}
+
+// SizeLTE applies the LTE predicate on the "size" field.
+func SizeLTE(v int64) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLTE(FieldSize, v))
+}
+
+// BlobKeyEQ applies the EQ predicate on the "blob_key" field.
+func BlobKeyEQ(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldBlobKey, v))
+}
+
+// BlobKeyNEQ applies the NEQ predicate on the "blob_key" field.
+func BlobKeyNEQ(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNEQ(FieldBlobKey, v))
+}
+
+// BlobKeyIn applies the In predicate on the "blob_key" field.
+func BlobKeyIn(vs ...string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldIn(FieldBlobKey, vs...))
+}
+
+// BlobKeyNotIn applies the NotIn predicate on the "blob_key" field.
+func BlobKeyNotIn(vs ...string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNotIn(FieldBlobKey, vs...))
+}
+
+// BlobKeyGT applies the GT predicate on the "blob_key" field.
+func BlobKeyGT(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGT(FieldBlobKey, v))
+}
+
+// BlobKeyGTE applies the GTE predicate on the "blob_key" field.
+func BlobKeyGTE(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGTE(FieldBlobKey, v))
+}
+
+// BlobKeyLT applies the LT predicate on the "blob_key" field.
+func BlobKeyLT(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLT(FieldBlobKey, v))
+}
+
+// BlobKeyLTE applies the LTE predicate on the "blob_key" field.
+func BlobKeyLTE(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLTE(FieldBlobKey, v))
+}
+
+// BlobKeyContains applies the Contains predicate on the "blob_key" field.
+func BlobKeyContains(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldContains(FieldBlobKey, v))
+}
+
+// BlobKeyHasPrefix applies the HasPrefix predicate on the "blob_key" field.
+func BlobKeyHasPrefix(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldHasPrefix(FieldBlobKey, v))
+}
+
+// BlobKeyHasSuffix applies the HasSuffix predicate on the "blob_key" field.
+func BlobKeyHasSuffix(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldHasSuffix(FieldBlobKey, v))
+}
+
+// BlobKeyEqualFold applies the EqualFold predicate on the "blob_key" field.
+func BlobKeyEqualFold(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEqualFold(FieldBlobKey, v))
+}
+
+// BlobKeyContainsFold applies the ContainsFold predicate on the "blob_key" field.
+func BlobKeyContainsFold(v string) predicate.Attachment {
+	return predicate.Attachment(sql.FieldContainsFold(FieldBlobKey, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.Attachment {
+	return predicate.Attachment(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.Attachment {
+	return predicate.Attachment(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.Attachment {
+	return predicate.Attachment(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.Attachment {
+	return predicate.Attachment(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.Attachment {
+	return predicate.Attachment(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// HasMessage applies the HasEdge predicate on the "message" edge.
+func HasMessage() predicate.Attachment {
+	return predicate.Attachment(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, MessageTable, MessageColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasMessageWith applies the HasEdge predicate on the "message" edge with a given conditions (other predicates).
+func HasMessageWith(preds ...predicate.Message) predicate.Attachment {
+	return predicate.Attachment(func(s *sql.Selector) {
+		step := newMessageStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.Attachment) predicate.Attachment {
+	return predicate.Attachment(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.Attachment) predicate.Attachment {
+	return predicate.Attachment(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.Attachment) predicate.Attachment {
+	return predicate.Attachment(sql.NotPredicates(p))
+}
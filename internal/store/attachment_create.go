@@ -0,0 +1,363 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/FischukSergey/chat-service/internal/store/attachment"
+	"github.com/FischukSergey/chat-service/internal/store/message"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// AttachmentCreate is the builder for creating a Attachment entity.
+type AttachmentCreate struct {
+	config
+	mutation *AttachmentMutation
+	hooks    []Hook
+}
+
+// SetUploaderID sets the "uploader_id" field.
+func (ac *AttachmentCreate) SetUploaderID(ti types.UserID) *AttachmentCreate {
+	ac.mutation.SetUploaderID(ti)
+	return ac
+}
+
+// SetMessageID sets the "message_id" field.
+func (ac *AttachmentCreate) SetMessageID(ti types.MessageID) *AttachmentCreate {
+	ac.mutation.SetMessageID(ti)
+	return ac
+}
+
+// SetNillableMessageID sets the "message_id" field if the given value is not nil.
+func (ac *AttachmentCreate) SetNillableMessageID(ti *types.MessageID) *AttachmentCreate {
+	if ti != nil {
+		ac.SetMessageID(*ti)
+	}
+	return ac
+}
+
+// SetFileName sets the "file_name" field.
+func (ac *AttachmentCreate) SetFileName(s string) *AttachmentCreate {
+	ac.mutation.SetFileName(s)
+	return ac
+}
+
+// SetContentType sets the "content_type" field.
+func (ac *AttachmentCreate) SetContentType(s string) *AttachmentCreate {
+	ac.mutation.SetContentType(s)
+	return ac
+}
+
+// SetSize sets the "size" field.
+func (ac *AttachmentCreate) SetSize(i int64) *AttachmentCreate {
+	ac.mutation.SetSize(i)
+	return ac
+}
+
+// SetBlobKey sets the "blob_key" field.
+func (ac *AttachmentCreate) SetBlobKey(s string) *AttachmentCreate {
+	ac.mutation.SetBlobKey(s)
+	return ac
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ac *AttachmentCreate) SetCreatedAt(t time.Time) *AttachmentCreate {
+	ac.mutation.SetCreatedAt(t)
+	return ac
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ac *AttachmentCreate) SetNillableCreatedAt(t *time.Time) *AttachmentCreate {
+	if t != nil {
+		ac.SetCreatedAt(*t)
+	}
+	return ac
+}
+
+// SetID sets the "id" field.
+func (ac *AttachmentCreate) SetID(ti types.AttachmentID) *AttachmentCreate {
+	ac.mutation.SetID(ti)
+	return ac
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (ac *AttachmentCreate) SetNillableID(ti *types.AttachmentID) *AttachmentCreate {
+	if ti != nil {
+		ac.SetID(*ti)
+	}
+	return ac
+}
+
+// SetMessage sets the "message" edge to the Message entity.
+func (ac *AttachmentCreate) SetMessage(m *Message) *AttachmentCreate {
+	return ac.SetMessageID(m.ID)
+}
+
+// Mutation returns the AttachmentMutation object of the builder.
+func (ac *AttachmentCreate) Mutation() *AttachmentMutation {
+	return ac.mutation
+}
+
+// Save creates the Attachment in the database.
+func (ac *AttachmentCreate) Save(ctx context.Context) (*Attachment, error) {
+	ac.defaults()
+	return withHooks(ctx, ac.sqlSave, ac.mutation, ac.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ac *AttachmentCreate) SaveX(ctx context.Context) *Attachment {
+	v, err := ac.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ac *AttachmentCreate) Exec(ctx context.Context) error {
+	_, err := ac.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ac *AttachmentCreate) ExecX(ctx context.Context) {
+	if err := ac.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ac *AttachmentCreate) defaults() {
+	if _, ok := ac.mutation.CreatedAt(); !ok {
+		v := attachment.DefaultCreatedAt()
+		ac.mutation.SetCreatedAt(v)
+	}
+	if _, ok := ac.mutation.ID(); !ok {
+		v := attachment.DefaultID()
+		ac.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ac *AttachmentCreate) check() error {
+	if _, ok := ac.mutation.UploaderID(); !ok {
+		return &ValidationError{Name: "uploader_id", err: errors.New(`store: missing required field "Attachment.uploader_id"`)}
+	}
+	if v, ok := ac.mutation.UploaderID(); ok {
+		if err := attachment.UploaderIDValidator(v.String()); err != nil {
+			return &ValidationError{Name: "uploader_id", err: fmt.Errorf(`store: validator failed for field "Attachment.uploader_id": %w`, err)}
+		}
+	}
+	if v, ok := ac.mutation.MessageID(); ok {
+		if err := v.Validate(); err != nil {
+			return &ValidationError{Name: "message_id", err: fmt.Errorf(`store: validator failed for field "Attachment.message_id": %w`, err)}
+		}
+	}
+	if _, ok := ac.mutation.FileName(); !ok {
+		return &ValidationError{Name: "file_name", err: errors.New(`store: missing required field "Attachment.file_name"`)}
+	}
+	if v, ok := ac.mutation.FileName(); ok {
+		if err := attachment.FileNameValidator(v); err != nil {
+			return &ValidationError{Name: "file_name", err: fmt.Errorf(`store: validator failed for field "Attachment.file_name": %w`, err)}
+		}
+	}
+	if _, ok := ac.mutation.ContentType(); !ok {
+		return &ValidationError{Name: "content_type", err: errors.New(`store: missing required field "Attachment.content_type"`)}
+	}
+	if v, ok := ac.mutation.ContentType(); ok {
+		if err := attachment.ContentTypeValidator(v); err != nil {
+			return &ValidationError{Name: "content_type", err: fmt.Errorf(`store: validator failed for field "Attachment.content_type": %w`, err)}
+		}
+	}
+	if _, ok := ac.mutation.Size(); !ok {
+		return &ValidationError{Name: "size", err: errors.New(`store: missing required field "Attachment.size"`)}
+	}
+	if v, ok := ac.mutation.Size(); ok {
+		if err := attachment.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`store: validator failed for field "Attachment.size": %w`, err)}
+		}
+	}
+	if _, ok := ac.mutation.BlobKey(); !ok {
+		return &ValidationError{Name: "blob_key", err: errors.New(`store: missing required field "Attachment.blob_key"`)}
+	}
+	if v, ok := ac.mutation.BlobKey(); ok {
+		if err := attachment.BlobKeyValidator(v); err != nil {
+			return &ValidationError{Name: "blob_key", err: fmt.Errorf(`store: validator failed for field "Attachment.blob_key": %w`, err)}
+		}
+	}
+	if _, ok := ac.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`store: missing required field "Attachment.created_at"`)}
+	}
+	if v, ok := ac.mutation.ID(); ok {
+		if err := v.Validate(); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`store: validator failed for field "Attachment.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ac *AttachmentCreate) sqlSave(ctx context.Context) (*Attachment, error) {
+	if err := ac.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ac.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ac.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*types.AttachmentID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	ac.mutation.id = &_node.ID
+	ac.mutation.done = true
+	return _node, nil
+}
+
+func (ac *AttachmentCreate) createSpec() (*Attachment, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Attachment{config: ac.config}
+		_spec = sqlgraph.NewCreateSpec(attachment.Table, sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeString))
+	)
+	if id, ok := ac.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := ac.mutation.UploaderID(); ok {
+		_spec.SetField(attachment.FieldUploaderID, field.TypeString, value)
+		_node.UploaderID = value
+	}
+	if value, ok := ac.mutation.FileName(); ok {
+		_spec.SetField(attachment.FieldFileName, field.TypeString, value)
+		_node.FileName = value
+	}
+	if value, ok := ac.mutation.ContentType(); ok {
+		_spec.SetField(attachment.FieldContentType, field.TypeString, value)
+		_node.ContentType = value
+	}
+	if value, ok := ac.mutation.Size(); ok {
+		_spec.SetField(attachment.FieldSize, field.TypeInt64, value)
+		_node.Size = value
+	}
+	if value, ok := ac.mutation.BlobKey(); ok {
+		_spec.SetField(attachment.FieldBlobKey, field.TypeString, value)
+		_node.BlobKey = value
+	}
+	if value, ok := ac.mutation.CreatedAt(); ok {
+		_spec.SetField(attachment.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := ac.mutation.MessageIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   attachment.MessageTable,
+			Columns: []string{attachment.MessageColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(message.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.MessageID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// AttachmentCreateBulk is the builder for creating many Attachment entities in bulk.
+type AttachmentCreateBulk struct {
+	config
+	err      error
+	builders []*AttachmentCreate
+}
+
+// Save creates the Attachment entities in the database.
+func (acb *AttachmentCreateBulk) Save(ctx context.Context) ([]*Attachment, error) {
+	if acb.err != nil {
+		return nil, acb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(acb.builders))
+	nodes := make([]*Attachment, len(acb.builders))
+	mutators := make([]Mutator, len(acb.builders))
+	for i := range acb.builders {
+		func(i int, root context.Context) {
+			builder := acb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AttachmentMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, acb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, acb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, acb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (acb *AttachmentCreateBulk) SaveX(ctx context.Context) []*Attachment {
+	v, err := acb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (acb *AttachmentCreateBulk) Exec(ctx context.Context) error {
+	_, err := acb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (acb *AttachmentCreateBulk) ExecX(ctx context.Context) {
+	if err := acb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
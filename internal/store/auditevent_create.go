@@ -0,0 +1,336 @@
+// Code generated by ent, DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/FischukSergey/chat-service/internal/store/auditevent"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// AuditEventCreate is the builder for creating a AuditEvent entity.
+type AuditEventCreate struct {
+	config
+	mutation *AuditEventMutation
+	hooks    []Hook
+}
+
+// SetActor sets the "actor" field.
+func (aec *AuditEventCreate) SetActor(s string) *AuditEventCreate {
+	aec.mutation.SetActor(s)
+	return aec
+}
+
+// SetNillableActor sets the "actor" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableActor(s *string) *AuditEventCreate {
+	if s != nil {
+		aec.SetActor(*s)
+	}
+	return aec
+}
+
+// SetAction sets the "action" field.
+func (aec *AuditEventCreate) SetAction(a auditevent.Action) *AuditEventCreate {
+	aec.mutation.SetAction(a)
+	return aec
+}
+
+// SetTarget sets the "target" field.
+func (aec *AuditEventCreate) SetTarget(s string) *AuditEventCreate {
+	aec.mutation.SetTarget(s)
+	return aec
+}
+
+// SetNillableTarget sets the "target" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableTarget(s *string) *AuditEventCreate {
+	if s != nil {
+		aec.SetTarget(*s)
+	}
+	return aec
+}
+
+// SetMetadata sets the "metadata" field.
+func (aec *AuditEventCreate) SetMetadata(m map[string]interface{}) *AuditEventCreate {
+	aec.mutation.SetMetadata(m)
+	return aec
+}
+
+// SetRequestID sets the "request_id" field.
+func (aec *AuditEventCreate) SetRequestID(ti types.RequestID) *AuditEventCreate {
+	aec.mutation.SetRequestID(ti)
+	return aec
+}
+
+// SetNillableRequestID sets the "request_id" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableRequestID(ti *types.RequestID) *AuditEventCreate {
+	if ti != nil {
+		aec.SetRequestID(*ti)
+	}
+	return aec
+}
+
+// SetIP sets the "ip" field.
+func (aec *AuditEventCreate) SetIP(s string) *AuditEventCreate {
+	aec.mutation.SetIP(s)
+	return aec
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableIP(s *string) *AuditEventCreate {
+	if s != nil {
+		aec.SetIP(*s)
+	}
+	return aec
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (aec *AuditEventCreate) SetCreatedAt(t time.Time) *AuditEventCreate {
+	aec.mutation.SetCreatedAt(t)
+	return aec
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableCreatedAt(t *time.Time) *AuditEventCreate {
+	if t != nil {
+		aec.SetCreatedAt(*t)
+	}
+	return aec
+}
+
+// SetID sets the "id" field.
+func (aec *AuditEventCreate) SetID(tei types.AuditEventID) *AuditEventCreate {
+	aec.mutation.SetID(tei)
+	return aec
+}
+
+// SetNillableID sets the "id" field if the given value is not nil.
+func (aec *AuditEventCreate) SetNillableID(tei *types.AuditEventID) *AuditEventCreate {
+	if tei != nil {
+		aec.SetID(*tei)
+	}
+	return aec
+}
+
+// Mutation returns the AuditEventMutation object of the builder.
+func (aec *AuditEventCreate) Mutation() *AuditEventMutation {
+	return aec.mutation
+}
+
+// Save creates the AuditEvent in the database.
+func (aec *AuditEventCreate) Save(ctx context.Context) (*AuditEvent, error) {
+	aec.defaults()
+	return withHooks(ctx, aec.sqlSave, aec.mutation, aec.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (aec *AuditEventCreate) SaveX(ctx context.Context) *AuditEvent {
+	v, err := aec.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (aec *AuditEventCreate) Exec(ctx context.Context) error {
+	_, err := aec.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aec *AuditEventCreate) ExecX(ctx context.Context) {
+	if err := aec.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (aec *AuditEventCreate) defaults() {
+	if _, ok := aec.mutation.CreatedAt(); !ok {
+		v := auditevent.DefaultCreatedAt()
+		aec.mutation.SetCreatedAt(v)
+	}
+	if _, ok := aec.mutation.ID(); !ok {
+		v := auditevent.DefaultID()
+		aec.mutation.SetID(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aec *AuditEventCreate) check() error {
+	if _, ok := aec.mutation.Action(); !ok {
+		return &ValidationError{Name: "action", err: errors.New(`store: missing required field "AuditEvent.action"`)}
+	}
+	if v, ok := aec.mutation.Action(); ok {
+		if err := auditevent.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`store: validator failed for field "AuditEvent.action": %w`, err)}
+		}
+	}
+	if v, ok := aec.mutation.RequestID(); ok {
+		if err := v.Validate(); err != nil {
+			return &ValidationError{Name: "request_id", err: fmt.Errorf(`store: validator failed for field "AuditEvent.request_id": %w`, err)}
+		}
+	}
+	if _, ok := aec.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`store: missing required field "AuditEvent.created_at"`)}
+	}
+	if v, ok := aec.mutation.ID(); ok {
+		if err := v.Validate(); err != nil {
+			return &ValidationError{Name: "id", err: fmt.Errorf(`store: validator failed for field "AuditEvent.id": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (aec *AuditEventCreate) sqlSave(ctx context.Context) (*AuditEvent, error) {
+	if err := aec.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := aec.createSpec()
+	if err := sqlgraph.CreateNode(ctx, aec.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != nil {
+		if id, ok := _spec.ID.Value.(*types.AuditEventID); ok {
+			_node.ID = *id
+		} else if err := _node.ID.Scan(_spec.ID.Value); err != nil {
+			return nil, err
+		}
+	}
+	aec.mutation.id = &_node.ID
+	aec.mutation.done = true
+	return _node, nil
+}
+
+func (aec *AuditEventCreate) createSpec() (*AuditEvent, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuditEvent{config: aec.config}
+		_spec = sqlgraph.NewCreateSpec(auditevent.Table, sqlgraph.NewFieldSpec(auditevent.FieldID, field.TypeString))
+	)
+	if id, ok := aec.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = &id
+	}
+	if value, ok := aec.mutation.Actor(); ok {
+		_spec.SetField(auditevent.FieldActor, field.TypeString, value)
+		_node.Actor = value
+	}
+	if value, ok := aec.mutation.Action(); ok {
+		_spec.SetField(auditevent.FieldAction, field.TypeEnum, value)
+		_node.Action = value
+	}
+	if value, ok := aec.mutation.Target(); ok {
+		_spec.SetField(auditevent.FieldTarget, field.TypeString, value)
+		_node.Target = value
+	}
+	if value, ok := aec.mutation.Metadata(); ok {
+		_spec.SetField(auditevent.FieldMetadata, field.TypeJSON, value)
+		_node.Metadata = value
+	}
+	if value, ok := aec.mutation.RequestID(); ok {
+		_spec.SetField(auditevent.FieldRequestID, field.TypeString, value)
+		_node.RequestID = value
+	}
+	if value, ok := aec.mutation.IP(); ok {
+		_spec.SetField(auditevent.FieldIP, field.TypeString, value)
+		_node.IP = value
+	}
+	if value, ok := aec.mutation.CreatedAt(); ok {
+		_spec.SetField(auditevent.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// AuditEventCreateBulk is the builder for creating many AuditEvent entities in bulk.
+type AuditEventCreateBulk struct {
+	config
+	err      error
+	builders []*AuditEventCreate
+}
+
+// Save creates the AuditEvent entities in the database.
+func (aecb *AuditEventCreateBulk) Save(ctx context.Context) ([]*AuditEvent, error) {
+	if aecb.err != nil {
+		return nil, aecb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(aecb.builders))
+	nodes := make([]*AuditEvent, len(aecb.builders))
+	mutators := make([]Mutator, len(aecb.builders))
+	for i := range aecb.builders {
+		func(i int, root context.Context) {
+			builder := aecb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuditEventMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, aecb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, aecb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, aecb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aecb *AuditEventCreateBulk) SaveX(ctx context.Context) []*AuditEvent {
+	v, err := aecb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (aecb *AuditEventCreateBulk) Exec(ctx context.Context) error {
+	_, err := aecb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aecb *AuditEventCreateBulk) ExecX(ctx context.Context) {
+	if err := aecb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,129 @@
+// Package breadcrumbs оборачивает драйвер ent, записывая запросы к БД хлебными крошками Sentry.
+package breadcrumbs
+
+import (
+	"context"
+	stdsql "database/sql"
+	"errors"
+	"time"
+
+	"entgo.io/ent/dialect"
+
+	"github.com/FischukSergey/chat-service/internal/logger"
+)
+
+// CategoryDB - категория хлебных крошек запросов к БД.
+const CategoryDB = "db"
+
+// Driver записывает каждый запрос к БД хлебной крошкой в хаб Sentry запроса.
+// В крошку попадает только текст запроса с плейсхолдерами, значения аргументов не записываются.
+type Driver struct {
+	dialect.Driver
+}
+
+// NewDriver оборачивает драйвер ent записью хлебных крошек.
+func NewDriver(drv dialect.Driver) *Driver {
+	return &Driver{Driver: drv}
+}
+
+func (d *Driver) Exec(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := d.Driver.Exec(ctx, query, args, v)
+	logger.AddBreadcrumb(ctx, CategoryDB, query, start, err)
+	return err
+}
+
+func (d *Driver) Query(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := d.Driver.Query(ctx, query, args, v)
+	logger.AddBreadcrumb(ctx, CategoryDB, query, start, err)
+	return err
+}
+
+// ExecContext нужен для Client.ExecContext (фича sql/execquery).
+func (d *Driver) ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error) {
+	drv, ok := d.Driver.(interface {
+		ExecContext(context.Context, string, ...any) (stdsql.Result, error)
+	})
+	if !ok {
+		return nil, errors.New("Driver.ExecContext is not supported")
+	}
+
+	start := time.Now()
+	res, err := drv.ExecContext(ctx, query, args...)
+	logger.AddBreadcrumb(ctx, CategoryDB, query, start, err)
+	return res, err
+}
+
+// QueryContext нужен для Client.QueryContext (фича sql/execquery).
+func (d *Driver) QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error) {
+	drv, ok := d.Driver.(interface {
+		QueryContext(context.Context, string, ...any) (*stdsql.Rows, error)
+	})
+	if !ok {
+		return nil, errors.New("Driver.QueryContext is not supported")
+	}
+
+	start := time.Now()
+	rows, err := drv.QueryContext(ctx, query, args...)
+	logger.AddBreadcrumb(ctx, CategoryDB, query, start, err)
+	return rows, err
+}
+
+func (d *Driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &breadcrumbTx{Tx: tx, ctx: ctx}, nil
+}
+
+// BeginTx нужен для Client.BeginTx.
+func (d *Driver) BeginTx(ctx context.Context, opts *stdsql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *stdsql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, errors.New("Driver.BeginTx is not supported")
+	}
+
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &breadcrumbTx{Tx: tx, ctx: ctx}, nil
+}
+
+type breadcrumbTx struct {
+	dialect.Tx
+	// ctx - контекст начала транзакции, в его хаб пишутся крошки Commit и Rollback.
+	ctx context.Context //nolint:containedctx // transaction is bound to the request context
+}
+
+func (t *breadcrumbTx) Exec(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := t.Tx.Exec(ctx, query, args, v)
+	logger.AddBreadcrumb(ctx, CategoryDB, query, start, err)
+	return err
+}
+
+func (t *breadcrumbTx) Query(ctx context.Context, query string, args, v any) error {
+	start := time.Now()
+	err := t.Tx.Query(ctx, query, args, v)
+	logger.AddBreadcrumb(ctx, CategoryDB, query, start, err)
+	return err
+}
+
+func (t *breadcrumbTx) Commit() error {
+	start := time.Now()
+	err := t.Tx.Commit()
+	logger.AddBreadcrumb(t.ctx, CategoryDB, "COMMIT", start, err)
+	return err
+}
+
+func (t *breadcrumbTx) Rollback() error {
+	start := time.Now()
+	err := t.Tx.Rollback()
+	logger.AddBreadcrumb(t.ctx, CategoryDB, "ROLLBACK", start, err)
+	return err
+}
@@ -0,0 +1,58 @@
+package breadcrumbs_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/getsentry/sentry-go"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/breadcrumbs"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+func TestDriver(t *testing.T) {
+	db, err := sql.Open(dialect.SQLite, "file:"+t.Name()+"?mode=memory&cache=shared&_fk=1")
+	require.NoError(t, err)
+
+	client := store.NewClient(store.Driver(breadcrumbs.NewDriver(entsql.OpenDB(dialect.SQLite, db))))
+	defer func() { require.NoError(t, client.Close()) }()
+	require.NoError(t, client.Schema.Create(context.Background()))
+
+	var crumbs []*sentry.Breadcrumb
+	sentryClient, err := sentry.NewClient(sentry.ClientOptions{
+		BeforeBreadcrumb: func(b *sentry.Breadcrumb, _ *sentry.BreadcrumbHint) *sentry.Breadcrumb {
+			crumbs = append(crumbs, b)
+			return b
+		},
+	})
+	require.NoError(t, err)
+	ctx := sentry.SetHubOnContext(context.Background(), sentry.NewHub(sentryClient, sentry.NewScope()))
+
+	clientID := types.NewUserID()
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	_, err = tx.Chat.Create().SetClientID(clientID).Save(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	_, err = client.Chat.Query().Count(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, crumbs, 3)
+	for _, b := range crumbs {
+		assert.Equal(t, breadcrumbs.CategoryDB, b.Category)
+		assert.Equal(t, sentry.LevelInfo, b.Level)
+		assert.NotContains(t, b.Message, clientID.String(), "query arguments must not be recorded")
+	}
+	assert.True(t, strings.HasPrefix(crumbs[0].Message, "INSERT INTO `chats`"), crumbs[0].Message)
+	assert.Equal(t, "COMMIT", crumbs[1].Message)
+	assert.True(t, strings.HasPrefix(crumbs[2].Message, "SELECT COUNT"), crumbs[2].Message)
+}
@@ -12,6 +12,8 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"github.com/FischukSergey/chat-service/internal/store/attachment"
+	"github.com/FischukSergey/chat-service/internal/store/auditevent"
 	"github.com/FischukSergey/chat-service/internal/store/chat"
 	"github.com/FischukSergey/chat-service/internal/store/message"
 	"github.com/FischukSergey/chat-service/internal/store/problem"
@@ -75,9 +77,11 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			chat.Table:    chat.ValidColumn,
-			message.Table: message.ValidColumn,
-			problem.Table: problem.ValidColumn,
+			attachment.Table: attachment.ValidColumn,
+			auditevent.Table: auditevent.ValidColumn,
+			chat.Table:       chat.ValidColumn,
+			message.Table:    message.ValidColumn,
+			problem.Table:    problem.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
@@ -22,6 +22,12 @@ type Message struct {
 	ID types.MessageID `json:"id,omitempty"`
 	// Body holds the value of the "body" field.
 	Body string `json:"body,omitempty"`
+	// OriginalBody holds the value of the "original_body" field.
+	OriginalBody *string `json:"-"`
+	// BodyKeyID holds the value of the "body_key_id" field.
+	BodyKeyID *string `json:"body_key_id,omitempty"`
+	// IsRedacted holds the value of the "is_redacted" field.
+	IsRedacted bool `json:"is_redacted,omitempty"`
 	// AuthorID holds the value of the "author_id" field.
 	AuthorID types.UserID `json:"author_id,omitempty"`
 	// IsVisibleForClient holds the value of the "is_visible_for_client" field.
@@ -50,9 +56,11 @@ type MessageEdges struct {
 	Chat *Chat `json:"chat,omitempty"`
 	// Problem holds the value of the problem edge.
 	Problem *Problem `json:"problem,omitempty"`
+	// Attachments holds the value of the attachments edge.
+	Attachments []*Attachment `json:"attachments,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [3]bool
 }
 
 // ChatOrErr returns the Chat value or an error if the edge
@@ -77,14 +85,23 @@ func (e MessageEdges) ProblemOrErr() (*Problem, error) {
 	return nil, &NotLoadedError{edge: "problem"}
 }
 
+// AttachmentsOrErr returns the Attachments value or an error if the edge
+// was not loaded in eager-loading.
+func (e MessageEdges) AttachmentsOrErr() ([]*Attachment, error) {
+	if e.loadedTypes[2] {
+		return e.Attachments, nil
+	}
+	return nil, &NotLoadedError{edge: "attachments"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Message) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case message.FieldIsVisibleForClient, message.FieldIsVisibleForManager, message.FieldIsBlocked, message.FieldIsService:
+		case message.FieldIsRedacted, message.FieldIsVisibleForClient, message.FieldIsVisibleForManager, message.FieldIsBlocked, message.FieldIsService:
 			values[i] = new(sql.NullBool)
-		case message.FieldBody:
+		case message.FieldBody, message.FieldOriginalBody, message.FieldBodyKeyID:
 			values[i] = new(sql.NullString)
 		case message.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
@@ -123,6 +140,26 @@ func (m *Message) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				m.Body = value.String
 			}
+		case message.FieldOriginalBody:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field original_body", values[i])
+			} else if value.Valid {
+				m.OriginalBody = new(string)
+				*m.OriginalBody = value.String
+			}
+		case message.FieldBodyKeyID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field body_key_id", values[i])
+			} else if value.Valid {
+				m.BodyKeyID = new(string)
+				*m.BodyKeyID = value.String
+			}
+		case message.FieldIsRedacted:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field is_redacted", values[i])
+			} else if value.Valid {
+				m.IsRedacted = value.Bool
+			}
 		case message.FieldAuthorID:
 			if value, ok := values[i].(*types.UserID); !ok {
 				return fmt.Errorf("unexpected type %T for field author_id", values[i])
@@ -194,6 +231,11 @@ func (m *Message) QueryProblem() *ProblemQuery {
 	return NewMessageClient(m.config).QueryProblem(m)
 }
 
+// QueryAttachments queries the "attachments" edge of the Message entity.
+func (m *Message) QueryAttachments() *AttachmentQuery {
+	return NewMessageClient(m.config).QueryAttachments(m)
+}
+
 // Update returns a builder for updating this Message.
 // Note that you need to call Message.Unwrap() before calling this method if this Message
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -220,6 +262,16 @@ func (m *Message) String() string {
 	builder.WriteString("body=")
 	builder.WriteString(m.Body)
 	builder.WriteString(", ")
+	builder.WriteString("original_body=<sensitive>")
+	builder.WriteString(", ")
+	if v := m.BodyKeyID; v != nil {
+		builder.WriteString("body_key_id=")
+		builder.WriteString(*v)
+	}
+	builder.WriteString(", ")
+	builder.WriteString("is_redacted=")
+	builder.WriteString(fmt.Sprintf("%v", m.IsRedacted))
+	builder.WriteString(", ")
 	builder.WriteString("author_id=")
 	builder.WriteString(fmt.Sprintf("%v", m.AuthorID))
 	builder.WriteString(", ")
@@ -17,6 +17,12 @@ const (
 	FieldID = "id"
 	// FieldBody holds the string denoting the body field in the database.
 	FieldBody = "body"
+	// FieldOriginalBody holds the string denoting the original_body field in the database.
+	FieldOriginalBody = "original_body"
+	// FieldBodyKeyID holds the string denoting the body_key_id field in the database.
+	FieldBodyKeyID = "body_key_id"
+	// FieldIsRedacted holds the string denoting the is_redacted field in the database.
+	FieldIsRedacted = "is_redacted"
 	// FieldAuthorID holds the string denoting the author_id field in the database.
 	FieldAuthorID = "author_id"
 	// FieldIsVisibleForClient holds the string denoting the is_visible_for_client field in the database.
@@ -37,6 +43,8 @@ const (
 	EdgeChat = "chat"
 	// EdgeProblem holds the string denoting the problem edge name in mutations.
 	EdgeProblem = "problem"
+	// EdgeAttachments holds the string denoting the attachments edge name in mutations.
+	EdgeAttachments = "attachments"
 	// Table holds the table name of the message in the database.
 	Table = "messages"
 	// ChatTable is the table that holds the chat relation/edge.
@@ -53,12 +61,22 @@ const (
 	ProblemInverseTable = "problems"
 	// ProblemColumn is the table column denoting the problem relation/edge.
 	ProblemColumn = "problem_id"
+	// AttachmentsTable is the table that holds the attachments relation/edge.
+	AttachmentsTable = "attachments"
+	// AttachmentsInverseTable is the table name for the Attachment entity.
+	// It exists in this package in order to avoid circular dependency with the "attachment" package.
+	AttachmentsInverseTable = "attachments"
+	// AttachmentsColumn is the table column denoting the attachments relation/edge.
+	AttachmentsColumn = "message_id"
 )
 
 // Columns holds all SQL columns for message fields.
 var Columns = []string{
 	FieldID,
 	FieldBody,
+	FieldOriginalBody,
+	FieldBodyKeyID,
+	FieldIsRedacted,
 	FieldAuthorID,
 	FieldIsVisibleForClient,
 	FieldIsVisibleForManager,
@@ -82,6 +100,8 @@ func ValidColumn(column string) bool {
 var (
 	// BodyValidator is a validator for the "body" field. It is called by the builders before save.
 	BodyValidator func(string) error
+	// DefaultIsRedacted holds the default value on creation for the "is_redacted" field.
+	DefaultIsRedacted bool
 	// AuthorIDValidator is a validator for the "author_id" field. It is called by the builders before save.
 	AuthorIDValidator func(string) error
 	// DefaultIsVisibleForClient holds the default value on creation for the "is_visible_for_client" field.
@@ -111,6 +131,21 @@ func ByBody(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldBody, opts...).ToFunc()
 }
 
+// ByOriginalBody orders the results by the original_body field.
+func ByOriginalBody(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOriginalBody, opts...).ToFunc()
+}
+
+// ByBodyKeyID orders the results by the body_key_id field.
+func ByBodyKeyID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBodyKeyID, opts...).ToFunc()
+}
+
+// ByIsRedacted orders the results by the is_redacted field.
+func ByIsRedacted(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldIsRedacted, opts...).ToFunc()
+}
+
 // ByAuthorID orders the results by the author_id field.
 func ByAuthorID(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldAuthorID, opts...).ToFunc()
@@ -164,6 +199,20 @@ func ByProblemField(field string, opts ...sql.OrderTermOption) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newProblemStep(), sql.OrderByField(field, opts...))
 	}
 }
+
+// ByAttachmentsCount orders the results by attachments count.
+func ByAttachmentsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newAttachmentsStep(), opts...)
+	}
+}
+
+// ByAttachments orders the results by attachments terms.
+func ByAttachments(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newAttachmentsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newChatStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -178,3 +227,10 @@ func newProblemStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.M2O, true, ProblemTable, ProblemColumn),
 	)
 }
+func newAttachmentsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(AttachmentsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, AttachmentsTable, AttachmentsColumn),
+	)
+}
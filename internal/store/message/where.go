@@ -61,6 +61,21 @@ func Body(v string) predicate.Message {
 	return predicate.Message(sql.FieldEQ(FieldBody, v))
 }
 
+// OriginalBody applies equality check predicate on the "original_body" field. It's identical to OriginalBodyEQ.
+func OriginalBody(v string) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldOriginalBody, v))
+}
+
+// BodyKeyID applies equality check predicate on the "body_key_id" field. It's identical to BodyKeyIDEQ.
+func BodyKeyID(v string) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldBodyKeyID, v))
+}
+
+// IsRedacted applies equality check predicate on the "is_redacted" field. It's identical to IsRedactedEQ.
+func IsRedacted(v bool) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldIsRedacted, v))
+}
+
 // AuthorID applies equality check predicate on the "author_id" field. It's identical to AuthorIDEQ.
 func AuthorID(v types.UserID) predicate.Message {
 	return predicate.Message(sql.FieldEQ(FieldAuthorID, v))
@@ -166,6 +181,166 @@ func BodyContainsFold(v string) predicate.Message {
 	return predicate.Message(sql.FieldContainsFold(FieldBody, v))
 }
 
+// OriginalBodyEQ applies the EQ predicate on the "original_body" field.
+func OriginalBodyEQ(v string) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldOriginalBody, v))
+}
+
+// OriginalBodyNEQ applies the NEQ predicate on the "original_body" field.
+func OriginalBodyNEQ(v string) predicate.Message {
+	return predicate.Message(sql.FieldNEQ(FieldOriginalBody, v))
+}
+
+// OriginalBodyIn applies the In predicate on the "original_body" field.
+func OriginalBodyIn(vs ...string) predicate.Message {
+	return predicate.Message(sql.FieldIn(FieldOriginalBody, vs...))
+}
+
+// OriginalBodyNotIn applies the NotIn predicate on the "original_body" field.
+func OriginalBodyNotIn(vs ...string) predicate.Message {
+	return predicate.Message(sql.FieldNotIn(FieldOriginalBody, vs...))
+}
+
+// OriginalBodyGT applies the GT predicate on the "original_body" field.
+func OriginalBodyGT(v string) predicate.Message {
+	return predicate.Message(sql.FieldGT(FieldOriginalBody, v))
+}
+
+// OriginalBodyGTE applies the GTE predicate on the "original_body" field.
+func OriginalBodyGTE(v string) predicate.Message {
+	return predicate.Message(sql.FieldGTE(FieldOriginalBody, v))
+}
+
+// OriginalBodyLT applies the LT predicate on the "original_body" field.
+func OriginalBodyLT(v string) predicate.Message {
+	return predicate.Message(sql.FieldLT(FieldOriginalBody, v))
+}
+
+// OriginalBodyLTE applies the LTE predicate on the "original_body" field.
+func OriginalBodyLTE(v string) predicate.Message {
+	return predicate.Message(sql.FieldLTE(FieldOriginalBody, v))
+}
+
+// OriginalBodyContains applies the Contains predicate on the "original_body" field.
+func OriginalBodyContains(v string) predicate.Message {
+	return predicate.Message(sql.FieldContains(FieldOriginalBody, v))
+}
+
+// OriginalBodyHasPrefix applies the HasPrefix predicate on the "original_body" field.
+func OriginalBodyHasPrefix(v string) predicate.Message {
+	return predicate.Message(sql.FieldHasPrefix(FieldOriginalBody, v))
+}
+
+// OriginalBodyHasSuffix applies the HasSuffix predicate on the "original_body" field.
+func OriginalBodyHasSuffix(v string) predicate.Message {
+	return predicate.Message(sql.FieldHasSuffix(FieldOriginalBody, v))
+}
+
+// OriginalBodyIsNil applies the IsNil predicate on the "original_body" field.
+func OriginalBodyIsNil() predicate.Message {
+	return predicate.Message(sql.FieldIsNull(FieldOriginalBody))
+}
+
+// OriginalBodyNotNil applies the NotNil predicate on the "original_body" field.
+func OriginalBodyNotNil() predicate.Message {
+	return predicate.Message(sql.FieldNotNull(FieldOriginalBody))
+}
+
+// OriginalBodyEqualFold applies the EqualFold predicate on the "original_body" field.
+func OriginalBodyEqualFold(v string) predicate.Message {
+	return predicate.Message(sql.FieldEqualFold(FieldOriginalBody, v))
+}
+
+// OriginalBodyContainsFold applies the ContainsFold predicate on the "original_body" field.
+func OriginalBodyContainsFold(v string) predicate.Message {
+	return predicate.Message(sql.FieldContainsFold(FieldOriginalBody, v))
+}
+
+// BodyKeyIDEQ applies the EQ predicate on the "body_key_id" field.
+func BodyKeyIDEQ(v string) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDNEQ applies the NEQ predicate on the "body_key_id" field.
+func BodyKeyIDNEQ(v string) predicate.Message {
+	return predicate.Message(sql.FieldNEQ(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDIn applies the In predicate on the "body_key_id" field.
+func BodyKeyIDIn(vs ...string) predicate.Message {
+	return predicate.Message(sql.FieldIn(FieldBodyKeyID, vs...))
+}
+
+// BodyKeyIDNotIn applies the NotIn predicate on the "body_key_id" field.
+func BodyKeyIDNotIn(vs ...string) predicate.Message {
+	return predicate.Message(sql.FieldNotIn(FieldBodyKeyID, vs...))
+}
+
+// BodyKeyIDGT applies the GT predicate on the "body_key_id" field.
+func BodyKeyIDGT(v string) predicate.Message {
+	return predicate.Message(sql.FieldGT(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDGTE applies the GTE predicate on the "body_key_id" field.
+func BodyKeyIDGTE(v string) predicate.Message {
+	return predicate.Message(sql.FieldGTE(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDLT applies the LT predicate on the "body_key_id" field.
+func BodyKeyIDLT(v string) predicate.Message {
+	return predicate.Message(sql.FieldLT(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDLTE applies the LTE predicate on the "body_key_id" field.
+func BodyKeyIDLTE(v string) predicate.Message {
+	return predicate.Message(sql.FieldLTE(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDContains applies the Contains predicate on the "body_key_id" field.
+func BodyKeyIDContains(v string) predicate.Message {
+	return predicate.Message(sql.FieldContains(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDHasPrefix applies the HasPrefix predicate on the "body_key_id" field.
+func BodyKeyIDHasPrefix(v string) predicate.Message {
+	return predicate.Message(sql.FieldHasPrefix(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDHasSuffix applies the HasSuffix predicate on the "body_key_id" field.
+func BodyKeyIDHasSuffix(v string) predicate.Message {
+	return predicate.Message(sql.FieldHasSuffix(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDIsNil applies the IsNil predicate on the "body_key_id" field.
+func BodyKeyIDIsNil() predicate.Message {
+	return predicate.Message(sql.FieldIsNull(FieldBodyKeyID))
+}
+
+// BodyKeyIDNotNil applies the NotNil predicate on the "body_key_id" field.
+func BodyKeyIDNotNil() predicate.Message {
+	return predicate.Message(sql.FieldNotNull(FieldBodyKeyID))
+}
+
+// BodyKeyIDEqualFold applies the EqualFold predicate on the "body_key_id" field.
+func BodyKeyIDEqualFold(v string) predicate.Message {
+	return predicate.Message(sql.FieldEqualFold(FieldBodyKeyID, v))
+}
+
+// BodyKeyIDContainsFold applies the ContainsFold predicate on the "body_key_id" field.
+func BodyKeyIDContainsFold(v string) predicate.Message {
+	return predicate.Message(sql.FieldContainsFold(FieldBodyKeyID, v))
+}
+
+// IsRedactedEQ applies the EQ predicate on the "is_redacted" field.
+func IsRedactedEQ(v bool) predicate.Message {
+	return predicate.Message(sql.FieldEQ(FieldIsRedacted, v))
+}
+
+// IsRedactedNEQ applies the NEQ predicate on the "is_redacted" field.
+func IsRedactedNEQ(v bool) predicate.Message {
+	return predicate.Message(sql.FieldNEQ(FieldIsRedacted, v))
+}
+
 // AuthorIDEQ applies the EQ predicate on the "author_id" field.
 func AuthorIDEQ(v types.UserID) predicate.Message {
 	return predicate.Message(sql.FieldEQ(FieldAuthorID, v))
@@ -512,6 +687,29 @@ func HasProblemWith(preds ...predicate.Problem) predicate.Message {
 	})
 }
 
+// HasAttachments applies the HasEdge predicate on the "attachments" edge.
+func HasAttachments() predicate.Message {
+	return predicate.Message(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, AttachmentsTable, AttachmentsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasAttachmentsWith applies the HasEdge predicate on the "attachments" edge with a given conditions (other predicates).
+func HasAttachmentsWith(preds ...predicate.Attachment) predicate.Message {
+	return predicate.Message(func(s *sql.Selector) {
+		step := newAttachmentsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Message) predicate.Message {
 	return predicate.Message(sql.AndPredicates(predicates...))
@@ -10,6 +10,7 @@ import (
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/FischukSergey/chat-service/internal/store/attachment"
 	"github.com/FischukSergey/chat-service/internal/store/chat"
 	"github.com/FischukSergey/chat-service/internal/store/message"
 	"github.com/FischukSergey/chat-service/internal/store/problem"
@@ -29,6 +30,48 @@ func (mc *MessageCreate) SetBody(s string) *MessageCreate {
 	return mc
 }
 
+// SetOriginalBody sets the "original_body" field.
+func (mc *MessageCreate) SetOriginalBody(s string) *MessageCreate {
+	mc.mutation.SetOriginalBody(s)
+	return mc
+}
+
+// SetNillableOriginalBody sets the "original_body" field if the given value is not nil.
+func (mc *MessageCreate) SetNillableOriginalBody(s *string) *MessageCreate {
+	if s != nil {
+		mc.SetOriginalBody(*s)
+	}
+	return mc
+}
+
+// SetBodyKeyID sets the "body_key_id" field.
+func (mc *MessageCreate) SetBodyKeyID(s string) *MessageCreate {
+	mc.mutation.SetBodyKeyID(s)
+	return mc
+}
+
+// SetNillableBodyKeyID sets the "body_key_id" field if the given value is not nil.
+func (mc *MessageCreate) SetNillableBodyKeyID(s *string) *MessageCreate {
+	if s != nil {
+		mc.SetBodyKeyID(*s)
+	}
+	return mc
+}
+
+// SetIsRedacted sets the "is_redacted" field.
+func (mc *MessageCreate) SetIsRedacted(b bool) *MessageCreate {
+	mc.mutation.SetIsRedacted(b)
+	return mc
+}
+
+// SetNillableIsRedacted sets the "is_redacted" field if the given value is not nil.
+func (mc *MessageCreate) SetNillableIsRedacted(b *bool) *MessageCreate {
+	if b != nil {
+		mc.SetIsRedacted(*b)
+	}
+	return mc
+}
+
 // SetAuthorID sets the "author_id" field.
 func (mc *MessageCreate) SetAuthorID(ti types.UserID) *MessageCreate {
 	mc.mutation.SetAuthorID(ti)
@@ -149,6 +192,21 @@ func (mc *MessageCreate) SetProblem(p *Problem) *MessageCreate {
 	return mc.SetProblemID(p.ID)
 }
 
+// AddAttachmentIDs adds the "attachments" edge to the Attachment entity by IDs.
+func (mc *MessageCreate) AddAttachmentIDs(ids ...types.AttachmentID) *MessageCreate {
+	mc.mutation.AddAttachmentIDs(ids...)
+	return mc
+}
+
+// AddAttachments adds the "attachments" edges to the Attachment entity.
+func (mc *MessageCreate) AddAttachments(a ...*Attachment) *MessageCreate {
+	ids := make([]types.AttachmentID, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return mc.AddAttachmentIDs(ids...)
+}
+
 // Mutation returns the MessageMutation object of the builder.
 func (mc *MessageCreate) Mutation() *MessageMutation {
 	return mc.mutation
@@ -184,6 +242,10 @@ func (mc *MessageCreate) ExecX(ctx context.Context) {
 
 // defaults sets the default values of the builder before save.
 func (mc *MessageCreate) defaults() {
+	if _, ok := mc.mutation.IsRedacted(); !ok {
+		v := message.DefaultIsRedacted
+		mc.mutation.SetIsRedacted(v)
+	}
 	if _, ok := mc.mutation.IsVisibleForClient(); !ok {
 		v := message.DefaultIsVisibleForClient
 		mc.mutation.SetIsVisibleForClient(v)
@@ -220,6 +282,9 @@ func (mc *MessageCreate) check() error {
 			return &ValidationError{Name: "body", err: fmt.Errorf(`store: validator failed for field "Message.body": %w`, err)}
 		}
 	}
+	if _, ok := mc.mutation.IsRedacted(); !ok {
+		return &ValidationError{Name: "is_redacted", err: errors.New(`store: missing required field "Message.is_redacted"`)}
+	}
 	if _, ok := mc.mutation.AuthorID(); !ok {
 		return &ValidationError{Name: "author_id", err: errors.New(`store: missing required field "Message.author_id"`)}
 	}
@@ -303,6 +368,18 @@ func (mc *MessageCreate) createSpec() (*Message, *sqlgraph.CreateSpec) {
 		_spec.SetField(message.FieldBody, field.TypeString, value)
 		_node.Body = value
 	}
+	if value, ok := mc.mutation.OriginalBody(); ok {
+		_spec.SetField(message.FieldOriginalBody, field.TypeString, value)
+		_node.OriginalBody = &value
+	}
+	if value, ok := mc.mutation.BodyKeyID(); ok {
+		_spec.SetField(message.FieldBodyKeyID, field.TypeString, value)
+		_node.BodyKeyID = &value
+	}
+	if value, ok := mc.mutation.IsRedacted(); ok {
+		_spec.SetField(message.FieldIsRedacted, field.TypeBool, value)
+		_node.IsRedacted = value
+	}
 	if value, ok := mc.mutation.AuthorID(); ok {
 		_spec.SetField(message.FieldAuthorID, field.TypeString, value)
 		_node.AuthorID = value
@@ -361,6 +438,22 @@ func (mc *MessageCreate) createSpec() (*Message, *sqlgraph.CreateSpec) {
 		_node.ProblemID = nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := mc.mutation.AttachmentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   message.AttachmentsTable,
+			Columns: []string{message.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -10,8 +10,10 @@ import (
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
+	"github.com/FischukSergey/chat-service/internal/store/attachment"
 	"github.com/FischukSergey/chat-service/internal/store/message"
 	"github.com/FischukSergey/chat-service/internal/store/predicate"
+	"github.com/FischukSergey/chat-service/internal/types"
 )
 
 // MessageUpdate is the builder for updating Message entities.
@@ -41,6 +43,60 @@ func (mu *MessageUpdate) SetNillableBody(s *string) *MessageUpdate {
 	return mu
 }
 
+// SetOriginalBody sets the "original_body" field.
+func (mu *MessageUpdate) SetOriginalBody(s string) *MessageUpdate {
+	mu.mutation.SetOriginalBody(s)
+	return mu
+}
+
+// SetNillableOriginalBody sets the "original_body" field if the given value is not nil.
+func (mu *MessageUpdate) SetNillableOriginalBody(s *string) *MessageUpdate {
+	if s != nil {
+		mu.SetOriginalBody(*s)
+	}
+	return mu
+}
+
+// ClearOriginalBody clears the value of the "original_body" field.
+func (mu *MessageUpdate) ClearOriginalBody() *MessageUpdate {
+	mu.mutation.ClearOriginalBody()
+	return mu
+}
+
+// SetBodyKeyID sets the "body_key_id" field.
+func (mu *MessageUpdate) SetBodyKeyID(s string) *MessageUpdate {
+	mu.mutation.SetBodyKeyID(s)
+	return mu
+}
+
+// SetNillableBodyKeyID sets the "body_key_id" field if the given value is not nil.
+func (mu *MessageUpdate) SetNillableBodyKeyID(s *string) *MessageUpdate {
+	if s != nil {
+		mu.SetBodyKeyID(*s)
+	}
+	return mu
+}
+
+// ClearBodyKeyID clears the value of the "body_key_id" field.
+func (mu *MessageUpdate) ClearBodyKeyID() *MessageUpdate {
+	mu.mutation.ClearBodyKeyID()
+	return mu
+}
+
+// SetIsRedacted sets the "is_redacted" field.
+func (mu *MessageUpdate) SetIsRedacted(b bool) *MessageUpdate {
+	mu.mutation.SetIsRedacted(b)
+	return mu
+}
+
+// SetNillableIsRedacted sets the "is_redacted" field if the given value is not nil.
+func (mu *MessageUpdate) SetNillableIsRedacted(b *bool) *MessageUpdate {
+	if b != nil {
+		mu.SetIsRedacted(*b)
+	}
+	return mu
+}
+
 // SetIsVisibleForClient sets the "is_visible_for_client" field.
 func (mu *MessageUpdate) SetIsVisibleForClient(b bool) *MessageUpdate {
 	mu.mutation.SetIsVisibleForClient(b)
@@ -97,11 +153,47 @@ func (mu *MessageUpdate) SetNillableIsService(b *bool) *MessageUpdate {
 	return mu
 }
 
+// AddAttachmentIDs adds the "attachments" edge to the Attachment entity by IDs.
+func (mu *MessageUpdate) AddAttachmentIDs(ids ...types.AttachmentID) *MessageUpdate {
+	mu.mutation.AddAttachmentIDs(ids...)
+	return mu
+}
+
+// AddAttachments adds the "attachments" edges to the Attachment entity.
+func (mu *MessageUpdate) AddAttachments(a ...*Attachment) *MessageUpdate {
+	ids := make([]types.AttachmentID, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return mu.AddAttachmentIDs(ids...)
+}
+
 // Mutation returns the MessageMutation object of the builder.
 func (mu *MessageUpdate) Mutation() *MessageMutation {
 	return mu.mutation
 }
 
+// ClearAttachments clears all "attachments" edges to the Attachment entity.
+func (mu *MessageUpdate) ClearAttachments() *MessageUpdate {
+	mu.mutation.ClearAttachments()
+	return mu
+}
+
+// RemoveAttachmentIDs removes the "attachments" edge to Attachment entities by IDs.
+func (mu *MessageUpdate) RemoveAttachmentIDs(ids ...types.AttachmentID) *MessageUpdate {
+	mu.mutation.RemoveAttachmentIDs(ids...)
+	return mu
+}
+
+// RemoveAttachments removes "attachments" edges to Attachment entities.
+func (mu *MessageUpdate) RemoveAttachments(a ...*Attachment) *MessageUpdate {
+	ids := make([]types.AttachmentID, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return mu.RemoveAttachmentIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (mu *MessageUpdate) Save(ctx context.Context) (int, error) {
 	return withHooks(ctx, mu.sqlSave, mu.mutation, mu.hooks)
@@ -157,6 +249,21 @@ func (mu *MessageUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := mu.mutation.Body(); ok {
 		_spec.SetField(message.FieldBody, field.TypeString, value)
 	}
+	if value, ok := mu.mutation.OriginalBody(); ok {
+		_spec.SetField(message.FieldOriginalBody, field.TypeString, value)
+	}
+	if mu.mutation.OriginalBodyCleared() {
+		_spec.ClearField(message.FieldOriginalBody, field.TypeString)
+	}
+	if value, ok := mu.mutation.BodyKeyID(); ok {
+		_spec.SetField(message.FieldBodyKeyID, field.TypeString, value)
+	}
+	if mu.mutation.BodyKeyIDCleared() {
+		_spec.ClearField(message.FieldBodyKeyID, field.TypeString)
+	}
+	if value, ok := mu.mutation.IsRedacted(); ok {
+		_spec.SetField(message.FieldIsRedacted, field.TypeBool, value)
+	}
 	if value, ok := mu.mutation.IsVisibleForClient(); ok {
 		_spec.SetField(message.FieldIsVisibleForClient, field.TypeBool, value)
 	}
@@ -169,6 +276,51 @@ func (mu *MessageUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := mu.mutation.IsService(); ok {
 		_spec.SetField(message.FieldIsService, field.TypeBool, value)
 	}
+	if mu.mutation.AttachmentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   message.AttachmentsTable,
+			Columns: []string{message.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.RemovedAttachmentsIDs(); len(nodes) > 0 && !mu.mutation.AttachmentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   message.AttachmentsTable,
+			Columns: []string{message.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.AttachmentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   message.AttachmentsTable,
+			Columns: []string{message.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, mu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{message.Label}
@@ -203,6 +355,60 @@ func (muo *MessageUpdateOne) SetNillableBody(s *string) *MessageUpdateOne {
 	return muo
 }
 
+// SetOriginalBody sets the "original_body" field.
+func (muo *MessageUpdateOne) SetOriginalBody(s string) *MessageUpdateOne {
+	muo.mutation.SetOriginalBody(s)
+	return muo
+}
+
+// SetNillableOriginalBody sets the "original_body" field if the given value is not nil.
+func (muo *MessageUpdateOne) SetNillableOriginalBody(s *string) *MessageUpdateOne {
+	if s != nil {
+		muo.SetOriginalBody(*s)
+	}
+	return muo
+}
+
+// ClearOriginalBody clears the value of the "original_body" field.
+func (muo *MessageUpdateOne) ClearOriginalBody() *MessageUpdateOne {
+	muo.mutation.ClearOriginalBody()
+	return muo
+}
+
+// SetBodyKeyID sets the "body_key_id" field.
+func (muo *MessageUpdateOne) SetBodyKeyID(s string) *MessageUpdateOne {
+	muo.mutation.SetBodyKeyID(s)
+	return muo
+}
+
+// SetNillableBodyKeyID sets the "body_key_id" field if the given value is not nil.
+func (muo *MessageUpdateOne) SetNillableBodyKeyID(s *string) *MessageUpdateOne {
+	if s != nil {
+		muo.SetBodyKeyID(*s)
+	}
+	return muo
+}
+
+// ClearBodyKeyID clears the value of the "body_key_id" field.
+func (muo *MessageUpdateOne) ClearBodyKeyID() *MessageUpdateOne {
+	muo.mutation.ClearBodyKeyID()
+	return muo
+}
+
+// SetIsRedacted sets the "is_redacted" field.
+func (muo *MessageUpdateOne) SetIsRedacted(b bool) *MessageUpdateOne {
+	muo.mutation.SetIsRedacted(b)
+	return muo
+}
+
+// SetNillableIsRedacted sets the "is_redacted" field if the given value is not nil.
+func (muo *MessageUpdateOne) SetNillableIsRedacted(b *bool) *MessageUpdateOne {
+	if b != nil {
+		muo.SetIsRedacted(*b)
+	}
+	return muo
+}
+
 // SetIsVisibleForClient sets the "is_visible_for_client" field.
 func (muo *MessageUpdateOne) SetIsVisibleForClient(b bool) *MessageUpdateOne {
 	muo.mutation.SetIsVisibleForClient(b)
@@ -259,11 +465,47 @@ func (muo *MessageUpdateOne) SetNillableIsService(b *bool) *MessageUpdateOne {
 	return muo
 }
 
+// AddAttachmentIDs adds the "attachments" edge to the Attachment entity by IDs.
+func (muo *MessageUpdateOne) AddAttachmentIDs(ids ...types.AttachmentID) *MessageUpdateOne {
+	muo.mutation.AddAttachmentIDs(ids...)
+	return muo
+}
+
+// AddAttachments adds the "attachments" edges to the Attachment entity.
+func (muo *MessageUpdateOne) AddAttachments(a ...*Attachment) *MessageUpdateOne {
+	ids := make([]types.AttachmentID, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return muo.AddAttachmentIDs(ids...)
+}
+
 // Mutation returns the MessageMutation object of the builder.
 func (muo *MessageUpdateOne) Mutation() *MessageMutation {
 	return muo.mutation
 }
 
+// ClearAttachments clears all "attachments" edges to the Attachment entity.
+func (muo *MessageUpdateOne) ClearAttachments() *MessageUpdateOne {
+	muo.mutation.ClearAttachments()
+	return muo
+}
+
+// RemoveAttachmentIDs removes the "attachments" edge to Attachment entities by IDs.
+func (muo *MessageUpdateOne) RemoveAttachmentIDs(ids ...types.AttachmentID) *MessageUpdateOne {
+	muo.mutation.RemoveAttachmentIDs(ids...)
+	return muo
+}
+
+// RemoveAttachments removes "attachments" edges to Attachment entities.
+func (muo *MessageUpdateOne) RemoveAttachments(a ...*Attachment) *MessageUpdateOne {
+	ids := make([]types.AttachmentID, len(a))
+	for i := range a {
+		ids[i] = a[i].ID
+	}
+	return muo.RemoveAttachmentIDs(ids...)
+}
+
 // Where appends a list predicates to the MessageUpdate builder.
 func (muo *MessageUpdateOne) Where(ps ...predicate.Message) *MessageUpdateOne {
 	muo.mutation.Where(ps...)
@@ -349,6 +591,21 @@ func (muo *MessageUpdateOne) sqlSave(ctx context.Context) (_node *Message, err e
 	if value, ok := muo.mutation.Body(); ok {
 		_spec.SetField(message.FieldBody, field.TypeString, value)
 	}
+	if value, ok := muo.mutation.OriginalBody(); ok {
+		_spec.SetField(message.FieldOriginalBody, field.TypeString, value)
+	}
+	if muo.mutation.OriginalBodyCleared() {
+		_spec.ClearField(message.FieldOriginalBody, field.TypeString)
+	}
+	if value, ok := muo.mutation.BodyKeyID(); ok {
+		_spec.SetField(message.FieldBodyKeyID, field.TypeString, value)
+	}
+	if muo.mutation.BodyKeyIDCleared() {
+		_spec.ClearField(message.FieldBodyKeyID, field.TypeString)
+	}
+	if value, ok := muo.mutation.IsRedacted(); ok {
+		_spec.SetField(message.FieldIsRedacted, field.TypeBool, value)
+	}
 	if value, ok := muo.mutation.IsVisibleForClient(); ok {
 		_spec.SetField(message.FieldIsVisibleForClient, field.TypeBool, value)
 	}
@@ -361,6 +618,51 @@ func (muo *MessageUpdateOne) sqlSave(ctx context.Context) (_node *Message, err e
 	if value, ok := muo.mutation.IsService(); ok {
 		_spec.SetField(message.FieldIsService, field.TypeBool, value)
 	}
+	if muo.mutation.AttachmentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   message.AttachmentsTable,
+			Columns: []string{message.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeString),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.RemovedAttachmentsIDs(); len(nodes) > 0 && !muo.mutation.AttachmentsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   message.AttachmentsTable,
+			Columns: []string{message.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.AttachmentsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   message.AttachmentsTable,
+			Columns: []string{message.AttachmentsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(attachment.FieldID, field.TypeString),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Message{config: muo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
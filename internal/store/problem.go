@@ -23,6 +23,8 @@ type Problem struct {
 	ManagerID types.UserID `json:"manager_id,omitempty"`
 	// Status holds the value of the "status" field.
 	Status problem.Status `json:"status,omitempty"`
+	// ResolvedAt holds the value of the "resolved_at" field.
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
@@ -73,7 +75,7 @@ func (*Problem) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case problem.FieldStatus:
 			values[i] = new(sql.NullString)
-		case problem.FieldCreatedAt, problem.FieldUpdatedAt:
+		case problem.FieldResolvedAt, problem.FieldCreatedAt, problem.FieldUpdatedAt:
 			values[i] = new(sql.NullTime)
 		case problem.FieldChatID:
 			values[i] = new(types.ChatID)
@@ -114,6 +116,13 @@ func (pr *Problem) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				pr.Status = problem.Status(value.String)
 			}
+		case problem.FieldResolvedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field resolved_at", values[i])
+			} else if value.Valid {
+				pr.ResolvedAt = new(time.Time)
+				*pr.ResolvedAt = value.Time
+			}
 		case problem.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -184,6 +193,11 @@ func (pr *Problem) String() string {
 	builder.WriteString("status=")
 	builder.WriteString(fmt.Sprintf("%v", pr.Status))
 	builder.WriteString(", ")
+	if v := pr.ResolvedAt; v != nil {
+		builder.WriteString("resolved_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(pr.CreatedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -43,6 +43,20 @@ func (pc *ProblemCreate) SetNillableStatus(pr *problem.Status) *ProblemCreate {
 	return pc
 }
 
+// SetResolvedAt sets the "resolved_at" field.
+func (pc *ProblemCreate) SetResolvedAt(t time.Time) *ProblemCreate {
+	pc.mutation.SetResolvedAt(t)
+	return pc
+}
+
+// SetNillableResolvedAt sets the "resolved_at" field if the given value is not nil.
+func (pc *ProblemCreate) SetNillableResolvedAt(t *time.Time) *ProblemCreate {
+	if t != nil {
+		pc.SetResolvedAt(*t)
+	}
+	return pc
+}
+
 // SetCreatedAt sets the "created_at" field.
 func (pc *ProblemCreate) SetCreatedAt(t time.Time) *ProblemCreate {
 	pc.mutation.SetCreatedAt(t)
@@ -247,6 +261,10 @@ func (pc *ProblemCreate) createSpec() (*Problem, *sqlgraph.CreateSpec) {
 		_spec.SetField(problem.FieldStatus, field.TypeEnum, value)
 		_node.Status = value
 	}
+	if value, ok := pc.mutation.ResolvedAt(); ok {
+		_spec.SetField(problem.FieldResolvedAt, field.TypeTime, value)
+		_node.ResolvedAt = &value
+	}
 	if value, ok := pc.mutation.CreatedAt(); ok {
 		_spec.SetField(problem.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
@@ -42,6 +42,26 @@ func (pu *ProblemUpdate) SetNillableStatus(pr *problem.Status) *ProblemUpdate {
 	return pu
 }
 
+// SetResolvedAt sets the "resolved_at" field.
+func (pu *ProblemUpdate) SetResolvedAt(t time.Time) *ProblemUpdate {
+	pu.mutation.SetResolvedAt(t)
+	return pu
+}
+
+// SetNillableResolvedAt sets the "resolved_at" field if the given value is not nil.
+func (pu *ProblemUpdate) SetNillableResolvedAt(t *time.Time) *ProblemUpdate {
+	if t != nil {
+		pu.SetResolvedAt(*t)
+	}
+	return pu
+}
+
+// ClearResolvedAt clears the value of the "resolved_at" field.
+func (pu *ProblemUpdate) ClearResolvedAt() *ProblemUpdate {
+	pu.mutation.ClearResolvedAt()
+	return pu
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (pu *ProblemUpdate) SetUpdatedAt(t time.Time) *ProblemUpdate {
 	pu.mutation.SetUpdatedAt(t)
@@ -117,6 +137,12 @@ func (pu *ProblemUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := pu.mutation.Status(); ok {
 		_spec.SetField(problem.FieldStatus, field.TypeEnum, value)
 	}
+	if value, ok := pu.mutation.ResolvedAt(); ok {
+		_spec.SetField(problem.FieldResolvedAt, field.TypeTime, value)
+	}
+	if pu.mutation.ResolvedAtCleared() {
+		_spec.ClearField(problem.FieldResolvedAt, field.TypeTime)
+	}
 	if value, ok := pu.mutation.UpdatedAt(); ok {
 		_spec.SetField(problem.FieldUpdatedAt, field.TypeTime, value)
 	}
@@ -154,6 +180,26 @@ func (puo *ProblemUpdateOne) SetNillableStatus(pr *problem.Status) *ProblemUpdat
 	return puo
 }
 
+// SetResolvedAt sets the "resolved_at" field.
+func (puo *ProblemUpdateOne) SetResolvedAt(t time.Time) *ProblemUpdateOne {
+	puo.mutation.SetResolvedAt(t)
+	return puo
+}
+
+// SetNillableResolvedAt sets the "resolved_at" field if the given value is not nil.
+func (puo *ProblemUpdateOne) SetNillableResolvedAt(t *time.Time) *ProblemUpdateOne {
+	if t != nil {
+		puo.SetResolvedAt(*t)
+	}
+	return puo
+}
+
+// ClearResolvedAt clears the value of the "resolved_at" field.
+func (puo *ProblemUpdateOne) ClearResolvedAt() *ProblemUpdateOne {
+	puo.mutation.ClearResolvedAt()
+	return puo
+}
+
 // SetUpdatedAt sets the "updated_at" field.
 func (puo *ProblemUpdateOne) SetUpdatedAt(t time.Time) *ProblemUpdateOne {
 	puo.mutation.SetUpdatedAt(t)
@@ -259,6 +305,12 @@ func (puo *ProblemUpdateOne) sqlSave(ctx context.Context) (_node *Problem, err e
 	if value, ok := puo.mutation.Status(); ok {
 		_spec.SetField(problem.FieldStatus, field.TypeEnum, value)
 	}
+	if value, ok := puo.mutation.ResolvedAt(); ok {
+		_spec.SetField(problem.FieldResolvedAt, field.TypeTime, value)
+	}
+	if puo.mutation.ResolvedAtCleared() {
+		_spec.ClearField(problem.FieldResolvedAt, field.TypeTime)
+	}
 	if value, ok := puo.mutation.UpdatedAt(); ok {
 		_spec.SetField(problem.FieldUpdatedAt, field.TypeTime, value)
 	}
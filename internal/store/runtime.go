@@ -5,6 +5,8 @@ package store
 import (
 	"time"
 
+	"github.com/FischukSergey/chat-service/internal/store/attachment"
+	"github.com/FischukSergey/chat-service/internal/store/auditevent"
 	"github.com/FischukSergey/chat-service/internal/store/chat"
 	"github.com/FischukSergey/chat-service/internal/store/message"
 	"github.com/FischukSergey/chat-service/internal/store/problem"
@@ -16,6 +18,46 @@ import (
 // (default values, validators, hooks and policies) and stitches it
 // to their package variables.
 func init() {
+	attachmentFields := schema.Attachment{}.Fields()
+	_ = attachmentFields
+	// attachmentDescUploaderID is the schema descriptor for uploader_id field.
+	attachmentDescUploaderID := attachmentFields[1].Descriptor()
+	// attachment.UploaderIDValidator is a validator for the "uploader_id" field. It is called by the builders before save.
+	attachment.UploaderIDValidator = attachmentDescUploaderID.Validators[0].(func(string) error)
+	// attachmentDescFileName is the schema descriptor for file_name field.
+	attachmentDescFileName := attachmentFields[3].Descriptor()
+	// attachment.FileNameValidator is a validator for the "file_name" field. It is called by the builders before save.
+	attachment.FileNameValidator = attachmentDescFileName.Validators[0].(func(string) error)
+	// attachmentDescContentType is the schema descriptor for content_type field.
+	attachmentDescContentType := attachmentFields[4].Descriptor()
+	// attachment.ContentTypeValidator is a validator for the "content_type" field. It is called by the builders before save.
+	attachment.ContentTypeValidator = attachmentDescContentType.Validators[0].(func(string) error)
+	// attachmentDescSize is the schema descriptor for size field.
+	attachmentDescSize := attachmentFields[5].Descriptor()
+	// attachment.SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	attachment.SizeValidator = attachmentDescSize.Validators[0].(func(int64) error)
+	// attachmentDescBlobKey is the schema descriptor for blob_key field.
+	attachmentDescBlobKey := attachmentFields[6].Descriptor()
+	// attachment.BlobKeyValidator is a validator for the "blob_key" field. It is called by the builders before save.
+	attachment.BlobKeyValidator = attachmentDescBlobKey.Validators[0].(func(string) error)
+	// attachmentDescCreatedAt is the schema descriptor for created_at field.
+	attachmentDescCreatedAt := attachmentFields[7].Descriptor()
+	// attachment.DefaultCreatedAt holds the default value on creation for the created_at field.
+	attachment.DefaultCreatedAt = attachmentDescCreatedAt.Default.(func() time.Time)
+	// attachmentDescID is the schema descriptor for id field.
+	attachmentDescID := attachmentFields[0].Descriptor()
+	// attachment.DefaultID holds the default value on creation for the id field.
+	attachment.DefaultID = attachmentDescID.Default.(func() types.AttachmentID)
+	auditeventFields := schema.AuditEvent{}.Fields()
+	_ = auditeventFields
+	// auditeventDescCreatedAt is the schema descriptor for created_at field.
+	auditeventDescCreatedAt := auditeventFields[7].Descriptor()
+	// auditevent.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditevent.DefaultCreatedAt = auditeventDescCreatedAt.Default.(func() time.Time)
+	// auditeventDescID is the schema descriptor for id field.
+	auditeventDescID := auditeventFields[0].Descriptor()
+	// auditevent.DefaultID holds the default value on creation for the id field.
+	auditevent.DefaultID = auditeventDescID.Default.(func() types.AuditEventID)
 	chatFields := schema.Chat{}.Fields()
 	_ = chatFields
 	// chatDescClientID is the schema descriptor for client_id field.
@@ -56,28 +98,32 @@ func init() {
 	messageDescBody := messageFields[1].Descriptor()
 	// message.BodyValidator is a validator for the "body" field. It is called by the builders before save.
 	message.BodyValidator = messageDescBody.Validators[0].(func(string) error)
+	// messageDescIsRedacted is the schema descriptor for is_redacted field.
+	messageDescIsRedacted := messageFields[4].Descriptor()
+	// message.DefaultIsRedacted holds the default value on creation for the is_redacted field.
+	message.DefaultIsRedacted = messageDescIsRedacted.Default.(bool)
 	// messageDescAuthorID is the schema descriptor for author_id field.
-	messageDescAuthorID := messageFields[2].Descriptor()
+	messageDescAuthorID := messageFields[5].Descriptor()
 	// message.AuthorIDValidator is a validator for the "author_id" field. It is called by the builders before save.
 	message.AuthorIDValidator = messageDescAuthorID.Validators[0].(func(string) error)
 	// messageDescIsVisibleForClient is the schema descriptor for is_visible_for_client field.
-	messageDescIsVisibleForClient := messageFields[3].Descriptor()
+	messageDescIsVisibleForClient := messageFields[6].Descriptor()
 	// message.DefaultIsVisibleForClient holds the default value on creation for the is_visible_for_client field.
 	message.DefaultIsVisibleForClient = messageDescIsVisibleForClient.Default.(bool)
 	// messageDescIsVisibleForManager is the schema descriptor for is_visible_for_manager field.
-	messageDescIsVisibleForManager := messageFields[4].Descriptor()
+	messageDescIsVisibleForManager := messageFields[7].Descriptor()
 	// message.DefaultIsVisibleForManager holds the default value on creation for the is_visible_for_manager field.
 	message.DefaultIsVisibleForManager = messageDescIsVisibleForManager.Default.(bool)
 	// messageDescIsBlocked is the schema descriptor for is_blocked field.
-	messageDescIsBlocked := messageFields[5].Descriptor()
+	messageDescIsBlocked := messageFields[8].Descriptor()
 	// message.DefaultIsBlocked holds the default value on creation for the is_blocked field.
 	message.DefaultIsBlocked = messageDescIsBlocked.Default.(bool)
 	// messageDescIsService is the schema descriptor for is_service field.
-	messageDescIsService := messageFields[6].Descriptor()
+	messageDescIsService := messageFields[9].Descriptor()
 	// message.DefaultIsService holds the default value on creation for the is_service field.
 	message.DefaultIsService = messageDescIsService.Default.(bool)
 	// messageDescCreatedAt is the schema descriptor for created_at field.
-	messageDescCreatedAt := messageFields[7].Descriptor()
+	messageDescCreatedAt := messageFields[10].Descriptor()
 	// message.DefaultCreatedAt holds the default value on creation for the created_at field.
 	message.DefaultCreatedAt = messageDescCreatedAt.Default.(func() time.Time)
 	// messageDescID is the schema descriptor for id field.
@@ -91,11 +137,11 @@ func init() {
 	// problem.ManagerIDValidator is a validator for the "manager_id" field. It is called by the builders before save.
 	problem.ManagerIDValidator = problemDescManagerID.Validators[0].(func(string) error)
 	// problemDescCreatedAt is the schema descriptor for created_at field.
-	problemDescCreatedAt := problemFields[3].Descriptor()
+	problemDescCreatedAt := problemFields[4].Descriptor()
 	// problem.DefaultCreatedAt holds the default value on creation for the created_at field.
 	problem.DefaultCreatedAt = problemDescCreatedAt.Default.(func() time.Time)
 	// problemDescUpdatedAt is the schema descriptor for updated_at field.
-	problemDescUpdatedAt := problemFields[4].Descriptor()
+	problemDescUpdatedAt := problemFields[5].Descriptor()
 	// problem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
 	problem.DefaultUpdatedAt = problemDescUpdatedAt.Default.(func() time.Time)
 	// problem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// Attachment holds the schema definition for the Attachment entity.
+type Attachment struct {
+	ent.Schema
+}
+
+// Fields of the Attachment.
+func (Attachment) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			GoType(types.AttachmentID{}).
+			DefaultFunc(func() types.AttachmentID {
+				return types.NewAttachmentID()
+			}).
+			Unique().
+			Immutable(),
+		field.String("uploader_id").
+			GoType(types.UserID{}).
+			NotEmpty().
+			Immutable(),
+		// message_id заполняется, когда вложение прикрепляется к отправленному сообщению.
+		field.String("message_id").
+			GoType(types.MessageID{}).
+			Optional(),
+		field.String("file_name").
+			NotEmpty().
+			Immutable(),
+		field.String("content_type").
+			NotEmpty().
+			Immutable(),
+		field.Int64("size").
+			Positive().
+			Immutable(),
+		// blob_key - ключ файла в хранилище BLOB-объектов.
+		field.String("blob_key").
+			NotEmpty().
+			Unique().
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Attachment.
+func (Attachment) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("message", Message.Type).
+			Ref("attachments").
+			Field("message_id").
+			Unique(),
+	}
+}
@@ -0,0 +1,72 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// AuditEvent holds the schema definition for the AuditEvent entity.
+// Журнал аудита только дополняется: все поля неизменяемые, а записи удаляются лишь по сроку хранения
+// (изменение и удаление событий вне очистки запрещает audit.Hook).
+type AuditEvent struct {
+	ent.Schema
+}
+
+// Fields of the AuditEvent.
+func (AuditEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("id").
+			GoType(types.AuditEventID{}).
+			DefaultFunc(func() types.AuditEventID {
+				return types.NewAuditEventID()
+			}).
+			Unique().
+			Immutable(),
+		// actor - кто совершил действие: идентификатор пользователя, имя пользователя отладочного сервера.
+		// Пустой, если пользователь неизвестен (например, при неудачной аутентификации).
+		field.String("actor").
+			Optional().
+			Immutable(),
+		field.Enum("action").
+			Values(
+				"auth_failed",
+				"message_edited",
+				"message_deleted",
+				"problem_assigned",
+				"problem_closed",
+				"config_changed",
+			).
+			Immutable(),
+		// target - объект действия, например "message:<id>" или "log_level:server-client".
+		field.String("target").
+			Optional().
+			Immutable(),
+		field.JSON("metadata", map[string]any{}).
+			Optional().
+			Immutable(),
+		field.String("request_id").
+			GoType(types.RequestID{}).
+			Optional().
+			Immutable(),
+		field.String("ip").
+			Optional().
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the AuditEvent.
+func (AuditEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+		index.Fields("actor", "created_at"),
+		index.Fields("action", "created_at"),
+	}
+}
@@ -27,6 +27,20 @@ func (Message) Fields() []ent.Field {
 			Immutable(),
 		field.String("body").
 			NotEmpty(),
+		// original_body хранит исходный текст сообщения до маскирования чувствительных данных.
+		// Заполняется, только если это разрешено конфигурацией редактора.
+		field.String("original_body").
+			Optional().
+			Nillable().
+			Sensitive(),
+		// body_key_id - идентификатор мастер-ключа, которым зашифрованы body и original_body.
+		// Пустой, если шифрование отключено.
+		field.String("body_key_id").
+			Optional().
+			Nillable(),
+		// is_redacted - признак того, что из сообщения были удалены чувствительные данные.
+		field.Bool("is_redacted").
+			Default(false),
 		field.String("author_id").
 			GoType(types.UserID{}).
 			Immutable().
@@ -66,5 +80,6 @@ func (Message) Edges() []ent.Edge {
 			Field("problem_id").
 			Unique().
 			Immutable(),
+		edge.To("attachments", Attachment.Type),
 	}
 }
@@ -32,6 +32,10 @@ func (Problem) Fields() []ent.Field {
 		field.Enum("status").
 			Values("open", "in_progress", "resolved", "closed").
 			Default("open"),
+		// resolved_at - когда проблема решена. Пустой, пока проблема открыта.
+		field.Time("resolved_at").
+			Optional().
+			Nillable(),
 		field.Time("created_at").
 			Default(time.Now).
 			Immutable(),
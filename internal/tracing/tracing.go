@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/FischukSergey/chat-service/internal/buildinfo"
+)
+
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+//go:generate options-gen -out-filename=tracing_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	serviceName string `option:"mandatory" validate:"required"`
+	exporter    string `option:"mandatory" validate:"oneof=otlp stdout"`
+	// otlpEndpoint - адрес OTLP/HTTP коллектора в формате host:port.
+	otlpEndpoint string `validate:"omitempty,hostname_port"`
+	otlpInsecure bool
+	// samplerRatio - доля трассируемых корневых запросов. Для продолжаемых трасс учитывается решение родителя.
+	samplerRatio float64 `validate:"min=0,max=1"`
+	env          string
+	// stdoutWriter - куда пишет экспортер stdout. По умолчанию stderr, чтобы спаны не перемешивались с логами в stdout.
+	stdoutWriter io.Writer `validate:"required"`
+}
+
+var defaultOptions = Options{
+	samplerRatio: 1,
+	env:          "dev",
+	stdoutWriter: os.Stderr,
+}
+
+// Init создает провайдер трассировки и делает его глобальным вместе с W3C-пропагатором (traceparent).
+// Провайдер нужно остановить через Shutdown, чтобы отправить накопленные спаны.
+func Init(ctx context.Context, opts Options) (*sdktrace.TracerProvider, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+	if opts.exporter == ExporterOTLP && opts.otlpEndpoint == "" {
+		return nil, errors.New("otlp endpoint is required for otlp exporter")
+	}
+
+	exporter, err := newExporter(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("create exporter: %v", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(opts.serviceName),
+		semconv.ServiceVersion(buildinfo.BuildInfo.Main.Version),
+		semconv.DeploymentEnvironment(opts.env),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("create resource: %v", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.samplerRatio))),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return tp, nil
+}
+
+func newExporter(ctx context.Context, opts Options) (sdktrace.SpanExporter, error) {
+	switch opts.exporter {
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(opts.stdoutWriter), stdouttrace.WithPrettyPrint())
+
+	case ExporterOTLP:
+		clientOpts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(opts.otlpEndpoint)}
+		if opts.otlpInsecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, clientOpts...)
+	}
+	return nil, fmt.Errorf("unknown exporter %q", opts.exporter)
+}
@@ -0,0 +1,127 @@
+// Code generated by options-gen. DO NOT EDIT.
+package tracing
+
+import (
+	fmt461e464ebed9 "fmt"
+	"io"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	serviceName string,
+	exporter string,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.serviceName = defaultOptions.serviceName
+
+	o.exporter = defaultOptions.exporter
+
+	o.otlpEndpoint = defaultOptions.otlpEndpoint
+
+	o.otlpInsecure = defaultOptions.otlpInsecure
+
+	o.samplerRatio = defaultOptions.samplerRatio
+
+	o.env = defaultOptions.env
+
+	o.stdoutWriter = defaultOptions.stdoutWriter
+
+	o.serviceName = serviceName
+
+	o.exporter = exporter
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// otlpEndpoint - адрес OTLP/HTTP коллектора в формате host:port.
+func WithOtlpEndpoint(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.otlpEndpoint = opt
+
+	}
+}
+
+func WithOtlpInsecure(opt bool) OptOptionsSetter {
+	return func(o *Options) {
+		o.otlpInsecure = opt
+
+	}
+}
+
+// samplerRatio - доля трассируемых корневых запросов. Для продолжаемых трасс учитывается решение родителя.
+func WithSamplerRatio(opt float64) OptOptionsSetter {
+	return func(o *Options) {
+		o.samplerRatio = opt
+
+	}
+}
+
+func WithEnv(opt string) OptOptionsSetter {
+	return func(o *Options) {
+		o.env = opt
+
+	}
+}
+
+// stdoutWriter - куда пишет экспортер stdout. По умолчанию stderr, чтобы спаны не перемешивались с логами в stdout.
+func WithStdoutWriter(opt io.Writer) OptOptionsSetter {
+	return func(o *Options) {
+		o.stdoutWriter = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("serviceName", _validate_Options_serviceName(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("exporter", _validate_Options_exporter(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("otlpEndpoint", _validate_Options_otlpEndpoint(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("samplerRatio", _validate_Options_samplerRatio(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("stdoutWriter", _validate_Options_stdoutWriter(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_serviceName(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.serviceName, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `serviceName` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_exporter(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.exporter, "oneof=otlp stdout"); err != nil {
+		return fmt461e464ebed9.Errorf("field `exporter` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_otlpEndpoint(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.otlpEndpoint, "omitempty,hostname_port"); err != nil {
+		return fmt461e464ebed9.Errorf("field `otlpEndpoint` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_samplerRatio(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.samplerRatio, "min=0,max=1"); err != nil {
+		return fmt461e464ebed9.Errorf("field `samplerRatio` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_stdoutWriter(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.stdoutWriter, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `stdoutWriter` did not pass the test: %w", err)
+	}
+	return nil
+}
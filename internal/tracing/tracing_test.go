@@ -0,0 +1,61 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/FischukSergey/chat-service/internal/tracing"
+)
+
+func TestInit(t *testing.T) {
+	ctx := context.Background()
+
+	var exported bytes.Buffer
+	tp, err := tracing.Init(ctx, tracing.NewOptions("test", tracing.ExporterStdout,
+		tracing.WithSamplerRatio(0),
+		tracing.WithStdoutWriter(&exported),
+	))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, tp.Shutdown(ctx)) })
+
+	t.Run("continues sampled parent trace", func(t *testing.T) {
+		h := http.Header{}
+		h.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		parentCtx := otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(h))
+
+		_, span := otel.Tracer("test").Start(parentCtx, "child")
+		sc := span.SpanContext()
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+		assert.True(t, sc.IsSampled())
+
+		span.End()
+		require.NoError(t, tp.ForceFlush(ctx))
+		assert.Contains(t, exported.String(), `"Name": "child"`)
+		assert.Contains(t, exported.String(), "4bf92f3577b34da6a3ce929d0e0e4736")
+	})
+
+	t.Run("root span uses ratio", func(t *testing.T) {
+		exported.Reset()
+
+		_, span := otel.Tracer("test").Start(ctx, "root")
+		assert.False(t, span.SpanContext().IsSampled())
+		assert.Equal(t, trace.SpanContextFromContext(ctx), trace.SpanContext{})
+
+		span.End()
+		require.NoError(t, tp.ForceFlush(ctx))
+		assert.Empty(t, exported.String())
+	})
+}
+
+func TestInit_InvalidOptions(t *testing.T) {
+	_, err := tracing.Init(context.Background(), tracing.NewOptions("test", tracing.ExporterOTLP))
+	require.Error(t, err)
+}
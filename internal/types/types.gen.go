@@ -298,6 +298,122 @@ func (c RequestID) IsZero() bool {
 	return c == RequestIDNil
 }
 
+type AttachmentID uuid.UUID
+
+func (c AttachmentID) String() string {
+	return uuid.UUID(c).String()
+}
+
+//TextMarshaler реализует интерфейс encoding.TextMarshaler
+func (c AttachmentID) MarshalText() ([]byte, error) {
+	return uuid.UUID(c).MarshalText()
+}
+
+//TextUnmarshaler реализует интерфейс encoding.TextUnmarshaler
+func (c *AttachmentID) UnmarshalText(text []byte) error {
+	return (*uuid.UUID)(c).UnmarshalText(text)
+}
+
+//ValueScanner реализует интерфейс entfield.ValueScanner
+//из двух методов: Scan и Value
+func (c *AttachmentID) Scan(src interface{}) error {
+	return (*uuid.UUID)(c).Scan(src)
+}
+
+func (c AttachmentID) Value() (driver.Value, error) {
+	return uuid.UUID(c).Value()
+}
+
+//Validator реализует интерфейс entfield.Validator
+func (c AttachmentID) Validate() error {
+	if c == AttachmentIDNil {
+		return errors.New("AttachmentID is nil")
+	}
+	return nil
+}
+
+//Matcher реализует интерфейс gomock.Matcher
+func (c1 AttachmentID) Matches(x interface{}) bool {
+	if c2, ok := x.(AttachmentID); ok {
+		return c1 == c2
+	}
+	if id, ok := x.(uuid.UUID); ok {
+		return uuid.UUID(c1).String() == id.String()
+	}
+	return false
+}
+
+//NewAttachmentID создает новый AttachmentID
+func NewAttachmentID() AttachmentID {
+	return AttachmentID(uuid.New())
+}
+
+//AttachmentIDNil это nil AttachmentID
+var AttachmentIDNil = AttachmentID(uuid.Nil)
+
+//IsZero проверяет, является ли AttachmentID нулевым
+func (c AttachmentID) IsZero() bool {
+	return c == AttachmentIDNil
+}
+
+type AuditEventID uuid.UUID
+
+func (c AuditEventID) String() string {
+	return uuid.UUID(c).String()
+}
+
+//TextMarshaler реализует интерфейс encoding.TextMarshaler
+func (c AuditEventID) MarshalText() ([]byte, error) {
+	return uuid.UUID(c).MarshalText()
+}
+
+//TextUnmarshaler реализует интерфейс encoding.TextUnmarshaler
+func (c *AuditEventID) UnmarshalText(text []byte) error {
+	return (*uuid.UUID)(c).UnmarshalText(text)
+}
+
+//ValueScanner реализует интерфейс entfield.ValueScanner
+//из двух методов: Scan и Value
+func (c *AuditEventID) Scan(src interface{}) error {
+	return (*uuid.UUID)(c).Scan(src)
+}
+
+func (c AuditEventID) Value() (driver.Value, error) {
+	return uuid.UUID(c).Value()
+}
+
+//Validator реализует интерфейс entfield.Validator
+func (c AuditEventID) Validate() error {
+	if c == AuditEventIDNil {
+		return errors.New("AuditEventID is nil")
+	}
+	return nil
+}
+
+//Matcher реализует интерфейс gomock.Matcher
+func (c1 AuditEventID) Matches(x interface{}) bool {
+	if c2, ok := x.(AuditEventID); ok {
+		return c1 == c2
+	}
+	if id, ok := x.(uuid.UUID); ok {
+		return uuid.UUID(c1).String() == id.String()
+	}
+	return false
+}
+
+//NewAuditEventID создает новый AuditEventID
+func NewAuditEventID() AuditEventID {
+	return AuditEventID(uuid.New())
+}
+
+//AuditEventIDNil это nil AuditEventID
+var AuditEventIDNil = AuditEventID(uuid.Nil)
+
+//IsZero проверяет, является ли AuditEventID нулевым
+func (c AuditEventID) IsZero() bool {
+	return c == AuditEventIDNil
+}
+
 // Parse парсит строку и возвращает UUID тип
 func Parse[T any](s string) (T, error) {	
 	var result T
@@ -316,6 +432,12 @@ func Parse[T any](s string) (T, error) {
 		return any(ProblemID(id)).(T), nil
 	case UserID:
 		return any(UserID(id)).(T), nil
+	case AttachmentID:
+		return any(AttachmentID(id)).(T), nil
+	case RequestID:
+		return any(RequestID(id)).(T), nil
+	case AuditEventID:
+		return any(AuditEventID(id)).(T), nil
 	default:
 		return any(id).(T), nil
 	}
@@ -339,6 +461,12 @@ func MustParse[T any](s string) T {
 		return any(ProblemID(id)).(T)
 	case UserID:
 		return any(UserID(id)).(T)
+	case AttachmentID:
+		return any(AttachmentID(id)).(T)
+	case RequestID:
+		return any(RequestID(id)).(T)
+	case AuditEventID:
+		return any(AuditEventID(id)).(T)
 	default:
 		return any(id).(T)
 	}
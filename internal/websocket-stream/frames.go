@@ -0,0 +1,81 @@
+package websocketstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// Кадры соединения описаны в спецификации API: TypingSignal, TypingEvent, MessageEvent.
+
+// SignalType - тип сигнала от пользователя.
+type SignalType string
+
+const SignalTyping SignalType = "typing"
+
+// Signal - сигнал от пользователя.
+type Signal struct {
+	Type SignalType `json:"type"`
+	// ChatID - чат, к которому относится сигнал менеджера. Клиент чат не указывает.
+	ChatID types.ChatID `json:"chatId"`
+}
+
+type typingEvent struct {
+	Type   string       `json:"type"`
+	ChatID types.ChatID `json:"chatId"`
+	UserID types.UserID `json:"userId"`
+	At     time.Time    `json:"at"`
+}
+
+type messageEvent struct {
+	Type    string       `json:"type"`
+	ChatID  types.ChatID `json:"chatId"`
+	Message message      `json:"message"`
+}
+
+type message struct {
+	ID            types.MessageID      `json:"id"`
+	AuthorID      types.UserID         `json:"authorId"`
+	Body          string               `json:"body"`
+	IsRedacted    bool                 `json:"isRedacted"`
+	AttachmentIDs []types.AttachmentID `json:"attachmentIds"`
+	CreatedAt     time.Time            `json:"createdAt"`
+}
+
+type errorFrame struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func encodeEvent(e eventstream.Event) ([]byte, error) {
+	switch e := e.(type) {
+	case eventstream.TypingEvent:
+		return json.Marshal(typingEvent{Type: "typing", ChatID: e.ChatID, UserID: e.UserID, At: e.At})
+	case eventstream.MessageEvent:
+		return json.Marshal(messageEvent{Type: "message", ChatID: e.ChatID, Message: message{
+			ID:            e.MessageID,
+			AuthorID:      e.AuthorID,
+			Body:          e.Body,
+			IsRedacted:    e.IsRedacted,
+			AttachmentIDs: nonNil(e.AttachmentIDs),
+			CreatedAt:     e.CreatedAt,
+		}})
+	}
+	return nil, fmt.Errorf("unknown event %T", e)
+}
+
+func encodeError(message string) []byte {
+	data, _ := json.Marshal(errorFrame{Type: "error", Message: message})
+	return data
+}
+
+// nonNil заменяет nil на пустой срез, чтобы в JSON был массив, а не null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
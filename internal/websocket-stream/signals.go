@@ -0,0 +1,63 @@
+package websocketstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/FischukSergey/chat-service/internal/services/typing"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+type clientTyping interface {
+	ClientTyping(ctx context.Context, clientID types.UserID) error
+}
+
+type managerTyping interface {
+	ManagerTyping(ctx context.Context, managerID types.UserID, chatID types.ChatID) error
+}
+
+// ClientSignals обрабатывает сигналы клиента.
+type ClientSignals struct {
+	typing clientTyping
+}
+
+func NewClientSignals(typing clientTyping) ClientSignals {
+	return ClientSignals{typing: typing}
+}
+
+func (s ClientSignals) HandleSignal(ctx context.Context, clientID types.UserID, sig Signal) error {
+	switch sig.Type {
+	case SignalTyping:
+		return rejectTypingErrors(s.typing.ClientTyping(ctx, clientID))
+	}
+	return fmt.Errorf("%w: unknown signal %q", ErrSignalRejected, sig.Type)
+}
+
+// ManagerSignals обрабатывает сигналы менеджера. Сигнал принимается только в чате,
+// где менеджеру назначена нерешенная проблема.
+type ManagerSignals struct {
+	typing managerTyping
+}
+
+func NewManagerSignals(typing managerTyping) ManagerSignals {
+	return ManagerSignals{typing: typing}
+}
+
+func (s ManagerSignals) HandleSignal(ctx context.Context, managerID types.UserID, sig Signal) error {
+	switch sig.Type {
+	case SignalTyping:
+		if sig.ChatID.IsZero() {
+			return fmt.Errorf("%w: chatId is required", ErrSignalRejected)
+		}
+		return rejectTypingErrors(s.typing.ManagerTyping(ctx, managerID, sig.ChatID))
+	}
+	return fmt.Errorf("%w: unknown signal %q", ErrSignalRejected, sig.Type)
+}
+
+func rejectTypingErrors(err error) error {
+	if errors.Is(err, typing.ErrChatNotFound) || errors.Is(err, typing.ErrNotAssigned) {
+		return fmt.Errorf("%w: %v", ErrSignalRejected, err)
+	}
+	return err
+}
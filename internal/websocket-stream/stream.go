@@ -0,0 +1,220 @@
+package websocketstream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// Protocol - подпротокол WebSocket. Браузер не может задать заголовок Authorization при открытии
+// соединения, поэтому передает токен вторым элементом Sec-WebSocket-Protocol: "chat-service-protocol, <token>".
+const Protocol = "chat-service-protocol"
+
+// ErrSignalRejected оборачивает ошибки сигналов, текст которых можно показать пользователю.
+var ErrSignalRejected = errors.New("signal rejected")
+
+type eventSubscriber interface {
+	Subscribe(ctx context.Context, userID types.UserID) <-chan eventstream.Event
+}
+
+// SignalHandler обрабатывает сигналы, которые пользователь присылает через соединение.
+type SignalHandler interface {
+	HandleSignal(ctx context.Context, userID types.UserID, s Signal) error
+}
+
+type presenceTracker interface {
+	Touch(userID types.UserID)
+}
+
+//go:generate options-gen -out-filename=stream_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	logger  *zap.Logger     `option:"mandatory" validate:"required"`
+	events  eventSubscriber `option:"mandatory" validate:"required"`
+	signals SignalHandler   `option:"mandatory" validate:"required"`
+	// presence отмечает активность пользователя, пока соединение открыто.
+	presence presenceTracker
+	// pingPeriod - интервал ping-кадров. Соединение закрывается, если за два интервала не пришло ни одного кадра.
+	pingPeriod   time.Duration `validate:"min=100ms"`
+	writeTimeout time.Duration `validate:"min=10ms"`
+	// maxSignalSize - максимальный размер кадра от пользователя в байтах.
+	maxSignalSize int64 `validate:"min=64"`
+}
+
+var defaultOptions = Options{
+	pingPeriod:    30 * time.Second,
+	writeTimeout:  5 * time.Second,
+	maxSignalSize: 1 << 10,
+}
+
+// HTTPHandler открывает WebSocket-соединение пользователя, пересылает в него события
+// из шины событий и передает обработчику сигналы пользователя.
+type HTTPHandler struct {
+	Options
+	upgrader websocket.Upgrader
+}
+
+func NewHTTPHandler(opts Options) (*HTTPHandler, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+
+	return &HTTPHandler{
+		Options: opts,
+		upgrader: websocket.Upgrader{
+			Subprotocols: []string{Protocol},
+			// Источник запроса проверяет сервер до открытия соединения по списку разрешенных CORS-источников.
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+	}, nil
+}
+
+// Serve обслуживает соединение пользователя userID, пока его не закроет пользователь
+// или шина событий (при остановке сервиса пользователь получает кадр закрытия 1001).
+func (h *HTTPHandler) Serve(w http.ResponseWriter, r *http.Request, userID types.UserID) error {
+	ws, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade уже ответил клиенту ошибкой.
+		h.logger.Debug("websocket upgrade failed", zap.Error(err))
+		return nil
+	}
+	defer ws.Close()
+
+	// После перехвата соединения контекст запроса не отменяется при отключении клиента:
+	// соединение отменяет его само, когда чтение или запись завершаются ошибкой.
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	events := h.events.Subscribe(ctx, userID)
+	replies := make(chan []byte, 1)
+
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		defer cancel()
+		h.readLoop(ctx, ws, userID, replies)
+	}()
+
+	h.writeLoop(ctx, ws, events, replies)
+
+	cancel()
+	_ = ws.Close()
+	<-readDone
+	return nil
+}
+
+func (h *HTTPHandler) writeLoop(ctx context.Context, ws *websocket.Conn, events <-chan eventstream.Event, replies <-chan []byte) {
+	ping := time.NewTicker(h.pingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case e, ok := <-events:
+			if !ok {
+				msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down")
+				if err := ws.WriteControl(websocket.CloseMessage, msg, time.Now().Add(h.writeTimeout)); err != nil {
+					h.logger.Debug("write close frame failed", zap.Error(err))
+				}
+				return
+			}
+
+			data, err := encodeEvent(e)
+			if err != nil {
+				h.logger.Error("encode event failed", zap.Error(err))
+				continue
+			}
+			if err := h.write(ws, data); err != nil {
+				return
+			}
+
+		case data := <-replies:
+			if err := h.write(ws, data); err != nil {
+				return
+			}
+
+		case <-ping.C:
+			if err := ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.writeTimeout)); err != nil {
+				h.logger.Debug("write ping failed", zap.Error(err))
+				return
+			}
+		}
+	}
+}
+
+func (h *HTTPHandler) write(ws *websocket.Conn, data []byte) error {
+	if err := ws.SetWriteDeadline(time.Now().Add(h.writeTimeout)); err != nil {
+		return err
+	}
+	if err := ws.WriteMessage(websocket.TextMessage, data); err != nil {
+		h.logger.Debug("write frame failed", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (h *HTTPHandler) readLoop(ctx context.Context, ws *websocket.Conn, userID types.UserID, replies chan<- []byte) {
+	ws.SetReadLimit(h.maxSignalSize)
+
+	alive := func() error {
+		if h.presence != nil {
+			h.presence.Touch(userID)
+		}
+		return ws.SetReadDeadline(time.Now().Add(2 * h.pingPeriod))
+	}
+	if err := alive(); err != nil {
+		return
+	}
+	ws.SetPongHandler(func(string) error { return alive() })
+
+	for {
+		_, data, err := ws.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				h.logger.Debug("read frame failed", zap.Error(err))
+			}
+			return
+		}
+		if err := alive(); err != nil {
+			return
+		}
+
+		reply := h.handleSignal(ctx, userID, data)
+		if reply == nil {
+			continue
+		}
+		select {
+		case replies <- reply:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handleSignal обрабатывает кадр пользователя и возвращает кадр ошибки для ответа или nil.
+func (h *HTTPHandler) handleSignal(ctx context.Context, userID types.UserID, data []byte) []byte {
+	var s Signal
+	if err := json.Unmarshal(data, &s); err != nil {
+		return encodeError("invalid signal")
+	}
+
+	err := h.signals.HandleSignal(ctx, userID, s)
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, ErrSignalRejected) {
+		return encodeError(err.Error())
+	}
+	h.logger.Error("handle signal failed", zap.Stringer("user_id", userID), zap.String("type", string(s.Type)), zap.Error(err))
+	return encodeError("internal error")
+}
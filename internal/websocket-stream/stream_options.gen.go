@@ -0,0 +1,132 @@
+// Code generated by options-gen. DO NOT EDIT.
+package websocketstream
+
+import (
+	fmt461e464ebed9 "fmt"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+	"go.uber.org/zap"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	logger *zap.Logger,
+	events eventSubscriber,
+	signals SignalHandler,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.logger = defaultOptions.logger
+
+	o.events = defaultOptions.events
+
+	o.signals = defaultOptions.signals
+
+	o.presence = defaultOptions.presence
+
+	o.pingPeriod = defaultOptions.pingPeriod
+
+	o.writeTimeout = defaultOptions.writeTimeout
+
+	o.maxSignalSize = defaultOptions.maxSignalSize
+
+	o.logger = logger
+
+	o.events = events
+
+	o.signals = signals
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// presence отмечает активность пользователя, пока соединение открыто.
+func WithPresence(opt presenceTracker) OptOptionsSetter {
+	return func(o *Options) {
+		o.presence = opt
+
+	}
+}
+
+// pingPeriod - интервал ping-кадров. Соединение закрывается, если за два интервала не пришло ни одного кадра.
+func WithPingPeriod(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.pingPeriod = opt
+
+	}
+}
+
+func WithWriteTimeout(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.writeTimeout = opt
+
+	}
+}
+
+// maxSignalSize - максимальный размер кадра от пользователя в байтах.
+func WithMaxSignalSize(opt int64) OptOptionsSetter {
+	return func(o *Options) {
+		o.maxSignalSize = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("logger", _validate_Options_logger(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("events", _validate_Options_events(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("signals", _validate_Options_signals(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("pingPeriod", _validate_Options_pingPeriod(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("writeTimeout", _validate_Options_writeTimeout(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("maxSignalSize", _validate_Options_maxSignalSize(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_logger(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.logger, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `logger` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_events(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.events, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `events` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_signals(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.signals, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `signals` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_pingPeriod(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.pingPeriod, "min=100ms"); err != nil {
+		return fmt461e464ebed9.Errorf("field `pingPeriod` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_writeTimeout(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.writeTimeout, "min=10ms"); err != nil {
+		return fmt461e464ebed9.Errorf("field `writeTimeout` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_maxSignalSize(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.maxSignalSize, "min=64"); err != nil {
+		return fmt461e464ebed9.Errorf("field `maxSignalSize` did not pass the test: %w", err)
+	}
+	return nil
+}
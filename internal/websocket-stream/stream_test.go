@@ -0,0 +1,185 @@
+package websocketstream_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/FischukSergey/chat-service/internal/services/eventstream"
+	"github.com/FischukSergey/chat-service/internal/types"
+	websocketstream "github.com/FischukSergey/chat-service/internal/websocket-stream"
+)
+
+type fakeSignals struct {
+	mu      sync.Mutex
+	signals []websocketstream.Signal
+}
+
+func (f *fakeSignals) HandleSignal(_ context.Context, _ types.UserID, s websocketstream.Signal) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.signals = append(f.signals, s)
+	switch s.ChatID {
+	case rejectedChatID:
+		return fmt.Errorf("%w: not assigned", websocketstream.ErrSignalRejected)
+	case failedChatID:
+		return errors.New("database is down")
+	}
+	return nil
+}
+
+func (f *fakeSignals) received() []websocketstream.Signal {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]websocketstream.Signal(nil), f.signals...)
+}
+
+type fakePresence struct {
+	mu      sync.Mutex
+	touched map[types.UserID]int
+}
+
+func (p *fakePresence) Touch(userID types.UserID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.touched[userID]++
+}
+
+func (p *fakePresence) count(userID types.UserID) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.touched[userID]
+}
+
+var (
+	rejectedChatID = types.NewChatID()
+	failedChatID   = types.NewChatID()
+)
+
+func TestHTTPHandler(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	events, err := eventstream.New(eventstream.NewOptions())
+	require.NoError(t, err)
+
+	signals := new(fakeSignals)
+	presence := &fakePresence{touched: make(map[types.UserID]int)}
+	h, err := websocketstream.NewHTTPHandler(websocketstream.NewOptions(
+		zap.NewNop(),
+		events,
+		signals,
+		websocketstream.WithPresence(presence),
+	))
+	require.NoError(t, err)
+
+	userID := types.NewUserID()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, h.Serve(w, r, userID))
+	}))
+	defer srv.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{websocketstream.Protocol}}
+	ws, resp, err := dialer.DialContext(ctx, "ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	defer ws.Close()
+	defer resp.Body.Close()
+	assert.Equal(t, websocketstream.Protocol, ws.Subprotocol())
+
+	require.Eventually(t, func() bool {
+		return events.Subscribers()[userID] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	t.Run("event is delivered", func(t *testing.T) {
+		at := time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC)
+		chatID, fromID := types.NewChatID(), types.NewUserID()
+		require.NoError(t, events.Publish(ctx, userID, eventstream.TypingEvent{ChatID: chatID, UserID: fromID, At: at}))
+
+		assert.JSONEq(t,
+			fmt.Sprintf(`{"type":"typing","chatId":%q,"userId":%q,"at":"2024-01-02T10:30:00Z"}`, chatID, fromID),
+			readFrame(t, ws))
+	})
+
+	t.Run("message is delivered", func(t *testing.T) {
+		at := time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC)
+		chatID, msgID, authorID, attachmentID := types.NewChatID(), types.NewMessageID(), types.NewUserID(), types.NewAttachmentID()
+		require.NoError(t, events.Publish(ctx, userID, eventstream.MessageEvent{
+			ChatID:        chatID,
+			MessageID:     msgID,
+			AuthorID:      authorID,
+			Body:          "Здравствуйте",
+			AttachmentIDs: []types.AttachmentID{attachmentID},
+			CreatedAt:     at,
+		}))
+
+		assert.JSONEq(t,
+			fmt.Sprintf(`{"type":"message","chatId":%q,"message":{"id":%q,"authorId":%q,"body":"Здравствуйте",`+
+				`"isRedacted":false,"attachmentIds":[%q],"createdAt":"2024-01-02T10:30:00Z"}}`,
+				chatID, msgID, authorID, attachmentID),
+			readFrame(t, ws))
+	})
+
+	t.Run("signal is handled", func(t *testing.T) {
+		chatID := types.NewChatID()
+		require.NoError(t, ws.WriteJSON(map[string]any{"type": "typing", "chatId": chatID}))
+
+		require.Eventually(t, func() bool {
+			return len(signals.received()) == 1
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, websocketstream.Signal{Type: websocketstream.SignalTyping, ChatID: chatID}, signals.received()[0])
+		assert.Positive(t, presence.count(userID))
+	})
+
+	t.Run("rejected signal", func(t *testing.T) {
+		require.NoError(t, ws.WriteJSON(map[string]any{"type": "typing", "chatId": rejectedChatID}))
+		assert.JSONEq(t, `{"type":"error","message":"signal rejected: not assigned"}`, readFrame(t, ws))
+	})
+
+	t.Run("internal errors are not exposed", func(t *testing.T) {
+		require.NoError(t, ws.WriteJSON(map[string]any{"type": "typing", "chatId": failedChatID}))
+		assert.JSONEq(t, `{"type":"error","message":"internal error"}`, readFrame(t, ws))
+	})
+
+	t.Run("invalid signal", func(t *testing.T) {
+		require.NoError(t, ws.WriteMessage(websocket.TextMessage, []byte("not json")))
+		assert.JSONEq(t, `{"type":"error","message":"invalid signal"}`, readFrame(t, ws))
+	})
+
+	t.Run("close frame on shutdown", func(t *testing.T) {
+		closed := make(chan error, 1)
+		go func() { closed <- events.Close(ctx) }()
+
+		_, _, err := ws.ReadMessage()
+		var closeErr *websocket.CloseError
+		require.ErrorAs(t, err, &closeErr)
+		assert.Equal(t, websocket.CloseGoingAway, closeErr.Code)
+
+		require.NoError(t, <-closed)
+		assert.Empty(t, events.Subscribers())
+	})
+}
+
+func readFrame(t *testing.T, ws *websocket.Conn) string {
+	t.Helper()
+
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(time.Second)))
+	_, data, err := ws.ReadMessage()
+	require.NoError(t, err)
+
+	var v map[string]any
+	require.NoError(t, json.Unmarshal(data, &v), string(data))
+	return string(data)
+}
@@ -0,0 +1,153 @@
+package chatclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	clientv1 "github.com/FischukSergey/chat-service/internal/server-client/v1"
+)
+
+// Типы запросов и ответов API.
+type (
+	GetHistoryRequest            = clientv1.GetHistoryRequest
+	MessagesPage                 = clientv1.MessagesPage
+	Message                      = clientv1.Message
+	SendMessageRequest           = clientv1.SendMessageRequest
+	ManagerSendMessageRequest    = clientv1.ManagerSendMessageRequest
+	ManagerGetChatHistoryRequest = clientv1.ManagerGetChatHistoryRequest
+	SearchMessagesRequest        = clientv1.SearchMessagesRequest
+	FoundMessagesPage            = clientv1.FoundMessagesPage
+	FoundMessage                 = clientv1.FoundMessage
+	GetAttachmentRequest         = clientv1.GetAttachmentRequest
+	Attachment                   = clientv1.Attachment
+	GetAuditEventsRequest        = clientv1.GetAuditEventsRequest
+	AuditEventsPage              = clientv1.AuditEventsPage
+	AuditEvent                   = clientv1.AuditEvent
+	AuditAction                  = clientv1.AuditAction
+	ChatsList                    = clientv1.ChatsList
+	ManagerChat                  = clientv1.ManagerChat
+	ManagerChatProblemStatus     = clientv1.ManagerChatProblemStatus
+	Presence                     = clientv1.Presence
+)
+
+// GetHistory возвращает страницу истории чата.
+func (c *Client) GetHistory(ctx context.Context, req GetHistoryRequest) (MessagesPage, error) {
+	return call[MessagesPage](ctx, c, "get history", func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+		return c.cli.PostGetHistory(ctx, &clientv1.PostGetHistoryParams{XRequestID: requestID}, req)
+	})
+}
+
+// SendMessage отправляет сообщение в чат клиента. Чат создается с первым сообщением.
+func (c *Client) SendMessage(ctx context.Context, req SendMessageRequest) (Message, error) {
+	return callMutating[Message](ctx, c, "send message",
+		func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+			return c.cli.PostSendMessage(ctx, &clientv1.PostSendMessageParams{XRequestID: requestID}, req)
+		})
+}
+
+// ManagerSendMessage отправляет сообщение менеджера в чат, где ему назначена нерешенная проблема.
+// Доступно только менеджерам.
+func (c *Client) ManagerSendMessage(ctx context.Context, req ManagerSendMessageRequest) (Message, error) {
+	return callMutating[Message](ctx, c, "manager send message",
+		func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+			return c.cli.PostManagerSendMessage(ctx, &clientv1.PostManagerSendMessageParams{XRequestID: requestID}, req)
+		})
+}
+
+// ManagerGetChatHistory возвращает страницу истории чата, включая сообщения, скрытые от клиента.
+// Доступно только менеджерам.
+func (c *Client) ManagerGetChatHistory(ctx context.Context, req ManagerGetChatHistoryRequest) (MessagesPage, error) {
+	return call[MessagesPage](ctx, c, "manager get chat history",
+		func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+			return c.cli.PostManagerGetChatHistory(ctx, &clientv1.PostManagerGetChatHistoryParams{XRequestID: requestID}, req)
+		})
+}
+
+// SearchMessages ищет по истории чата, новые сообщения первыми.
+func (c *Client) SearchMessages(ctx context.Context, req SearchMessagesRequest) (FoundMessagesPage, error) {
+	return call[FoundMessagesPage](ctx, c, "search messages",
+		func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+			return c.cli.PostSearchMessages(ctx, &clientv1.PostSearchMessagesParams{XRequestID: requestID}, req)
+		})
+}
+
+// ManagerSearchMessages ищет по чатам, где менеджеру назначалась проблема, включая сообщения, скрытые от клиентов.
+// Доступно только менеджерам.
+func (c *Client) ManagerSearchMessages(ctx context.Context, req SearchMessagesRequest) (FoundMessagesPage, error) {
+	return call[FoundMessagesPage](ctx, c, "manager search messages",
+		func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+			return c.cli.PostManagerSearchMessages(ctx, &clientv1.PostManagerSearchMessagesParams{XRequestID: requestID}, req)
+		})
+}
+
+// ManagerGetChats возвращает чаты, в которых менеджеру назначены нерешенные проблемы,
+// вместе с присутствием клиентов. Доступно только менеджерам.
+func (c *Client) ManagerGetChats(ctx context.Context) (ChatsList, error) {
+	return call[ChatsList](ctx, c, "manager get chats", func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+		return c.cli.PostManagerGetChats(ctx, &clientv1.PostManagerGetChatsParams{XRequestID: requestID})
+	})
+}
+
+// GetAttachment возвращает вложение со свежей ссылкой на скачивание.
+func (c *Client) GetAttachment(ctx context.Context, req GetAttachmentRequest) (Attachment, error) {
+	return call[Attachment](ctx, c, "get attachment", func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+		return c.cli.PostGetAttachment(ctx, &clientv1.PostGetAttachmentParams{XRequestID: requestID}, req)
+	})
+}
+
+// ManagerGetAttachment возвращает менеджеру вложение из сообщения чата со свежей ссылкой на скачивание.
+func (c *Client) ManagerGetAttachment(ctx context.Context, req GetAttachmentRequest) (Attachment, error) {
+	return call[Attachment](ctx, c, "manager get attachment",
+		func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+			return c.cli.PostManagerGetAttachment(ctx, &clientv1.PostManagerGetAttachmentParams{XRequestID: requestID}, req)
+		})
+}
+
+// UploadAttachment загружает файл. Содержимое читается в память целиком, чтобы повторить запрос после 429.
+func (c *Client) UploadAttachment(ctx context.Context, fileName string, content io.Reader) (Attachment, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("upload attachment: read content: %v", err)
+	}
+
+	return callMutating[Attachment](ctx, c, "upload attachment",
+		func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+			body, contentType, err := multipartFile(fileName, data)
+			if err != nil {
+				return nil, err
+			}
+			return c.cli.PostUploadAttachmentWithBody(ctx,
+				&clientv1.PostUploadAttachmentParams{XRequestID: requestID}, contentType, body)
+		})
+}
+
+// GetAuditEvents возвращает страницу журнала аудита. Доступно только менеджерам.
+func (c *Client) GetAuditEvents(ctx context.Context, req GetAuditEventsRequest) (AuditEventsPage, error) {
+	return call[AuditEventsPage](ctx, c, "get audit events",
+		func(ctx context.Context, requestID uuid.UUID) (*http.Response, error) {
+			return c.cli.PostGetAuditEvents(ctx, &clientv1.PostGetAuditEventsParams{XRequestID: requestID}, req)
+		})
+}
+
+func multipartFile(fileName string, data []byte) (io.Reader, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	part, err := w.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, "", fmt.Errorf("create form file: %v", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, "", fmt.Errorf("write form file: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", fmt.Errorf("close multipart writer: %v", err)
+	}
+	return &buf, w.FormDataContentType(), nil
+}
@@ -0,0 +1,268 @@
+// Package chatclient - клиент API чата для внутренних инструментов и e2e-тестов.
+// Оборачивает сгенерированный clientv1.Client: подставляет bearer-токен и X-Request-ID,
+// повторяет запросы с тем же X-Request-ID и разбирает ответы {"data"}/{"error"}.
+// Операции, меняющие данные, повторяются только после 429: сервер не распознает повторы.
+package chatclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	clientv1 "github.com/FischukSergey/chat-service/internal/server-client/v1"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+// TokenSource возвращает bearer-токен. Вызывается перед каждой попыткой запроса,
+// поэтому может обновлять истекший токен.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// StaticToken - неизменный токен.
+type StaticToken string
+
+func (t StaticToken) Token(context.Context) (string, error) {
+	return string(t), nil
+}
+
+//go:generate options-gen -out-filename=client_options.gen.go -from-struct=Options -defaults-from=var
+type Options struct {
+	baseURL string `option:"mandatory" validate:"required,url"`
+	// token - источник bearer-токена. Если не задан, запросы отправляются без токена.
+	token      TokenSource
+	httpClient *http.Client `validate:"required"`
+	// maxRetries - сколько раз повторять запрос после сетевой ошибки, 429 или 502/503/504.
+	// Запросы, меняющие данные, повторяются только после 429.
+	maxRetries int `validate:"min=0"`
+	// retryBackoff - пауза перед первым повтором, каждая следующая вдвое больше.
+	// Если сервер вернул Retry-After, ждем не меньше указанного.
+	retryBackoff time.Duration `validate:"min=0"`
+}
+
+var defaultOptions = Options{
+	httpClient:   http.DefaultClient,
+	maxRetries:   2,
+	retryBackoff: 200 * time.Millisecond,
+}
+
+type Client struct {
+	cli          *clientv1.Client
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+func New(opts Options) (*Client, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("validate options: %v", err)
+	}
+
+	clientOpts := []clientv1.ClientOption{clientv1.WithHTTPClient(opts.httpClient)}
+	if opts.token != nil {
+		clientOpts = append(clientOpts, clientv1.WithRequestEditorFn(bearerToken(opts.token)))
+	}
+
+	cli, err := clientv1.NewClient(opts.baseURL, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("create client: %v", err)
+	}
+
+	return &Client{
+		cli:          cli,
+		maxRetries:   opts.maxRetries,
+		retryBackoff: opts.retryBackoff,
+	}, nil
+}
+
+// errToken - ошибка источника токена. Запрос с ней не отправлялся и не повторяется.
+var errToken = errors.New("get token")
+
+func bearerToken(ts TokenSource) clientv1.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		token, err := ts.Token(ctx)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errToken, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+type envelope[T any] struct {
+	Data T `json:"data"`
+}
+
+// call отправляет запрос операции, не меняющей данные, и повторяет его при временных ошибках
+// с тем же X-Request-ID. Тело успешного ответа разбирается в T.
+func call[T any](
+	ctx context.Context,
+	c *Client,
+	operation string,
+	send func(ctx context.Context, requestID uuid.UUID) (*http.Response, error),
+) (T, error) {
+	return do[T](ctx, c, operation, true, send)
+}
+
+// callMutating отправляет запрос операции, меняющей данные. Сервер не распознает повторы,
+// поэтому после сетевой ошибки или 502/503/504 нельзя понять, выполнен ли запрос, и он не повторяется.
+// Повторяется только отказ 429: ограничение частоты проверяется до обработки запроса.
+func callMutating[T any](
+	ctx context.Context,
+	c *Client,
+	operation string,
+	send func(ctx context.Context, requestID uuid.UUID) (*http.Response, error),
+) (T, error) {
+	return do[T](ctx, c, operation, false, send)
+}
+
+func do[T any](
+	ctx context.Context,
+	c *Client,
+	operation string,
+	idempotent bool,
+	send func(ctx context.Context, requestID uuid.UUID) (*http.Response, error),
+) (T, error) {
+	var zero T
+	requestID := types.NewRequestID()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := send(ctx, uuid.UUID(requestID))
+		if err != nil {
+			if !idempotent || errors.Is(err, errToken) || ctx.Err() != nil || attempt >= c.maxRetries {
+				return zero, fmt.Errorf("%s: %w", operation, err)
+			}
+			if err := c.wait(ctx, attempt, 0); err != nil {
+				return zero, fmt.Errorf("%s: %w", operation, err)
+			}
+			continue
+		}
+
+		if resp.StatusCode == http.StatusOK {
+			var env envelope[T]
+			err := json.NewDecoder(resp.Body).Decode(&env)
+			closeBody(resp)
+			if err != nil {
+				return zero, fmt.Errorf("%s: decode response: %v", operation, err)
+			}
+			return env.Data, nil
+		}
+
+		apiErr := decodeError(resp, requestID)
+		closeBody(resp)
+		if !retryable(resp.StatusCode, idempotent) || attempt >= c.maxRetries {
+			return zero, fmt.Errorf("%s: %w", operation, apiErr)
+		}
+		if err := c.wait(ctx, attempt, retryAfter(resp)); err != nil {
+			return zero, fmt.Errorf("%s: %w", operation, err)
+		}
+	}
+}
+
+func (c *Client) wait(ctx context.Context, attempt int, atLeast time.Duration) error {
+	d := max(c.retryBackoff<<attempt, atLeast)
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func retryable(status int, idempotent bool) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func closeBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+// errorBodyLimit ограничивает, сколько байт тела ответа с ошибкой читается.
+const errorBodyLimit = 64 << 10
+
+// decodeError разбирает тело ответа с ошибкой. Кроме {"error": {"code", "message"}} из спецификации
+// сервер может вернуть {"error": "..."} после паники или {"message": "..."} из обработчика ошибок Echo.
+func decodeError(resp *http.Response, requestID types.RequestID) *Error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		Code:       resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+		RequestID:  requestID.String(),
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+	if err != nil {
+		return apiErr
+	}
+
+	var body struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return apiErr
+	}
+
+	var e clientv1.Error
+	var msg string
+	switch {
+	case json.Unmarshal(body.Error, &e) == nil && e.Message != "":
+		apiErr.Message = e.Message
+		if e.Code != 0 {
+			apiErr.Code = e.Code
+		}
+	case json.Unmarshal(body.Error, &msg) == nil && msg != "":
+		apiErr.Message = msg
+	case body.Message != "":
+		apiErr.Message = body.Message
+	}
+	return apiErr
+}
+
+// Error - ошибка, которую вернул сервер.
+type Error struct {
+	// StatusCode - HTTP-статус ответа.
+	StatusCode int
+	// Code - код ошибки из тела ответа, если сервер его не вернул - HTTP-статус.
+	Code    int
+	Message string
+	// RequestID - X-Request-ID запроса, по нему ошибку можно найти в логах сервера.
+	RequestID string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s (request id %s)", e.Code, e.Message, e.RequestID)
+}
+
+// IsStatus сообщает, вернул ли сервер ошибку с HTTP-статусом status.
+func IsStatus(err error, status int) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
@@ -0,0 +1,107 @@
+// Code generated by options-gen. DO NOT EDIT.
+package chatclient
+
+import (
+	fmt461e464ebed9 "fmt"
+	"net/http"
+	"time"
+
+	errors461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/errors"
+	validator461e464ebed9 "github.com/kazhuravlev/options-gen/pkg/validator"
+)
+
+type OptOptionsSetter func(o *Options)
+
+func NewOptions(
+	baseURL string,
+	options ...OptOptionsSetter,
+) Options {
+	o := Options{}
+
+	// Setting defaults from variable
+	o.baseURL = defaultOptions.baseURL
+
+	o.token = defaultOptions.token
+
+	o.httpClient = defaultOptions.httpClient
+
+	o.maxRetries = defaultOptions.maxRetries
+
+	o.retryBackoff = defaultOptions.retryBackoff
+
+	o.baseURL = baseURL
+
+	for _, opt := range options {
+		opt(&o)
+	}
+	return o
+}
+
+// token - источник bearer-токена. Если не задан, запросы отправляются без токена.
+func WithToken(opt TokenSource) OptOptionsSetter {
+	return func(o *Options) {
+		o.token = opt
+
+	}
+}
+
+func WithHttpClient(opt *http.Client) OptOptionsSetter {
+	return func(o *Options) {
+		o.httpClient = opt
+
+	}
+}
+
+// maxRetries - сколько раз повторять запрос после сетевой ошибки, 429 или 502/503/504.
+func WithMaxRetries(opt int) OptOptionsSetter {
+	return func(o *Options) {
+		o.maxRetries = opt
+
+	}
+}
+
+// retryBackoff - пауза перед первым повтором, каждая следующая вдвое больше.
+// Если сервер вернул Retry-After, ждем не меньше указанного.
+func WithRetryBackoff(opt time.Duration) OptOptionsSetter {
+	return func(o *Options) {
+		o.retryBackoff = opt
+
+	}
+}
+
+func (o *Options) Validate() error {
+	errs := new(errors461e464ebed9.ValidationErrors)
+	errs.Add(errors461e464ebed9.NewValidationError("baseURL", _validate_Options_baseURL(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("httpClient", _validate_Options_httpClient(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("maxRetries", _validate_Options_maxRetries(o)))
+	errs.Add(errors461e464ebed9.NewValidationError("retryBackoff", _validate_Options_retryBackoff(o)))
+	return errs.AsError()
+}
+
+func _validate_Options_baseURL(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.baseURL, "required,url"); err != nil {
+		return fmt461e464ebed9.Errorf("field `baseURL` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_httpClient(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.httpClient, "required"); err != nil {
+		return fmt461e464ebed9.Errorf("field `httpClient` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_maxRetries(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.maxRetries, "min=0"); err != nil {
+		return fmt461e464ebed9.Errorf("field `maxRetries` did not pass the test: %w", err)
+	}
+	return nil
+}
+
+func _validate_Options_retryBackoff(o *Options) error {
+	if err := validator461e464ebed9.GetValidatorFor(o).Var(o.retryBackoff, "min=0"); err != nil {
+		return fmt461e464ebed9.Errorf("field `retryBackoff` did not pass the test: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package chatclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/FischukSergey/chat-service/pkg/chatclient"
+)
+
+const token = "test-token"
+
+// recorder отвечает заранее заданными ответами и запоминает запросы.
+type recorder struct {
+	mu         sync.Mutex
+	requestIDs []string
+	responses  []response
+}
+
+type response struct {
+	status int
+	body   string
+	header map[string]string
+}
+
+func (r *recorder) handler(t *testing.T, check func(req *http.Request)) http.HandlerFunc {
+	t.Helper()
+
+	return func(w http.ResponseWriter, req *http.Request) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		assert.Equal(t, "Bearer "+token, req.Header.Get("Authorization"))
+		if check != nil {
+			check(req)
+		}
+		r.requestIDs = append(r.requestIDs, req.Header.Get("X-Request-ID"))
+
+		resp := r.responses[min(len(r.requestIDs), len(r.responses))-1]
+		for k, v := range resp.header {
+			w.Header().Set(k, v)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.status)
+		_, _ = io.WriteString(w, resp.body)
+	}
+}
+
+func newClient(t *testing.T, h http.Handler, opts ...chatclient.OptOptionsSetter) *chatclient.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	c, err := chatclient.New(chatclient.NewOptions(srv.URL, append([]chatclient.OptOptionsSetter{
+		chatclient.WithToken(chatclient.StaticToken(token)),
+		chatclient.WithRetryBackoff(time.Millisecond),
+	}, opts...)...))
+	require.NoError(t, err)
+	return c
+}
+
+func TestClient_GetHistory(t *testing.T) {
+	rec := &recorder{responses: []response{
+		{status: http.StatusOK, body: `{"data":{"messages":[{"id":"2b9b9a82-0c8e-4f43-8a6d-0e0f3bd0bb3c",` +
+			`"authorId":"0e8d41e7-1d1e-4a0b-a7a2-bbd5e9fe35cb","body":"hello","createdAt":"2024-01-02T03:04:05Z"}],` +
+			`"nextCursor":"next"}}`},
+	}}
+	c := newClient(t, rec.handler(t, func(req *http.Request) {
+		assert.Equal(t, "/v1/getHistory", req.URL.Path)
+
+		var body chatclient.GetHistoryRequest
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, "cursor", *body.Cursor)
+	}))
+
+	cursor := "cursor"
+	page, err := c.GetHistory(context.Background(), chatclient.GetHistoryRequest{Cursor: &cursor})
+	require.NoError(t, err)
+
+	require.Len(t, page.Messages, 1)
+	assert.Equal(t, "hello", page.Messages[0].Body)
+	assert.Equal(t, "next", *page.NextCursor)
+	require.Len(t, rec.requestIDs, 1)
+	assert.NotEmpty(t, rec.requestIDs[0])
+}
+
+func TestClient_RetriesWithSameRequestID(t *testing.T) {
+	rec := &recorder{responses: []response{
+		{status: http.StatusServiceUnavailable, body: `{"message":"Service Unavailable"}`},
+		{
+			status: http.StatusTooManyRequests,
+			body:   `{"error":{"code":429,"message":"too many requests"}}`,
+			header: map[string]string{"Retry-After": "0"},
+		},
+		{status: http.StatusOK, body: `{"data":{}}`},
+	}}
+	c := newClient(t, rec.handler(t, nil))
+
+	_, err := c.ManagerGetChats(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, rec.requestIDs, 3)
+	assert.Equal(t, rec.requestIDs[0], rec.requestIDs[1])
+	assert.Equal(t, rec.requestIDs[0], rec.requestIDs[2])
+}
+
+func TestClient_RetriesExhausted(t *testing.T) {
+	rec := &recorder{responses: []response{
+		{status: http.StatusBadGateway, body: `bad gateway`},
+	}}
+	c := newClient(t, rec.handler(t, nil))
+
+	_, err := c.ManagerGetChats(context.Background())
+	require.Error(t, err)
+	assert.True(t, chatclient.IsStatus(err, http.StatusBadGateway))
+	assert.Len(t, rec.requestIDs, 3)
+}
+
+func TestClient_MutatingNotRetried(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			rec := &recorder{responses: []response{{status: status}}}
+			c := newClient(t, rec.handler(t, nil))
+
+			// Сервер мог сохранить сообщение до ошибки прокси, повтор создал бы дубль.
+			_, err := c.SendMessage(context.Background(), chatclient.SendMessageRequest{Body: "hello"})
+			require.Error(t, err)
+			assert.True(t, chatclient.IsStatus(err, status))
+			assert.Len(t, rec.requestIDs, 1)
+		})
+	}
+}
+
+func TestClient_TokenError(t *testing.T) {
+	rec := &recorder{}
+	tokenErr := errors.New("keycloak is down")
+	var calls int
+	c := newClient(t, rec.handler(t, nil), chatclient.WithToken(tokenSourceFunc(func(context.Context) (string, error) {
+		calls++
+		return "", tokenErr
+	})))
+
+	_, err := c.ManagerGetChats(context.Background())
+	require.ErrorContains(t, err, tokenErr.Error())
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, rec.requestIDs)
+}
+
+type tokenSourceFunc func(ctx context.Context) (string, error)
+
+func (f tokenSourceFunc) Token(ctx context.Context) (string, error) { return f(ctx) }
+
+func TestClient_UploadAttachment(t *testing.T) {
+	rec := &recorder{responses: []response{
+		// Отказ ограничителя частоты повторяется и для загрузки: файл отправляется заново.
+		{status: http.StatusTooManyRequests, header: map[string]string{"Retry-After": "0"}},
+		{status: http.StatusOK, body: `{"data":{"id":"2b9b9a82-0c8e-4f43-8a6d-0e0f3bd0bb3c","fileName":"a.txt",` +
+			`"contentType":"text/plain","size":5,"url":"http://files/a.txt","urlExpiresAt":"2024-01-02T03:04:05Z"}}`},
+	}}
+	c := newClient(t, rec.handler(t, func(req *http.Request) {
+		f, fh, err := req.FormFile("file")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer f.Close()
+
+		content, err := io.ReadAll(f)
+		assert.NoError(t, err)
+		assert.Equal(t, "a.txt", fh.Filename)
+		assert.Equal(t, "hello", string(content))
+	}))
+
+	a, err := c.UploadAttachment(context.Background(), "a.txt", strings.NewReader("hello"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "a.txt", a.FileName)
+	assert.Equal(t, int64(5), a.Size)
+	assert.Len(t, rec.requestIDs, 2)
+}
+
+func TestClient_Errors(t *testing.T) {
+	cases := []struct {
+		name   string
+		resp   response
+		expErr chatclient.Error
+	}{
+		{
+			name: "error envelope",
+			resp: response{status: http.StatusTooManyRequests, body: `{"error":{"code":4290,"message":"slow down"}}`},
+			expErr: chatclient.Error{
+				StatusCode: http.StatusTooManyRequests, Code: 4290, Message: "slow down",
+			},
+		},
+		{
+			name: "error message",
+			resp: response{status: http.StatusInternalServerError, body: `{"error":"Internal Server Error"}`},
+			expErr: chatclient.Error{
+				StatusCode: http.StatusInternalServerError, Code: http.StatusInternalServerError, Message: "Internal Server Error",
+			},
+		},
+		{
+			name: "echo error",
+			resp: response{status: http.StatusBadRequest, body: `{"message":"invalid request format"}`},
+			expErr: chatclient.Error{
+				StatusCode: http.StatusBadRequest, Code: http.StatusBadRequest, Message: "invalid request format",
+			},
+		},
+		{
+			name: "not json",
+			resp: response{status: http.StatusNotFound, body: `not found`},
+			expErr: chatclient.Error{
+				StatusCode: http.StatusNotFound, Code: http.StatusNotFound, Message: "Not Found",
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{responses: []response{tt.resp}}
+			// 429 повторяется, поэтому отключаем повторы.
+			c := newClient(t, rec.handler(t, nil), chatclient.WithMaxRetries(0))
+
+			_, err := c.GetAttachment(context.Background(), chatclient.GetAttachmentRequest{})
+			require.Error(t, err)
+
+			var apiErr *chatclient.Error
+			require.True(t, errors.As(err, &apiErr))
+			require.Len(t, rec.requestIDs, 1)
+			tt.expErr.RequestID = rec.requestIDs[0]
+			assert.Equal(t, tt.expErr, *apiErr)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package e2e_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	chatapp "github.com/FischukSergey/chat-service/internal/app"
+	keycloakclient "github.com/FischukSergey/chat-service/internal/clients/keycloak"
+	"github.com/FischukSergey/chat-service/internal/config"
+	"github.com/FischukSergey/chat-service/internal/logger"
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/enttest"
+	"github.com/FischukSergey/chat-service/internal/types"
+)
+
+const (
+	// Ресурсы и роли совпадают с теми, что проверяет клиентский сервер.
+	clientResource  = "chat-ui-client"
+	clientRole      = "support-chat-client"
+	managerResource = "chat-ui-manager"
+	managerRole     = "support-chat-manager"
+
+	// Политика getAttachment: после attachmentBurst запросов подряд следующий получает 429.
+	attachmentBurst = 3
+	// attachmentMaxSize - максимальный размер вложения.
+	attachmentMaxSize = 1 << 20
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// app - сервис, собранный internal/app так же, как в cmd/chat-service, но с SQLite в памяти,
+// локальным хранилищем вложений во временном каталоге и фейковым Keycloak.
+type app struct {
+	clientURL string
+	debugURL  string
+
+	store        *store.Client
+	introspector *fakeIntrospector
+
+	stopApp context.CancelFunc
+	runErr  chan error
+	closeFn func() error
+}
+
+func startApp(ctx context.Context, t *testing.T) *app {
+	t.Helper()
+
+	require.NoError(t, logger.Init(logger.NewOptions("error")))
+
+	clientAddr, debugAddr := freeAddr(t), freeAddr(t)
+	a := &app{
+		clientURL:    "http://" + clientAddr,
+		debugURL:     "http://" + debugAddr,
+		introspector: newFakeIntrospector(),
+		runErr:       make(chan error, 1),
+	}
+
+	cfg, err := config.ParseAndValidate(filepath.Join("..", "..", "configs", "config.example.toml"))
+	require.NoError(t, err)
+	cfg.Log.Level = "error"
+	cfg.Servers.Client.Addr = clientAddr
+	cfg.Servers.Debug.Addr = debugAddr
+	cfg.Servers.Client.RateLimit = config.RateLimitConfig{
+		Enabled: true,
+		PerIP:   config.IPRateLimitPolicyConfig{Rate: 1000, Burst: 1000},
+		Default: config.RateLimitPolicyConfig{Rate: 100, Burst: 100},
+		Operations: map[string]config.RateLimitPolicyConfig{
+			"getAttachment": {Rate: 0.01, Burst: attachmentBurst},
+		},
+	}
+	cfg.Services.Attachments.MaxSize = attachmentMaxSize
+	cfg.Services.Attachments.AllowedMIMETypes = []string{"text/plain"}
+	cfg.Services.Attachments.Local.Dir = t.TempDir()
+	cfg.Services.Attachments.Local.BaseURL = a.clientURL
+	cfg.Shutdown.DrainDelay = 0
+	require.NoError(t, config.Validate(cfg))
+
+	// Имя базы уникально: при -count>1 общий кэш SQLite иначе сохранил бы данные прошлого запуска.
+	a.store = enttest.Open(t, dialect.SQLite, "file:e2e-"+uuid.NewString()+"?mode=memory&cache=shared&_fk=1")
+
+	svc, err := chatapp.New(ctx, chatapp.NewOptions(cfg,
+		chatapp.WithStore(a.store),
+		chatapp.WithStoreDialect(dialect.SQLite),
+		chatapp.WithIntrospector(a.introspector),
+	))
+	require.NoError(t, err)
+	a.closeFn = svc.Close
+
+	runCtx, stopApp := context.WithCancel(ctx)
+	a.stopApp = stopApp
+	go func() { a.runErr <- svc.Run(runCtx) }()
+
+	require.Eventually(t, func() bool {
+		resp, err := http.Get(a.debugURL + "/health/ready") //nolint:noctx // readiness polling
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+
+		conn, err := net.Dial("tcp", clientAddr)
+		if err != nil {
+			return false
+		}
+		_ = conn.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond, "servers are not ready")
+
+	return a
+}
+
+// stop останавливает сервис так же, как сигнал в cmd/chat-service.
+func (a *app) stop(t *testing.T) {
+	t.Helper()
+
+	a.stopApp()
+	select {
+	case err := <-a.runErr:
+		assert.NoError(t, err)
+	case <-time.After(shutdownTimeout):
+		t.Error("app is not stopped")
+	}
+	assert.NoError(t, a.closeFn())
+	assert.NoError(t, a.store.Close())
+}
+
+// freeAddr возвращает адрес со свободным портом. Порт освобождается до запуска сервера,
+// поэтому его теоретически может занять кто-то еще, но для тестов этого достаточно.
+func freeAddr(t require.TestingT) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	return ln.Addr().String()
+}
+
+// fakeIntrospector заменяет Keycloak: активны только выданные и не отозванные токены.
+type fakeIntrospector struct {
+	mu     sync.Mutex
+	active map[string]bool
+}
+
+func newFakeIntrospector() *fakeIntrospector {
+	return &fakeIntrospector{active: make(map[string]bool)}
+}
+
+func (i *fakeIntrospector) IntrospectToken(_ context.Context, token string) (*keycloakclient.IntrospectTokenResult, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return &keycloakclient.IntrospectTokenResult{Active: i.active[token]}, nil
+}
+
+// issue выдает токен пользователю userID с ролью role ресурса resource.
+// Подпись сервис не проверяет: это делает Keycloak при интроспекции.
+func (i *fakeIntrospector) issue(userID types.UserID, resource, role string) (string, error) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"jti": uuid.NewString(),
+		"sub": userID.String(),
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"resource_access": map[string]any{
+			resource: map[string]any{"roles": []string{role}},
+		},
+	}).SignedString([]byte("e2e"))
+	if err != nil {
+		return "", fmt.Errorf("sign token: %v", err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.active[token] = true
+	return token, nil
+}
+
+func (i *fakeIntrospector) revoke(token string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.active, token)
+}
@@ -0,0 +1,537 @@
+package e2e_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/FischukSergey/chat-service/internal/types"
+	websocketstream "github.com/FischukSergey/chat-service/internal/websocket-stream"
+	"github.com/FischukSergey/chat-service/pkg/chatclient"
+)
+
+func (s *E2ESuite) TestTyping() {
+	managerID, managerToken, manager := s.newUser(managerResource, managerRole)
+	clientID, clientToken, _ := s.newUser(clientResource, clientRole)
+	chatID := s.createChat(clientID, managerID)
+
+	managerWS := s.dialRealtime("/manager/ws", managerToken)
+	clientWS := s.dialRealtime("/ws", clientToken)
+	s.Require().Eventually(func() bool {
+		conns := s.realtimeConnections()
+		return conns[managerID] == 1 && conns[clientID] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	s.Run("client typing is delivered to manager", func() {
+		s.Require().NoError(clientWS.WriteJSON(map[string]any{"type": "typing"}))
+
+		e := s.readFrame(managerWS)
+		s.Equal("typing", e["type"])
+		s.Equal(chatID.String(), e["chatId"])
+		s.Equal(clientID.String(), e["userId"])
+	})
+
+	s.Run("manager typing is delivered to client", func() {
+		s.Require().NoError(managerWS.WriteJSON(map[string]any{"type": "typing", "chatId": chatID}))
+
+		e := s.readFrame(clientWS)
+		s.Equal("typing", e["type"])
+		s.Equal(chatID.String(), e["chatId"])
+		s.Equal(managerID.String(), e["userId"])
+	})
+
+	s.Run("not assigned manager is rejected", func() {
+		_, otherToken, _ := s.newUser(managerResource, managerRole)
+		otherWS := s.dialRealtime("/manager/ws", otherToken)
+
+		s.Require().NoError(otherWS.WriteJSON(map[string]any{"type": "typing", "chatId": chatID}))
+
+		e := s.readFrame(otherWS)
+		s.Equal("error", e["type"])
+		s.Contains(e["message"], "signal rejected")
+	})
+
+	s.Run("manager chats contain client presence", func() {
+		chats, err := manager.ManagerGetChats(s.Ctx)
+		s.Require().NoError(err)
+		s.Require().Len(chats.Chats, 1)
+
+		c := chats.Chats[0]
+		s.Equal(chatID, c.ChatId)
+		s.Equal(clientID, c.ClientId)
+		s.Equal(chatclient.ManagerChatProblemStatus("in_progress"), c.ProblemStatus)
+		s.True(c.ClientPresence.Online)
+		s.NotNil(c.ClientPresence.LastSeenAt)
+	})
+
+	// Отладочный сервер видит подключения и присутствие клиента.
+	var realtime []struct {
+		UserID      types.UserID `json:"user_id"`
+		Connections int          `json:"connections"`
+		Online      bool         `json:"online"`
+	}
+	s.getDebugJSON("/debug/realtime", &realtime)
+
+	seen := make(map[types.UserID]int)
+	for _, c := range realtime {
+		seen[c.UserID] = c.Connections
+		if c.UserID == clientID {
+			s.True(c.Online)
+		}
+	}
+	s.Equal(1, seen[managerID])
+	s.Equal(1, seen[clientID])
+}
+
+func (s *E2ESuite) TestSendMessage() {
+	managerID, managerToken, manager := s.newUser(managerResource, managerRole)
+	clientID, _, client := s.newUser(clientResource, clientRole)
+
+	// Чат создается с первым сообщением клиента.
+	first, err := client.SendMessage(s.Ctx, chatclient.SendMessageRequest{Body: " Здравствуйте!​ "})
+	s.Require().NoError(err)
+	s.Equal("Здравствуйте!", first.Body)
+	s.Equal(clientID, first.AuthorId)
+
+	var chatID types.ChatID
+	s.Run("manager is not assigned yet", func() {
+		c, err := s.app.store.Message.Get(s.Ctx, first.Id)
+		s.Require().NoError(err)
+		chatID = c.ChatID
+
+		_, err = manager.ManagerSendMessage(s.Ctx, chatclient.ManagerSendMessageRequest{ChatId: chatID, Body: "Добрый день"})
+		s.True(chatclient.IsStatus(err, http.StatusForbidden), err)
+		_, err = manager.ManagerSendMessage(s.Ctx, chatclient.ManagerSendMessageRequest{ChatId: types.NewChatID(), Body: "Добрый день"})
+		s.True(chatclient.IsStatus(err, http.StatusNotFound), err)
+	})
+
+	s.app.store.Problem.Create().SetChatID(chatID).SetManagerID(managerID).SaveX(s.Ctx)
+	managerWS := s.dialRealtime("/manager/ws", managerToken)
+	s.Require().Eventually(func() bool {
+		return s.realtimeConnections()[managerID] == 1
+	}, time.Second, 10*time.Millisecond)
+
+	s.Run("client message is delivered to manager", func() {
+		msg, err := client.SendMessage(s.Ctx, chatclient.SendMessageRequest{Body: "Карта 4111 1111 1111 1111 заблокирована"})
+		s.Require().NoError(err)
+		s.True(msg.IsRedacted)
+
+		e := s.readFrame(managerWS)
+		s.Equal("message", e["type"])
+		s.Equal(chatID.String(), e["chatId"])
+		m, ok := e["message"].(map[string]any)
+		s.Require().True(ok)
+		s.Equal(msg.Id.String(), m["id"])
+		s.Equal(msg.Body, m["body"])
+	})
+
+	s.Run("manager replies", func() {
+		reply, err := manager.ManagerSendMessage(s.Ctx, chatclient.ManagerSendMessageRequest{ChatId: chatID, Body: "Разберёмся"})
+		s.Require().NoError(err)
+		s.Equal(managerID, reply.AuthorId)
+
+		page, err := client.GetHistory(s.Ctx, chatclient.GetHistoryRequest{})
+		s.Require().NoError(err)
+		s.Require().Len(page.Messages, 3)
+		s.Equal(reply.Id, page.Messages[0].Id)
+		s.Equal(first.Id, page.Messages[2].Id)
+	})
+
+	s.Run("invalid body", func() {
+		for _, body := range []string{"", "  ​ ", "<b></b>", strings.Repeat("a", 3001)} {
+			_, err := client.SendMessage(s.Ctx, chatclient.SendMessageRequest{Body: body})
+			s.True(chatclient.IsStatus(err, http.StatusBadRequest), "%q: %v", body, err)
+		}
+	})
+}
+
+func (s *E2ESuite) TestAttachment() {
+	clientID, clientToken, client := s.newUser(clientResource, clientRole)
+
+	uploaded, err := client.UploadAttachment(s.Ctx, "note.txt", strings.NewReader("hello from e2e"))
+	s.Require().NoError(err)
+	s.Equal("note.txt", uploaded.FileName)
+	s.Equal(int64(len("hello from e2e")), uploaded.Size)
+
+	a, err := client.GetAttachment(s.Ctx, chatclient.GetAttachmentRequest{Id: uploaded.Id})
+	s.Require().NoError(err)
+
+	req, err := http.NewRequestWithContext(s.Ctx, http.MethodGet, a.Url, nil)
+	s.Require().NoError(err)
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Equal(http.StatusOK, resp.StatusCode)
+	content, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	s.Equal("hello from e2e", string(content))
+
+	// Чужое вложение не отдается.
+	_, _, other := s.newUser(clientResource, clientRole)
+	_, err = other.GetAttachment(s.Ctx, chatclient.GetAttachmentRequest{Id: uploaded.Id})
+	s.True(chatclient.IsStatus(err, http.StatusNotFound), err)
+
+	s.Run("sent attachment is available to manager", func() {
+		managerID, _, manager := s.newUser(managerResource, managerRole)
+		_, err := manager.ManagerGetAttachment(s.Ctx, chatclient.GetAttachmentRequest{Id: uploaded.Id})
+		s.True(chatclient.IsStatus(err, http.StatusNotFound), err)
+
+		s.createChat(clientID, managerID)
+		ids := []types.AttachmentID{uploaded.Id}
+		msg, err := client.SendMessage(s.Ctx, chatclient.SendMessageRequest{Body: "Файл", AttachmentIds: &ids})
+		s.Require().NoError(err)
+		s.Equal(ids, msg.AttachmentIds)
+
+		_, err = manager.ManagerGetAttachment(s.Ctx, chatclient.GetAttachmentRequest{Id: uploaded.Id})
+		s.Require().NoError(err)
+
+		// Отправленное вложение нельзя прикрепить еще раз.
+		_, err = client.SendMessage(s.Ctx, chatclient.SendMessageRequest{Body: "Еще раз", AttachmentIds: &ids})
+		s.True(chatclient.IsStatus(err, http.StatusBadRequest), err)
+	})
+
+	s.Run("form fields before the file are skipped", func() {
+		status, body := s.uploadMultipart(clientToken, func(w *multipart.Writer) error {
+			if err := w.WriteField("comment", "скриншот"); err != nil {
+				return err
+			}
+			part, err := w.CreateFormFile("file", "second.txt")
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(part, "second file")
+			return err
+		})
+		s.Equal(http.StatusOK, status, body)
+		s.Contains(body, `"fileName":"second.txt"`)
+	})
+
+	s.Run("too large file is rejected while reading", func() {
+		status, body := s.uploadMultipart(clientToken, func(w *multipart.Writer) error {
+			part, err := w.CreateFormFile("file", "large.txt")
+			if err != nil {
+				return err
+			}
+			_, err = io.WriteString(part, strings.Repeat("a", attachmentMaxSize+1))
+			return err
+		})
+		s.Equal(http.StatusRequestEntityTooLarge, status, body)
+	})
+}
+
+// uploadMultipart отправляет форму загрузки вложения потоком, без Content-Length,
+// и возвращает код и тело ответа.
+func (s *E2ESuite) uploadMultipart(token string, write func(w *multipart.Writer) error) (int, string) {
+	s.T().Helper()
+
+	pr, pw := io.Pipe()
+	w := multipart.NewWriter(pw)
+	go func() {
+		err := write(w)
+		if err == nil {
+			err = w.Close()
+		}
+		_ = pw.CloseWithError(err)
+	}()
+
+	req, err := http.NewRequestWithContext(s.Ctx, http.MethodPost, s.app.clientURL+"/v1/uploadAttachment", pr)
+	s.Require().NoError(err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	req.Header.Set("X-Request-ID", types.NewRequestID().String())
+
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	s.Require().NoError(err)
+	return resp.StatusCode, string(body)
+}
+
+func (s *E2ESuite) TestHistoryPagination() {
+	managerID, _, manager := s.newUser(managerResource, managerRole)
+	clientID, _, client := s.newUser(clientResource, clientRole)
+	chatID := s.createChat(clientID, managerID)
+
+	start := time.Now().Add(-time.Hour)
+	var clientIDs, managerIDs []types.MessageID
+	for i := range 5 {
+		m := s.addMessage(chatID, clientID, fmt.Sprintf("сообщение %d", i), start.Add(time.Duration(i)*time.Minute))
+		clientIDs = append([]types.MessageID{m.ID}, clientIDs...) // новые первыми
+	}
+	managerIDs = append(managerIDs, clientIDs...)
+
+	// Служебное сообщение видно только менеджеру.
+	hidden := s.app.store.Message.Create().
+		SetChatID(chatID).
+		SetAuthorID(managerID).
+		SetBody("клиент уже обращался").
+		SetIsVisibleForClient(false).
+		SetIsVisibleForManager(true).
+		SetCreatedAt(start.Add(10 * time.Minute)).
+		SaveX(s.Ctx)
+	managerIDs = append([]types.MessageID{hidden.ID}, managerIDs...)
+
+	// Сообщения другого чата в историю не попадают.
+	otherClientID := types.NewUserID()
+	s.addMessage(s.createChat(otherClientID, managerID), otherClientID, "чужое сообщение", start)
+
+	pageSize := 2
+	collect := func(getPage func(cursor *string) (chatclient.MessagesPage, error)) (ids []types.MessageID, pages int) {
+		var cursor *string
+		for {
+			page, err := getPage(cursor)
+			s.Require().NoError(err)
+			pages++
+
+			s.LessOrEqual(len(page.Messages), pageSize)
+			for _, m := range page.Messages {
+				ids = append(ids, m.Id)
+			}
+			if page.NextCursor == nil {
+				return ids, pages
+			}
+			cursor = page.NextCursor
+			s.Require().Less(pages, 10, "pagination does not end")
+		}
+	}
+
+	s.Run("client", func() {
+		ids, pages := collect(func(cursor *string) (chatclient.MessagesPage, error) {
+			return client.GetHistory(s.Ctx, chatclient.GetHistoryRequest{PageSize: &pageSize, Cursor: cursor})
+		})
+		s.Equal(3, pages)
+		s.Equal(clientIDs, ids)
+	})
+
+	s.Run("manager", func() {
+		ids, pages := collect(func(cursor *string) (chatclient.MessagesPage, error) {
+			return manager.ManagerGetChatHistory(s.Ctx, chatclient.ManagerGetChatHistoryRequest{
+				ChatId:   chatID,
+				PageSize: &pageSize,
+				Cursor:   cursor,
+			})
+		})
+		s.Equal(3, pages)
+		s.Equal(managerIDs, ids)
+	})
+
+	s.Run("invalid cursor", func() {
+		invalid := "invalid"
+		_, err := client.GetHistory(s.Ctx, chatclient.GetHistoryRequest{Cursor: &invalid})
+		s.True(chatclient.IsStatus(err, http.StatusBadRequest), err)
+	})
+}
+
+func (s *E2ESuite) TestSearchPagination() {
+	managerID, _, manager := s.newUser(managerResource, managerRole)
+	clientID, _, client := s.newUser(clientResource, clientRole)
+	chatID := s.createChat(clientID, managerID)
+
+	start := time.Now().Add(-time.Hour)
+	var expIDs []types.MessageID
+	for i := range 5 {
+		m := s.addMessage(chatID, clientID, "не работает карта", start.Add(time.Duration(i)*time.Minute))
+		expIDs = append([]types.MessageID{m.ID}, expIDs...) // новые первыми
+	}
+	s.addMessage(chatID, managerID, "другая тема", start)
+
+	// Сообщения другого клиента не находятся.
+	otherClientID := types.NewUserID()
+	otherChatID := s.createChat(otherClientID, managerID)
+	s.addMessage(otherChatID, otherClientID, "не работает карта", start)
+
+	pageSize := 2
+	var (
+		gotIDs []types.MessageID
+		pages  int
+		cursor *string
+	)
+	for {
+		page, err := client.SearchMessages(s.Ctx, chatclient.SearchMessagesRequest{
+			Query:    "карта",
+			PageSize: &pageSize,
+			Cursor:   cursor,
+		})
+		s.Require().NoError(err)
+		pages++
+
+		s.LessOrEqual(len(page.Messages), pageSize)
+		for _, m := range page.Messages {
+			gotIDs = append(gotIDs, m.Id)
+			s.Equal(clientID, m.AuthorId)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		cursor = page.NextCursor
+		s.Require().Less(pages, 10, "pagination does not end")
+	}
+
+	s.Equal(3, pages)
+	s.Equal(expIDs, gotIDs)
+
+	invalid := "invalid"
+	_, err := client.SearchMessages(s.Ctx, chatclient.SearchMessagesRequest{Query: "карта", Cursor: &invalid})
+	s.True(chatclient.IsStatus(err, http.StatusBadRequest), err)
+
+	s.Run("manager searches chats of own problems", func() {
+		// Чат, назначенный другому менеджеру, не находится.
+		foreignClientID := types.NewUserID()
+		foreignChatID := s.createChat(foreignClientID, types.NewUserID())
+		s.addMessage(foreignChatID, foreignClientID, "не работает карта", start)
+
+		pageSize := 100
+		page, err := manager.ManagerSearchMessages(s.Ctx, chatclient.SearchMessagesRequest{Query: "карта", PageSize: &pageSize})
+		s.Require().NoError(err)
+
+		chats := make(map[types.ChatID]int)
+		for _, m := range page.Messages {
+			chats[m.ChatId]++
+		}
+		s.Equal(map[types.ChatID]int{chatID: 5, otherChatID: 1}, chats)
+
+		_, err = client.ManagerSearchMessages(s.Ctx, chatclient.SearchMessagesRequest{Query: "карта"})
+		s.True(chatclient.IsStatus(err, http.StatusUnauthorized), err)
+	})
+}
+
+func (s *E2ESuite) TestAuthFailure() {
+	_, token, client := s.newUser(clientResource, clientRole)
+	_, _, manager := s.newUser(managerResource, managerRole)
+
+	s.Run("no token", func() {
+		anonymous, err := chatclient.New(chatclient.NewOptions(s.app.clientURL))
+		s.Require().NoError(err)
+
+		_, err = anonymous.GetHistory(s.Ctx, chatclient.GetHistoryRequest{})
+		s.True(chatclient.IsStatus(err, http.StatusBadRequest), err)
+	})
+
+	s.Run("client token on manager route", func() {
+		_, err := client.GetAuditEvents(s.Ctx, chatclient.GetAuditEventsRequest{})
+		s.True(chatclient.IsStatus(err, http.StatusUnauthorized), err)
+	})
+
+	s.Run("revoked token", func() {
+		_, err := client.GetHistory(s.Ctx, chatclient.GetHistoryRequest{})
+		s.Require().NoError(err)
+
+		s.app.introspector.revoke(token)
+
+		_, err = client.GetHistory(s.Ctx, chatclient.GetHistoryRequest{})
+		var apiErr *chatclient.Error
+		s.Require().True(errors.As(err, &apiErr), err)
+		s.Equal(http.StatusUnauthorized, apiErr.StatusCode)
+
+		// Отказ попадает в журнал аудита с идентификатором запроса. Событие пишется в фоне.
+		action := chatclient.AuditAction("auth_failed")
+		var target *string
+		s.Require().Eventually(func() bool {
+			page, err := manager.GetAuditEvents(s.Ctx, chatclient.GetAuditEventsRequest{Action: &action})
+			if err != nil {
+				return false
+			}
+
+			for _, e := range page.Events {
+				if e.RequestId != nil && e.RequestId.String() == apiErr.RequestID {
+					target = e.Target
+					return true
+				}
+			}
+			return false
+		}, time.Second, 10*time.Millisecond, "no audit event for request %s", apiErr.RequestID)
+		s.Require().NotNil(target)
+		s.Equal("route:/v1/getHistory", *target)
+	})
+}
+
+func (s *E2ESuite) TestRateLimit() {
+	// Повторы отключены: клиент иначе ждал бы Retry-After.
+	_, _, client := s.newUser(clientResource, clientRole, chatclient.WithMaxRetries(0))
+	_, _, other := s.newUser(clientResource, clientRole, chatclient.WithMaxRetries(0))
+
+	// Вложения нет, но до обработчика дело доходит: лимит проверяется раньше.
+	req := chatclient.GetAttachmentRequest{Id: types.NewAttachmentID()}
+	for range attachmentBurst {
+		_, err := client.GetAttachment(s.Ctx, req)
+		s.True(chatclient.IsStatus(err, http.StatusNotFound), err)
+	}
+
+	_, err := client.GetAttachment(s.Ctx, req)
+	s.True(chatclient.IsStatus(err, http.StatusTooManyRequests), err)
+
+	// Корзины у каждого пользователя свои.
+	_, err = other.GetAttachment(s.Ctx, req)
+	s.True(chatclient.IsStatus(err, http.StatusNotFound), err)
+
+	// Другие операции ограничиваются своей политикой.
+	_, err = client.GetHistory(s.Ctx, chatclient.GetHistoryRequest{})
+	s.NoError(err)
+}
+
+// dialRealtime открывает WebSocket-соединение так же, как браузер: токен передается в подпротоколе.
+func (s *E2ESuite) dialRealtime(path, token string) *websocket.Conn {
+	s.T().Helper()
+
+	dialer := websocket.Dialer{
+		Subprotocols:     []string{websocketstream.Protocol, token},
+		HandshakeTimeout: time.Second,
+	}
+	header := http.Header{"Origin": []string{"http://localhost:3000"}}
+	ws, resp, err := dialer.DialContext(s.Ctx, "ws"+strings.TrimPrefix(s.app.clientURL, "http")+path, header)
+	s.Require().NoError(err)
+	_ = resp.Body.Close()
+	s.T().Cleanup(func() { _ = ws.Close() })
+
+	s.Equal(websocketstream.Protocol, ws.Subprotocol())
+	return ws
+}
+
+func (s *E2ESuite) readFrame(ws *websocket.Conn) map[string]any {
+	s.T().Helper()
+
+	s.Require().NoError(ws.SetReadDeadline(time.Now().Add(time.Second)))
+	var frame map[string]any
+	s.Require().NoError(ws.ReadJSON(&frame))
+	return frame
+}
+
+// realtimeConnections возвращает количество realtime-подключений пользователей по данным отладочного сервера.
+func (s *E2ESuite) realtimeConnections() map[types.UserID]int {
+	s.T().Helper()
+
+	var realtime []struct {
+		UserID      types.UserID `json:"user_id"`
+		Connections int          `json:"connections"`
+	}
+	s.getDebugJSON("/debug/realtime", &realtime)
+
+	res := make(map[types.UserID]int, len(realtime))
+	for _, c := range realtime {
+		res[c.UserID] = c.Connections
+	}
+	return res
+}
+
+func (s *E2ESuite) getDebugJSON(path string, v any) {
+	s.T().Helper()
+
+	req, err := http.NewRequestWithContext(s.Ctx, http.MethodGet, s.app.debugURL+path, nil)
+	s.Require().NoError(err)
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer resp.Body.Close()
+
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().NoError(json.NewDecoder(resp.Body).Decode(v))
+}
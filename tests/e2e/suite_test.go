@@ -0,0 +1,91 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/FischukSergey/chat-service/internal/store"
+	"github.com/FischukSergey/chat-service/internal/store/problem"
+	"github.com/FischukSergey/chat-service/internal/testingh"
+	"github.com/FischukSergey/chat-service/internal/types"
+	"github.com/FischukSergey/chat-service/pkg/chatclient"
+)
+
+// E2ESuite поднимает сервис один раз на весь набор. Тесты не мешают друг другу,
+// потому что каждый работает со своими пользователями.
+type E2ESuite struct {
+	testingh.ContextSuite
+
+	app *app
+}
+
+func TestE2ESuite(t *testing.T) {
+	suite.Run(t, new(E2ESuite))
+}
+
+func (s *E2ESuite) SetupSuite() {
+	s.ContextSuite.SetupSuite()
+	s.app = startApp(s.SuiteCtx, s.T())
+}
+
+func (s *E2ESuite) TearDownSuite() {
+	s.app.stop(s.T())
+	s.ContextSuite.TearDownSuite()
+}
+
+// newUser выдает токен новому пользователю и возвращает клиент API от его имени.
+func (s *E2ESuite) newUser(
+	resource, role string,
+	opts ...chatclient.OptOptionsSetter,
+) (types.UserID, string, *chatclient.Client) {
+	s.T().Helper()
+
+	userID := types.NewUserID()
+	token, err := s.app.introspector.issue(userID, resource, role)
+	s.Require().NoError(err)
+
+	return userID, token, s.newClient(token, opts...)
+}
+
+func (s *E2ESuite) newClient(token string, opts ...chatclient.OptOptionsSetter) *chatclient.Client {
+	s.T().Helper()
+
+	opts = append([]chatclient.OptOptionsSetter{
+		chatclient.WithToken(chatclient.StaticToken(token)),
+		chatclient.WithHttpClient(&http.Client{Timeout: 5 * time.Second}),
+		chatclient.WithRetryBackoff(10 * time.Millisecond),
+	}, opts...)
+
+	c, err := chatclient.New(chatclient.NewOptions(s.app.clientURL, opts...))
+	s.Require().NoError(err)
+	return c
+}
+
+// createChat создает чат клиента с открытой проблемой, назначенной менеджеру.
+func (s *E2ESuite) createChat(clientID, managerID types.UserID) types.ChatID {
+	s.T().Helper()
+
+	chat := s.app.store.Chat.Create().SetClientID(clientID).SaveX(s.Ctx)
+	s.app.store.Problem.Create().
+		SetChatID(chat.ID).
+		SetManagerID(managerID).
+		SetStatus(problem.StatusInProgress).
+		SaveX(s.Ctx)
+	return chat.ID
+}
+
+func (s *E2ESuite) addMessage(chatID types.ChatID, authorID types.UserID, body string, createdAt time.Time) *store.Message {
+	s.T().Helper()
+
+	return s.app.store.Message.Create().
+		SetChatID(chatID).
+		SetAuthorID(authorID).
+		SetBody(body).
+		SetIsVisibleForClient(true).
+		SetIsVisibleForManager(true).
+		SetCreatedAt(createdAt).
+		SaveX(s.Ctx)
+}